@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// visibleWidth is the number of terminal columns text occupies, ignoring
+// ANSI color codes.
+func visibleWidth(text string) int {
+	return utf8.RuneCountInString(stripColor(text))
+}
+
+// fillWidth returns the spaces that pad text, colored or not, to width
+// visible columns.
+func fillWidth(text string, width int) string {
+	if gap := width - visibleWidth(text); gap > 0 {
+		return strings.Repeat(" ", gap)
+	}
+	return ""
+}
+
+func padRight(text string, width int) string {
+	return text + fillWidth(text, width)
+}
+
+// columnWidths returns the visible width of the widest cell in each column.
+func columnWidths(rows [][]string) []int {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := visibleWidth(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+	return widths
+}
+
+// alignedLine pads every cell to its column width and joins them, with
+// gaps[i] after column i; the last gap repeats for the remaining columns.
+func alignedLine(cells []string, widths []int, gaps ...string) string {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(gaps[min(i-1, len(gaps)-1)])
+		}
+		if i < len(widths) {
+			cell = padRight(cell, widths[i])
+		}
+		line.WriteString(cell)
+	}
+	return strings.TrimRight(line.String(), " ")
+}
+
+// printAligned writes rows as columns padded to the widest visible cell, so
+// cells may carry colors without breaking alignment.
+func printAligned(w io.Writer, rows [][]string, gaps ...string) {
+	widths := columnWidths(rows)
+	for _, row := range rows {
+		fmt.Fprintln(w, alignedLine(row, widths, gaps...))
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	assert.Equal(t, 4, visibleWidth("prod"))
+	assert.Equal(t, 4, visibleWidth(colorRed+"prod"+colorReset))
+	assert.Equal(t, 6, visibleWidth("■ prod"))
+}
+
+func TestPadRight(t *testing.T) {
+	assert.Equal(t, "prod  ", padRight("prod", 6))
+	assert.Equal(t, colorRed+"prod"+colorReset+"  ", padRight(colorRed+"prod"+colorReset, 6))
+	assert.Equal(t, "production", padRight("production", 6))
+}
+
+func TestAlignedLine(t *testing.T) {
+	widths := []int{4, 5, 3}
+	assert.Equal(t, "ctx1  web      1", alignedLine([]string{"ctx1", "web", "1"}, widths, "  ", "    "))
+	assert.Equal(t, "ctx1  web", alignedLine([]string{"ctx1", "web", ""}, widths, "  ", "    "))
+	assert.Equal(t, "a   b", alignedLine([]string{"a", "b"}, []int{1}, "   "))
+}
+
+func TestPrintAlignedColoredCells(t *testing.T) {
+	var out bytes.Buffer
+	printAligned(&out, [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web", colorGreen + "Running" + colorReset, "5m"},
+		{"db", colorRed + "Error" + colorReset, "12d"},
+	}, "    ")
+	assert.Equal(t, "NAME    STATUS     AGE\n"+
+		"web     "+colorGreen+"Running"+colorReset+"    5m\n"+
+		"db      "+colorRed+"Error"+colorReset+"      12d\n", out.String())
+}
+
+func TestPrintContextTableColoredContexts(t *testing.T) {
+	colorMode = "always"
+	noHeaders = false
+	t.Cleanup(func() { colorMode = "auto" })
+
+	var out bytes.Buffer
+	printContextTable(&out, []string{"CONTEXT", "RESULT"}, [][]string{{"prod", "OK"}, {"staging-eu", "ERROR"}})
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Equal(t, "CONTEXT     RESULT", string(lines[0]))
+	assert.Equal(t, "prod        OK", stripColor(string(lines[1])))
+	assert.Equal(t, "staging-eu  ERROR", stripColor(string(lines[2])))
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:                "apply",
+	Short:              "Run kubectl apply against all contexts",
+	Long:               `Run kubectl apply against all contexts in parallel after confirming the target contexts (skip with --yes), then print per-context created/configured/unchanged counts.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfirmedCommand("apply", args)
+	},
+}
+
+type applyCounts struct {
+	created    int
+	configured int
+	unchanged  int
+}
+
+// countApplyResults tallies kubectl apply's "<kind>/<name> <action>" lines.
+func countApplyResults(output string) applyCounts {
+	var counts applyCounts
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSuffix(line, " (server dry run)")
+		line = strings.TrimSuffix(line, " (dry run)")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[len(fields)-1] {
+		case "created":
+			counts.created++
+		case "configured", "serverside-applied":
+			counts.configured++
+		case "unchanged":
+			counts.unchanged++
+		}
+	}
+	return counts
+}
+
+func formatApplyOutput(results []contextResult) error {
+	var rows [][]string
+	failed := 0
+	for _, result := range results {
+		status := "OK"
+		if result.err != nil {
+			status = "ERROR"
+			failed++
+			printContextError(result)
+		}
+		counts := countApplyResults(result.output)
+		rows = append(rows, []string{
+			result.context,
+			strconv.Itoa(counts.created),
+			strconv.Itoa(counts.configured),
+			strconv.Itoa(counts.unchanged),
+			status,
+		})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "CREATED", "CONFIGURED", "UNCHANGED", "RESULT"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts failed", failed, len(results))
+	}
+	return nil
+}
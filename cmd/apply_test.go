@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyCmd(t *testing.T) {
+	require.NotNil(t, applyCmd)
+	assert.Equal(t, "apply", applyCmd.Use)
+	assert.True(t, applyCmd.DisableFlagParsing)
+}
+
+func TestCountApplyResults(t *testing.T) {
+	output := "namespace/web unchanged\n" +
+		"deployment.apps/web configured\n" +
+		"service/web created\n" +
+		"configmap/web created (server dry run)\n" +
+		"Warning: resource is missing the last-applied annotation\n"
+	assert.Equal(t, applyCounts{created: 2, configured: 1, unchanged: 1}, countApplyResults(output))
+	assert.Equal(t, applyCounts{}, countApplyResults(""))
+}
+
+func TestFormatApplyOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "service/web created\ndeployment.apps/web configured\n"},
+		{context: "ctx2", output: "error: unable to recognize", err: fmt.Errorf("exit status 1")},
+	}
+	var err error
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			err = formatApplyOutput(results)
+		})
+	})
+	require.Error(t, err)
+	assert.Equal(t, "CONTEXT  CREATED    CONFIGURED    UNCHANGED    RESULT\n"+
+		"ctx1     1          1             0            OK\n"+
+		"ctx2     0          0             0            ERROR\n", output)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import "strings"
+
+// Subcommands disable cobra's flag parsing so that kubectl flags pass through
+// untouched. The helpers below pull kubectl-x specific flags out of those
+// argument lists before they are forwarded.
+
+// extractBoolFlag removes every occurrence of the named flags from args and
+// reports whether any was present. "--name=false" is honored.
+func extractBoolFlag(args []string, names ...string) (bool, []string) {
+	found := false
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		matched := false
+		for _, name := range names {
+			if arg == name {
+				found = true
+				matched = true
+				break
+			}
+			if value, ok := strings.CutPrefix(arg, name+"="); ok {
+				found = value != "false"
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			remaining = append(remaining, arg)
+		}
+	}
+	return found, remaining
+}
+
+// extractStringFlag removes every occurrence of the named flags, in either
+// "--name value" or "--name=value" form, and returns their values in order.
+func extractStringFlag(args []string, names ...string) ([]string, []string) {
+	var values []string
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		matched := false
+		for _, name := range names {
+			if arg == name {
+				if i+1 < len(args) {
+					values = append(values, args[i+1])
+					i++
+				}
+				matched = true
+				break
+			}
+			if value, ok := strings.CutPrefix(arg, name+"="); ok {
+				values = append(values, value)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			remaining = append(remaining, arg)
+		}
+	}
+	return values, remaining
+}
+
+// rewriteOutputFormat applies fn to the value of every -o/--output flag,
+// whichever form it was written in.
+func rewriteOutputFormat(args []string, fn func(format string) string) []string {
+	rewritten := make([]string, len(args))
+	copy(rewritten, args)
+	for i := 0; i < len(rewritten); i++ {
+		arg := rewritten[i]
+		switch {
+		case arg == "-o" || arg == "--output":
+			if i+1 < len(rewritten) {
+				rewritten[i+1] = fn(rewritten[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "--output="):
+			rewritten[i] = "--output=" + fn(strings.TrimPrefix(arg, "--output="))
+		case strings.HasPrefix(arg, "-o") && len(arg) > 2 && !strings.HasPrefix(arg, "--"):
+			rewritten[i] = "-o" + fn(strings.TrimPrefix(arg, "-o"))
+		}
+	}
+	return rewritten
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractBoolFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		found     bool
+		remaining []string
+	}{
+		{
+			name:      "flag absent",
+			args:      []string{"restart", "deploy/app"},
+			flags:     []string{"--yes", "-y"},
+			found:     false,
+			remaining: []string{"restart", "deploy/app"},
+		},
+		{
+			name:      "long flag",
+			args:      []string{"restart", "--yes", "deploy/app"},
+			flags:     []string{"--yes", "-y"},
+			found:     true,
+			remaining: []string{"restart", "deploy/app"},
+		},
+		{
+			name:      "short flag",
+			args:      []string{"restart", "deploy/app", "-y"},
+			flags:     []string{"--yes", "-y"},
+			found:     true,
+			remaining: []string{"restart", "deploy/app"},
+		},
+		{
+			name:      "explicit false",
+			args:      []string{"restart", "--yes=false"},
+			flags:     []string{"--yes", "-y"},
+			found:     false,
+			remaining: []string{"restart"},
+		},
+		{
+			name:      "similar flag is not matched",
+			args:      []string{"restart", "--yesterday"},
+			flags:     []string{"--yes"},
+			found:     false,
+			remaining: []string{"restart", "--yesterday"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, remaining := extractBoolFlag(tt.args, tt.flags...)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.remaining, remaining)
+		})
+	}
+}
+
+func TestExtractStringFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		values    []string
+		remaining []string
+	}{
+		{
+			name:      "flag absent",
+			args:      []string{"undo", "deploy/app"},
+			flags:     []string{"--revisions"},
+			values:    nil,
+			remaining: []string{"undo", "deploy/app"},
+		},
+		{
+			name:      "separate value",
+			args:      []string{"undo", "--revisions", "ctx1=2", "deploy/app"},
+			flags:     []string{"--revisions"},
+			values:    []string{"ctx1=2"},
+			remaining: []string{"undo", "deploy/app"},
+		},
+		{
+			name:      "equals value",
+			args:      []string{"undo", "deploy/app", "--revisions=ctx1=2"},
+			flags:     []string{"--revisions"},
+			values:    []string{"ctx1=2"},
+			remaining: []string{"undo", "deploy/app"},
+		},
+		{
+			name:      "repeated flag",
+			args:      []string{"--revisions", "ctx1=2", "--revisions=ctx2=3"},
+			flags:     []string{"--revisions"},
+			values:    []string{"ctx1=2", "ctx2=3"},
+			remaining: []string{},
+		},
+		{
+			name:      "flag without value at end",
+			args:      []string{"undo", "--revisions"},
+			flags:     []string{"--revisions"},
+			values:    nil,
+			remaining: []string{"undo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, remaining := extractStringFlag(tt.args, tt.flags...)
+			assert.Equal(t, tt.values, values)
+			assert.Equal(t, tt.remaining, remaining)
+		})
+	}
+}
+
+func TestRewriteOutputFormat(t *testing.T) {
+	upper := func(format string) string { return strings.ToUpper(format) }
+	assert.Equal(t, []string{"pods", "-o", "WIDE"}, rewriteOutputFormat([]string{"pods", "-o", "wide"}, upper))
+	assert.Equal(t, []string{"pods", "--output", "NAME"}, rewriteOutputFormat([]string{"pods", "--output", "name"}, upper))
+	assert.Equal(t, []string{"pods", "-oJSON"}, rewriteOutputFormat([]string{"pods", "-ojson"}, upper))
+	assert.Equal(t, []string{"pods", "--output=YAML"}, rewriteOutputFormat([]string{"pods", "--output=yaml"}, upper))
+	assert.Equal(t, []string{"pods", "--other=x", "-n", "web"}, rewriteOutputFormat([]string{"pods", "--other=x", "-n", "web"}, upper))
+
+	args := []string{"pods", "-o", "json"}
+	rewriteOutputFormat(args, upper)
+	assert.Equal(t, "json", args[2])
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var attachCmd = &cobra.Command{
+	Use:                "attach",
+	Short:              "Attach read-only to a pod in every context",
+	Long:               `Run kubectl attach against the same pod in all contexts and stream its output with a context prefix, like logs -f. Attaching is read-only; -i/-t are not supported across contexts.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if isInteractiveExec(args) {
+			return fmt.Errorf("attach is read-only across contexts; -i/-t are not supported")
+		}
+		return runStreamingCommand("attach", args, false)
+	},
+}
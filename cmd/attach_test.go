@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachCmd(t *testing.T) {
+	require.NotNil(t, attachCmd)
+	assert.Equal(t, "attach", attachCmd.Use)
+	assert.True(t, attachCmd.DisableFlagParsing)
+}
+
+func TestAttachCmdRejectsInteractive(t *testing.T) {
+	err := attachCmd.RunE(attachCmd, []string{"-it", "my-pod"})
+	assert.ErrorContains(t, err, "read-only")
+}
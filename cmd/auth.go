@@ -1,15 +1,86 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
 var authCmd = &cobra.Command{
 	Use:                "auth",
 	Short:              "Run kubectl auth subcommands against all contexts",
-	Long:               `Run kubectl auth subcommands (e.g. whoami, can-i) against all contexts in parallel.`,
+	Long:               `Run kubectl auth subcommands (e.g. whoami, can-i) against all contexts in parallel. "auth can-i" prints a CONTEXT / ALLOWED table.`,
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && args[0] == "can-i" && !hasListFlag(args) {
+			return runCanI(args)
+		}
 		return runCommand("auth", args)
 	},
 }
+
+// hasListFlag reports whether can-i was asked for its full permission table,
+// which is merged like any other table output.
+func hasListFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--list" || arg == "--list=true" {
+			return true
+		}
+	}
+	return false
+}
+
+func runCanI(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	results := runAcrossContexts(contexts, "auth", args)
+	return formatCanIOutput(results)
+}
+
+// canIAnswer interprets kubectl auth can-i, which prints "yes" or "no" and
+// exits 1 for "no" (or with --quiet prints nothing and only sets the exit code).
+func canIAnswer(result contextResult) (string, bool) {
+	answer := ""
+	if output := strings.TrimSpace(result.output); output != "" {
+		answer = strings.Fields(lastLine(output))[0]
+	}
+	switch {
+	case answer == "yes" && result.err == nil:
+		return "yes", true
+	case answer == "no":
+		return "no", true
+	case answer == "" && result.err == nil:
+		return "yes", true
+	case answer == "" && exitCode(result.err) == 1:
+		return "no", true
+	}
+	return "", false
+}
+
+func formatCanIOutput(results []contextResult) error {
+	var rows [][]string
+	var answered []contextResult
+	for _, result := range results {
+		answer, ok := canIAnswer(result)
+		if !ok {
+			answer = "ERROR"
+			printContextError(result)
+		} else if result.err != nil {
+			// kubectl exits 1 when the answer is no.
+			answered = append(answered, result)
+		}
+		if ok && onlyErrors {
+			continue
+		}
+		rows = append(rows, []string{result.context, answer})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "ALLOWED"}, rows)
+	recordSucceeded(answered)
+	return nil
+}
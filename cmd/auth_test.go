@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,3 +13,57 @@ func TestAuthCmd(t *testing.T) {
 	assert.Equal(t, "auth", authCmd.Use)
 	assert.True(t, authCmd.DisableFlagParsing)
 }
+
+func TestHasListFlag(t *testing.T) {
+	assert.False(t, hasListFlag([]string{"can-i", "get", "pods"}))
+	assert.True(t, hasListFlag([]string{"can-i", "--list"}))
+}
+
+func TestCanIAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   contextResult
+		expected string
+		ok       bool
+	}{
+		{name: "yes", result: contextResult{output: "yes\n"}, expected: "yes", ok: true},
+		{name: "no exits non-zero", result: contextResult{output: "no\n", err: exitStatus(t, 1)}, expected: "no", ok: true},
+		{name: "no with reason", result: contextResult{output: "no - RBAC: access denied\n", err: exitStatus(t, 1)}, expected: "no", ok: true},
+		{name: "warning before answer", result: contextResult{output: "Warning: resource 'widgets' is not namespace scoped\nyes\n"}, expected: "yes", ok: true},
+		{name: "quiet yes", result: contextResult{}, expected: "yes", ok: true},
+		{name: "quiet no", result: contextResult{err: exitStatus(t, 1)}, expected: "no", ok: true},
+		{name: "connection error", result: contextResult{output: "error: You must be logged in to the server\n", err: exitStatus(t, 1)}, ok: false},
+		{name: "kubectl missing", result: contextResult{err: fmt.Errorf("executable file not found")}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer, ok := canIAnswer(tt.result)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, answer)
+		})
+	}
+}
+
+func TestFormatCanIOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "yes\n"},
+		{context: "ctx2", output: "no\n", err: exitStatus(t, 1)},
+		{context: "ctx3", output: "error: You must be logged in to the server\n", err: exitStatus(t, 1)},
+	}
+	resetRecordedResults()
+	t.Cleanup(resetRecordedResults)
+	recordResults(results)
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, formatCanIOutput(results))
+		})
+	})
+	assert.Equal(t, "CONTEXT  ALLOWED\nctx1     yes\nctx2     no\nctx3     ERROR\n", output)
+	assert.Contains(t, stderr, "ctx3")
+
+	recorded := recordedResults()
+	assert.NoError(t, recorded[1].err, "no is an answer, not a failure")
+	assert.Error(t, recorded[2].err)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// extractCanary removes --canary from args and returns its last value, or ""
+// when the flag is absent.
+func extractCanary(args []string) (string, []string) {
+	canaries, args := extractStringFlag(args, "--canary")
+	if len(canaries) == 0 {
+		return "", args
+	}
+	return canaries[len(canaries)-1], args
+}
+
+// splitCanary divides contexts into the canary wave and the rest. spec is
+// either a count of contexts to take from the front or a case-insensitive
+// regex selecting the canary contexts by name.
+func splitCanary(contexts []string, spec string) ([]string, []string, error) {
+	if n, err := strconv.Atoi(spec); err == nil {
+		if n < 1 {
+			return nil, nil, fmt.Errorf("--canary count must be at least 1")
+		}
+		if n > len(contexts) {
+			n = len(contexts)
+		}
+		return contexts[:n], contexts[n:], nil
+	}
+
+	regex, err := regexp.Compile("(?i)" + spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --canary pattern %q: %w", spec, err)
+	}
+	var canary, rest []string
+	for _, ctx := range contexts {
+		if regex.MatchString(ctx) {
+			canary = append(canary, ctx)
+		} else {
+			rest = append(rest, ctx)
+		}
+	}
+	if len(canary) == 0 {
+		return nil, nil, fmt.Errorf("--canary %q matches none of the selected contexts", spec)
+	}
+	return canary, rest, nil
+}
+
+// runWithCanary calls run for the canary contexts first, shows how they went,
+// and only continues with the remaining contexts if every canary succeeded
+// and the user confirms (skipped with --yes). Without a spec all contexts run
+// at once. The returned results cover every context that was run.
+func runWithCanary(contexts []string, spec string, yes bool, run func(contexts []string) []contextResult) ([]contextResult, error) {
+	if spec == "" {
+		return run(contexts), nil
+	}
+	canary, rest, err := splitCanary(contexts, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "Canary: running against %s first\n", strings.Join(canary, ", "))
+	results := run(canary)
+
+	var rows [][]string
+	failed := 0
+	for _, result := range results {
+		status := "OK"
+		if result.err != nil {
+			status = "ERROR"
+			failed++
+		}
+		rows = append(rows, []string{result.context, status, lastLine(result.output)})
+	}
+	printContextTable(os.Stderr, []string{"CONTEXT", "CANARY", "MESSAGE"}, rows)
+
+	if failed > 0 {
+		return results, fmt.Errorf("canary failed in %d of %d contexts; %d remaining contexts were not changed", failed, len(canary), len(rest))
+	}
+	if len(rest) == 0 {
+		return results, nil
+	}
+	if !confirm(fmt.Sprintf("Canary succeeded. Continue with the remaining %d contexts?", len(rest)), yes) {
+		return results, fmt.Errorf("stopped after canary; %d remaining contexts were not changed", len(rest))
+	}
+	return append(results, run(rest)...), nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCanary(t *testing.T) {
+	contexts := []string{"staging", "prod-us", "prod-eu"}
+
+	canary, rest, err := splitCanary(contexts, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, canary)
+	assert.Equal(t, []string{"prod-us", "prod-eu"}, rest)
+
+	canary, rest, err = splitCanary(contexts, "10")
+	require.NoError(t, err)
+	assert.Equal(t, contexts, canary)
+	assert.Empty(t, rest)
+
+	canary, rest, err = splitCanary(contexts, "PROD-EU")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-eu"}, canary)
+	assert.Equal(t, []string{"staging", "prod-us"}, rest)
+
+	_, _, err = splitCanary(contexts, "0")
+	assert.Error(t, err)
+	_, _, err = splitCanary(contexts, "dev")
+	assert.ErrorContains(t, err, "matches none")
+	_, _, err = splitCanary(contexts, "(")
+	assert.ErrorContains(t, err, "invalid --canary pattern")
+}
+
+func TestRunWithCanary(t *testing.T) {
+	contexts := []string{"staging", "prod-us", "prod-eu"}
+	var waves [][]string
+	succeed := func(contexts []string) []contextResult {
+		waves = append(waves, contexts)
+		var results []contextResult
+		for _, ctx := range contexts {
+			results = append(results, contextResult{context: ctx, output: "ok"})
+		}
+		return results
+	}
+
+	t.Run("no canary runs everything at once", func(t *testing.T) {
+		waves = nil
+		results, err := runWithCanary(contexts, "", false, succeed)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, [][]string{contexts}, waves)
+	})
+
+	t.Run("continues after confirmation", func(t *testing.T) {
+		waves = nil
+		old := confirmInput
+		confirmInput = strings.NewReader("y\n")
+		t.Cleanup(func() { confirmInput = old })
+
+		var results []contextResult
+		var err error
+		stderr := captureStderr(func() { results, err = runWithCanary(contexts, "staging", false, succeed) })
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, [][]string{{"staging"}, {"prod-us", "prod-eu"}}, waves)
+		assert.Contains(t, stderr, "Canary: running against staging first")
+		assert.Contains(t, stderr, "Continue with the remaining 2 contexts?")
+	})
+
+	t.Run("declined confirmation stops", func(t *testing.T) {
+		waves = nil
+		old := confirmInput
+		confirmInput = strings.NewReader("n\n")
+		t.Cleanup(func() { confirmInput = old })
+
+		var results []contextResult
+		var err error
+		captureStderr(func() { results, err = runWithCanary(contexts, "1", false, succeed) })
+		assert.EqualError(t, err, "stopped after canary; 2 remaining contexts were not changed")
+		assert.Len(t, results, 1)
+		assert.Len(t, waves, 1)
+	})
+
+	t.Run("failed canary never continues", func(t *testing.T) {
+		fail := func(contexts []string) []contextResult {
+			return []contextResult{{context: contexts[0], output: "error: boom", err: fmt.Errorf("exit status 1")}}
+		}
+		var err error
+		stderr := captureStderr(func() { _, err = runWithCanary(contexts, "1", true, fail) })
+		assert.EqualError(t, err, "canary failed in 1 of 1 contexts; 2 remaining contexts were not changed")
+		assert.Regexp(t, `staging\s+ERROR\s+error: boom`, stderr)
+	})
+}
+
+func TestRunConfirmedCommandCanary(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, context+" "+strings.Join(extraArgs, " "))
+		return "deployment.apps/web configured\n", nil
+	})
+
+	var err error
+	captureStderr(func() {
+		captureStdout(func() {
+			err = runConfirmedCommand("apply", []string{"-f", "web.yaml", "--canary", "ctx2", "--yes"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctx2 -f web.yaml", "ctx1 -f web.yaml"}, calls)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// collapsedObject is one object of get --collapse-identical: the contexts it
+// was found in, with the normalized copy each of them has.
+type collapsedObject struct {
+	namespace string
+	name      string
+	contexts  []string
+	copies    []string
+}
+
+// collapseArgs asks kubectl for -o yaml, whose objects are compared, in place
+// of any other output format.
+func collapseArgs(args []string) []string {
+	_, args = extractStringFlag(args, "-o", "--output")
+	return append(args, "-o", "yaml")
+}
+
+// collapsedObjects parses -o yaml output, a single object or a List, into
+// kind/name keyed objects with their volatile fields removed.
+func collapsedObjects(output string) ([]collapsedObject, error) {
+	var object map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &object); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if object == nil {
+		return nil, nil
+	}
+	items := []interface{}{object}
+	if list, ok := object["items"].([]interface{}); ok {
+		items = list
+	}
+
+	var objects []collapsedObject
+	for _, item := range items {
+		item, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		metadata, _ := item["metadata"].(map[string]interface{})
+		kind, _ := item["kind"].(string)
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		normalized, err := normalizedYAML(item)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, collapsedObject{
+			namespace: namespace,
+			name:      strings.ToLower(kind) + "/" + name,
+			copies:    []string{normalized},
+		})
+	}
+	return objects, nil
+}
+
+// collapseObjects merges the objects of every context, in the order they
+// were first seen, into one per namespace and name.
+func collapseObjects(contexts []string, perContext map[string][]collapsedObject) []*collapsedObject {
+	var merged []*collapsedObject
+	byKey := make(map[string]*collapsedObject)
+	for _, context := range contexts {
+		for _, object := range perContext[context] {
+			key := object.namespace + "/" + object.name
+			if !keepRow(key) {
+				continue
+			}
+			entry := byKey[key]
+			if entry == nil {
+				entry = &collapsedObject{namespace: object.namespace, name: object.name}
+				byKey[key] = entry
+				merged = append(merged, entry)
+			}
+			entry.contexts = append(entry.contexts, context)
+			entry.copies = append(entry.copies, object.copies[0])
+		}
+	}
+	return merged
+}
+
+// differing returns the contexts whose copy differs from the most common
+// one. Ties go to the copy of the earliest context.
+func (o *collapsedObject) differing() []string {
+	counts := make(map[string]int)
+	common := ""
+	for _, manifest := range o.copies {
+		counts[manifest]++
+		if counts[manifest] > counts[common] {
+			common = manifest
+		}
+	}
+	var differ []string
+	for i, manifest := range o.copies {
+		if manifest != common {
+			differ = append(differ, o.contexts[i])
+		}
+	}
+	return differ
+}
+
+func runCollapsedCommand(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+	warnNamespaceSkew(contexts, "get", args)
+
+	results := runAcrossContexts(contexts, "get", collapseArgs(args))
+	perContext := make(map[string][]collapsedObject, len(results))
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		objects, err := collapsedObjects(result.output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Context %s: %v\n", colorizeContext(result.context), err)
+			continue
+		}
+		perContext[result.context] = objects
+	}
+	return formatCollapsedOutput(collapseObjects(contexts, perContext))
+}
+
+// formatCollapsedOutput prints one row per object with the contexts it was
+// found in, and those whose copy differs from the rest in DIFFERS.
+func formatCollapsedOutput(objects []*collapsedObject) error {
+	namespaced := false
+	for _, object := range objects {
+		namespaced = namespaced || object.namespace != ""
+	}
+
+	var rows [][]string
+	if !noHeaders {
+		header := []string{"NAME", "CONTEXTS", "DIFFERS"}
+		if namespaced {
+			header = append([]string{"NAMESPACE"}, header...)
+		}
+		rows = append(rows, header)
+	}
+	for _, object := range objects {
+		colored := make([]string, len(object.contexts))
+		for i, context := range object.contexts {
+			colored[i] = colorizeContext(context)
+		}
+		differ := strings.Join(object.differing(), ",")
+		if differ != "" && colorEnabled() {
+			differ = colorYellow + differ + colorReset
+		}
+		row := []string{object.name, strings.Join(colored, ","), differ}
+		if namespaced {
+			row = append([]string{object.namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	printAligned(os.Stdout, rows, "    ")
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collapseList(issuers ...string) string {
+	list := "apiVersion: v1\nkind: List\nitems:\n"
+	for _, issuer := range issuers {
+		list += issuer
+	}
+	return list
+}
+
+func collapseIssuer(name, server, resourceVersion string) string {
+	return "- apiVersion: cert-manager.io/v1\n" +
+		"  kind: ClusterIssuer\n" +
+		"  metadata:\n" +
+		"    name: " + name + "\n" +
+		"    resourceVersion: \"" + resourceVersion + "\"\n" +
+		"  spec:\n" +
+		"    acme:\n" +
+		"      server: " + server + "\n" +
+		"  status:\n" +
+		"    conditions: []\n"
+}
+
+func TestCollapseArgs(t *testing.T) {
+	assert.Equal(t, []string{"clusterissuers", "-o", "yaml"}, collapseArgs([]string{"clusterissuers", "-o", "wide"}))
+}
+
+func TestCollapsedObjects(t *testing.T) {
+	objects, err := collapsedObjects(collapseList(collapseIssuer("letsencrypt", "https://acme", "1")))
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "clusterissuer/letsencrypt", objects[0].name)
+	assert.Equal(t, "", objects[0].namespace)
+	assert.NotContains(t, objects[0].copies[0], "resourceVersion")
+	assert.NotContains(t, objects[0].copies[0], "status")
+
+	objects, err = collapsedObjects("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: flags\n  namespace: payments\n")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "configmap/flags", objects[0].name)
+	assert.Equal(t, "payments", objects[0].namespace)
+
+	objects, err = collapsedObjects("")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestCollapsedObjectDiffering(t *testing.T) {
+	object := collapsedObject{contexts: []string{"a", "b", "c"}, copies: []string{"x", "y", "y"}}
+	assert.Equal(t, []string{"a"}, object.differing())
+	object = collapsedObject{contexts: []string{"a", "b"}, copies: []string{"x", "y"}}
+	assert.Equal(t, []string{"b"}, object.differing())
+	object = collapsedObject{contexts: []string{"a", "b"}, copies: []string{"x", "x"}}
+	assert.Empty(t, object.differing())
+}
+
+func TestRunCollapsedCommand(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu", "staging", "dev"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"clusterissuers", "-o", "yaml"}, extraArgs)
+		switch context {
+		case "prod-us", "prod-eu":
+			return collapseList(collapseIssuer("letsencrypt", "https://acme", context), collapseIssuer("internal-ca", "https://ca", context)), nil
+		case "staging":
+			return collapseList(collapseIssuer("letsencrypt", "https://acme-staging", "9")), nil
+		}
+		return "error: You must be logged in to the server (Unauthorized)", errors.New("exit status 1")
+	})
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, runCollapsedCommand([]string{"clusterissuers"}))
+		})
+	})
+	assert.Equal(t, ""+
+		"NAME                         CONTEXTS                   DIFFERS\n"+
+		"clusterissuer/letsencrypt    prod-us,prod-eu,staging    staging\n"+
+		"clusterissuer/internal-ca    prod-us,prod-eu\n", output)
+	assert.Contains(t, stderr, "Context dev: Error: exit status 1")
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ColorRule assigns a color to the contexts whose name equals Match or, failing
+// any exact match, matches it as a case-insensitive regex.
+type ColorRule struct {
+	Match string `yaml:"match"`
+	Color string `yaml:"color"`
+}
+
+type contextColorRule struct {
+	match string
+	regex *regexp.Regexp
+	code  string
+}
+
+var contextColorRules []contextColorRule
+
+var namedColors = map[string]string{
+	"red":            colorRed,
+	"green":          colorGreen,
+	"yellow":         colorYellow,
+	"blue":           colorBlue,
+	"magenta":        colorPurple,
+	"cyan":           colorCyan,
+	"white":          colorWhite,
+	"gray":           colorGray,
+	"bright-red":     "\033[91m",
+	"bright-green":   "\033[92m",
+	"bright-yellow":  "\033[93m",
+	"bright-blue":    "\033[94m",
+	"bright-magenta": "\033[95m",
+	"bright-cyan":    "\033[96m",
+	"bright-white":   "\033[97m",
+}
+
+func colorNames() string {
+	var names []string
+	for name := range namedColors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func compileColorRules(rules []ColorRule) ([]contextColorRule, error) {
+	var compiled []contextColorRule
+	for _, rule := range rules {
+		code, ok := parseColor(rule.Color)
+		if !ok {
+			return nil, fmt.Errorf("invalid color %q for %q in config: must be a 256-color index, a #rrggbb value, or one of %s", rule.Color, rule.Match, colorNames())
+		}
+		regex, err := regexp.Compile("(?i)" + rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color match %q in config: %w", rule.Match, err)
+		}
+		compiled = append(compiled, contextColorRule{match: rule.Match, regex: regex, code: code})
+	}
+	return compiled, nil
+}
+
+// configuredColor returns the color the config assigns to a context: an exact
+// name wins over patterns, and patterns apply in the order they are listed.
+func configuredColor(context string) (string, bool) {
+	for _, rule := range contextColorRules {
+		if rule.match == context {
+			return rule.code, true
+		}
+	}
+	for _, rule := range contextColorRules {
+		if rule.regex.MatchString(context) {
+			return rule.code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setColorRules(t *testing.T, rules []ColorRule) {
+	t.Helper()
+	compiled, err := compileColorRules(rules)
+	require.NoError(t, err)
+	contextColorRules = compiled
+	t.Cleanup(func() { contextColorRules = nil })
+}
+
+func TestCompileColorRules(t *testing.T) {
+	_, err := compileColorRules([]ColorRule{{Match: "prod", Color: "purple"}})
+	assert.ErrorContains(t, err, `invalid color "purple" for "prod"`)
+
+	_, err = compileColorRules([]ColorRule{{Match: "prod(", Color: "red"}})
+	assert.ErrorContains(t, err, `invalid color match "prod("`)
+}
+
+func TestConfiguredColor(t *testing.T) {
+	setColorRules(t, []ColorRule{
+		{Match: "prod", Color: "red"},
+		{Match: "^staging", Color: "Yellow"},
+		{Match: "prod-canary", Color: "green"},
+	})
+
+	code, ok := configuredColor("eu-PROD-1")
+	assert.True(t, ok)
+	assert.Equal(t, colorRed, code)
+
+	code, _ = configuredColor("prod-canary")
+	assert.Equal(t, colorGreen, code, "exact names win over earlier patterns")
+
+	code, _ = configuredColor("staging-us")
+	assert.Equal(t, colorYellow, code)
+
+	_, ok = configuredColor("dev")
+	assert.False(t, ok)
+}
+
+func TestColorizeContextUsesConfiguredColor(t *testing.T) {
+	colorMode = "always"
+	t.Cleanup(func() { colorMode = "auto" })
+	setColorRules(t, []ColorRule{{Match: "prod", Color: "red"}})
+
+	assert.Equal(t, colorRed+"prod-us"+colorReset, colorizeContext("prod-us"))
+}
+
+func TestLoadAndApplyConfigColors(t *testing.T) {
+	resetConfigState(t)
+	t.Cleanup(func() { contextColorRules = nil })
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, "colors:\n  - match: prod\n    color: red\n"))
+
+	require.NoError(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil))
+	code, ok := configuredColor("prod")
+	assert.True(t, ok)
+	assert.Equal(t, colorRed, code)
+
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, "colors:\n  - match: prod\n    color: pink\n"))
+	assert.ErrorContains(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil), `invalid color "pink"`)
+}
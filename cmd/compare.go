@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var compareCmd = &cobra.Command{
+	Use:                "compare",
+	Short:              "Compare one resource across contexts",
+	Long:               `Fetch one resource, e.g. kubectl x compare deployment/web -n payments, from every context as YAML and diff the copies, ignoring fields that differ between any two live objects such as status, resourceVersion, and managedFields. Each context is compared with the first one that has the resource, or with --baseline; --pairwise compares every pair instead. Exits 1 if any copy differs and 2 if any context failed.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompare(args)
+	},
+}
+
+const (
+	compareBaseline  = "BASELINE"
+	compareSame      = "SAME"
+	compareDifferent = "DIFFERENT"
+	compareNotFound  = "NOT FOUND"
+	compareError     = "ERROR"
+)
+
+var compareColors = map[string]string{
+	compareSame:      colorGreen,
+	compareDifferent: colorYellow,
+	compareNotFound:  colorYellow,
+	compareError:     colorRed,
+}
+
+// volatileFields are removed before comparing, as they differ between any
+// two live copies of an object.
+var volatileFields = [][]string{
+	{"status"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "managedFields"},
+	{"metadata", "selfLink"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "annotations", "deployment.kubernetes.io/revision"},
+}
+
+// comparedObject is a context's copy of the resource, or the status saying
+// why there is none.
+type comparedObject struct {
+	yaml   string
+	status string
+	reason string
+}
+
+// deleteField removes path from object, along with the maps it leaves empty.
+func deleteField(object map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(object, path[0])
+		return
+	}
+	child, ok := object[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	deleteField(child, path[1:])
+	if len(child) == 0 {
+		delete(object, path[0])
+	}
+}
+
+// normalizeObject drops the volatile fields from a YAML object and prints it
+// again with sorted keys, so that copies only differ where their
+// configuration does.
+func normalizeObject(manifest string) (string, error) {
+	var object map[string]interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &object); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if _, ok := object["items"]; ok {
+		return "", fmt.Errorf("compare takes a single resource, e.g. deployment/web, not a list")
+	}
+	return normalizedYAML(object)
+}
+
+// normalizedYAML drops the volatile fields from object and prints the rest.
+func normalizedYAML(object map[string]interface{}) (string, error) {
+	for _, path := range volatileFields {
+		deleteField(object, path)
+	}
+
+	var normalized strings.Builder
+	encoder := yaml.NewEncoder(&normalized)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(object); err != nil {
+		return "", fmt.Errorf("failed to print YAML: %w", err)
+	}
+	encoder.Close()
+	// difflib.SplitLines adds a line after a final newline.
+	return strings.TrimSuffix(normalized.String(), "\n"), nil
+}
+
+func compareObject(result contextResult) comparedObject {
+	if result.err != nil {
+		if strings.Contains(result.output, "(NotFound)") {
+			return comparedObject{status: compareNotFound}
+		}
+		return comparedObject{status: compareError, reason: lastLine(result.output)}
+	}
+	// --ignore-not-found-types leaves a missing resource type empty.
+	if strings.TrimSpace(result.output) == "" {
+		return comparedObject{status: compareNotFound}
+	}
+	normalized, err := normalizeObject(result.output)
+	if err != nil {
+		return comparedObject{status: compareError, reason: err.Error()}
+	}
+	return comparedObject{yaml: normalized}
+}
+
+func compareStatus(status string) string {
+	if color := compareColors[status]; color != "" && colorEnabled() {
+		return color + status + colorReset
+	}
+	return status
+}
+
+// printObjectDiff prints a unified diff, colored like git's, followed by a
+// blank line.
+func printObjectDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		color := ""
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			color = colorGreen
+		case strings.HasPrefix(line, "-"):
+			color = colorRed
+		case strings.HasPrefix(line, "@@"):
+			color = colorCyan
+		}
+		if color != "" && colorEnabled() {
+			line = color + line + colorReset
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func runCompare(args []string) error {
+	pairwise, args := extractBoolFlag(args, "--pairwise")
+	baselines, args := extractStringFlag(args, "--baseline")
+	if len(args) == 0 {
+		return fmt.Errorf("compare requires a resource, e.g. deployment/web")
+	}
+	baseline := ""
+	if len(baselines) > 0 {
+		baseline = baselines[len(baselines)-1]
+	}
+	if pairwise && baseline != "" {
+		return fmt.Errorf("--pairwise cannot be combined with --baseline")
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+	if baseline != "" && !slices.Contains(contexts, baseline) {
+		return fmt.Errorf("baseline context %q is not one of the selected contexts", baseline)
+	}
+	warnNamespaceSkew(contexts, "get", args)
+
+	getArgs := append(append([]string{}, args...), "-o", "yaml")
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		return runKubectlCommand(context, "get", getArgs)
+	})
+
+	objects := make(map[string]comparedObject, len(results))
+	var failures []contextResult
+	for _, result := range results {
+		object := compareObject(result)
+		if object.status == compareError {
+			failures = append(failures, contextResult{context: result.context, err: errors.New(object.reason)})
+			if !onlyErrors {
+				fmt.Fprintf(os.Stderr, "Context %s: %s\n", colorizeContext(result.context), object.reason)
+			}
+		}
+		objects[result.context] = object
+	}
+	failed := len(failures)
+
+	var differ error
+	switch {
+	case onlyErrors:
+		if err := formatOnlyErrors(failures); err != nil {
+			return err
+		}
+	case pairwise:
+		if pairs := comparePairwise(contexts, objects); pairs > 0 {
+			differ = fmt.Errorf("%d pairs of contexts differ", pairs)
+		}
+	default:
+		if baseline == "" {
+			baseline = firstCompared(contexts, objects)
+		}
+		if err := checkBaseline(baseline, objects); err != nil {
+			return err
+		}
+		if count := compareToBaseline(contexts, objects, baseline); count > 0 {
+			differ = fmt.Errorf("%d of %d contexts differ from %s", count, len(contexts)-1, baseline)
+		}
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: 2, Err: fmt.Errorf("compare failed in %d of %d contexts", failed, len(contexts))}
+	}
+	if differ != nil {
+		return &ExitError{Code: 1, Err: differ}
+	}
+	return nil
+}
+
+// firstCompared returns the first context that has the resource.
+func firstCompared(contexts []string, objects map[string]comparedObject) string {
+	for _, context := range contexts {
+		if objects[context].status == "" {
+			return context
+		}
+	}
+	return ""
+}
+
+func checkBaseline(baseline string, objects map[string]comparedObject) error {
+	switch {
+	case baseline == "":
+		return fmt.Errorf("no context has the resource to compare")
+	case objects[baseline].status == compareNotFound:
+		return fmt.Errorf("baseline context %s does not have the resource", baseline)
+	case objects[baseline].status != "":
+		return fmt.Errorf("failed to get the resource from baseline context %s", baseline)
+	}
+	return nil
+}
+
+// compareToBaseline diffs every context's copy against the baseline's and
+// returns how many contexts differ, counting those without a copy.
+func compareToBaseline(contexts []string, objects map[string]comparedObject, baseline string) int {
+	base := objects[baseline]
+
+	var rows [][]string
+	differ := 0
+	for _, context := range contexts {
+		object := objects[context]
+		status := object.status
+		switch {
+		case context == baseline:
+			status = compareBaseline
+		case status == compareNotFound:
+			differ++
+		case status == "":
+			status = compareSame
+			if diff := unifiedDiff(base.yaml, object.yaml, baseline, context); diff != "" {
+				status = compareDifferent
+				differ++
+				printObjectDiff(diff)
+			}
+		}
+		rows = append(rows, []string{context, compareStatus(status)})
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "RESULT"}, rows)
+
+	return differ
+}
+
+// comparePairwise diffs every pair of contexts that have the resource,
+// prints a matrix of the results, and returns how many pairs differ.
+func comparePairwise(contexts []string, objects map[string]comparedObject) int {
+	differ := 0
+	different := make(map[[2]string]bool)
+	for i, from := range contexts {
+		for _, to := range contexts[i+1:] {
+			if objects[from].status != "" || objects[to].status != "" {
+				continue
+			}
+			if diff := unifiedDiff(objects[from].yaml, objects[to].yaml, from, to); diff != "" {
+				different[[2]string{from, to}] = true
+				different[[2]string{to, from}] = true
+				differ++
+				printObjectDiff(diff)
+			}
+		}
+	}
+
+	header := []string{"CONTEXT"}
+	var rows [][]string
+	for _, from := range contexts {
+		header = append(header, from)
+		row := []string{from}
+		for _, to := range contexts {
+			status := objects[from].status
+			if status == "" {
+				status = objects[to].status
+			}
+			switch {
+			case from == to:
+				status = "-"
+			case status != "":
+			case different[[2]string{from, to}]:
+				status = compareDifferent
+			default:
+				status = compareSame
+			}
+			row = append(row, compareStatus(status))
+		}
+		rows = append(rows, row)
+	}
+	printContextTable(os.Stdout, header, rows)
+
+	return differ
+}
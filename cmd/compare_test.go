@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compareManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    deployment.kubernetes.io/revision: "%d"
+  creationTimestamp: "2025-01-01T00:00:00Z"
+  generation: 4
+  managedFields:
+  - manager: kubectl
+  name: web
+  namespace: payments
+  resourceVersion: "%d"
+  uid: 0c5e-%d
+spec:
+  replicas: %d
+status:
+  readyReplicas: %d
+`
+
+func compareCopy(version, replicas int) string {
+	return fmt.Sprintf(compareManifest, version, version, version, replicas, replicas)
+}
+
+func TestCompareCmd(t *testing.T) {
+	require.NotNil(t, compareCmd)
+	assert.Equal(t, "compare", compareCmd.Use)
+	assert.True(t, compareCmd.DisableFlagParsing)
+	assert.ErrorContains(t, compareCmd.RunE(compareCmd, nil), "requires a resource")
+	assert.ErrorContains(t, compareCmd.RunE(compareCmd, []string{"deployment/web", "--pairwise", "--baseline", "prod"}), "cannot be combined")
+}
+
+func TestNormalizeObject(t *testing.T) {
+	normalized, err := normalizeObject(compareCopy(7, 3))
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: apps/v1\n"+
+		"kind: Deployment\n"+
+		"metadata:\n"+
+		"  name: web\n"+
+		"  namespace: payments\n"+
+		"spec:\n"+
+		"  replicas: 3", normalized)
+
+	again, err := normalizeObject(compareCopy(9, 3))
+	require.NoError(t, err)
+	assert.Equal(t, normalized, again)
+
+	_, err = normalizeObject("apiVersion: v1\nkind: List\nitems: []\n")
+	assert.ErrorContains(t, err, "not a list")
+}
+
+func TestDeleteField(t *testing.T) {
+	object := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"annotations": map[string]interface{}{"a": "1"},
+		},
+	}
+	deleteField(object, []string{"metadata", "annotations", "a"})
+	deleteField(object, []string{"spec", "replicas"})
+	assert.Equal(t, map[string]interface{}{"metadata": map[string]interface{}{"name": "web"}}, object)
+}
+
+func TestCompareObject(t *testing.T) {
+	assert.Equal(t, compareNotFound, compareObject(contextResult{output: `Error from server (NotFound): deployments.apps "web" not found`, err: errors.New("exit status 1")}).status)
+	assert.Equal(t, compareNotFound, compareObject(contextResult{}).status)
+	failed := compareObject(contextResult{output: "error: You must be logged in to the server (Unauthorized)\n", err: errors.New("exit status 1")})
+	assert.Equal(t, compareError, failed.status)
+	assert.Equal(t, "error: You must be logged in to the server (Unauthorized)", failed.reason)
+}
+
+func fakeCompareKubectl(t *testing.T, copies map[string]string) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, "get", subcommand)
+		assert.Equal(t, []string{"deployment/web", "-n", "payments", "-o", "yaml"}, extraArgs)
+		if manifest, ok := copies[context]; ok {
+			return manifest, nil
+		}
+		return `Error from server (NotFound): deployments.apps "web" not found`, errors.New("exit status 1")
+	})
+}
+
+func TestRunCompareBaseline(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu", "staging", "dev"})
+	t.Setenv("KUBECONFIG", path)
+	fakeCompareKubectl(t, map[string]string{
+		"prod-us": compareCopy(1, 3),
+		"prod-eu": compareCopy(2, 3),
+		"staging": compareCopy(3, 1),
+	})
+
+	var err error
+	output := captureStdout(func() {
+		err = runCompare([]string{"deployment/web", "-n", "payments"})
+	})
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	assert.EqualError(t, err, "2 of 3 contexts differ from prod-us")
+	assert.Equal(t, "--- prod-us\n"+
+		"+++ staging\n"+
+		"@@ -4,4 +4,4 @@\n"+
+		"   name: web\n"+
+		"   namespace: payments\n"+
+		" spec:\n"+
+		"-  replicas: 3\n"+
+		"+  replicas: 1\n"+
+		"\n"+
+		"CONTEXT  RESULT\n"+
+		"prod-us  BASELINE\n"+
+		"prod-eu  SAME\n"+
+		"staging  DIFFERENT\n"+
+		"dev      NOT FOUND\n", output)
+
+	captureStdout(func() {
+		err = runCompare([]string{"deployment/web", "-n", "payments", "--baseline", "dev"})
+	})
+	assert.EqualError(t, err, "baseline context dev does not have the resource")
+}
+
+func TestRunComparePairwise(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	fakeCompareKubectl(t, map[string]string{
+		"prod-us": compareCopy(1, 3),
+		"prod-eu": compareCopy(2, 3),
+		"staging": compareCopy(3, 1),
+	})
+
+	var err error
+	output := captureStdout(func() {
+		err = runCompare([]string{"deployment/web", "--pairwise", "-n", "payments"})
+	})
+	assert.EqualError(t, err, "2 pairs of contexts differ")
+	assert.Contains(t, output, "--- prod-us\n+++ staging\n")
+	assert.Contains(t, output, "--- prod-eu\n+++ staging\n")
+	assert.Contains(t, output, "CONTEXT  prod-us      prod-eu      staging\n"+
+		"prod-us  -            SAME         DIFFERENT\n"+
+		"prod-eu  SAME         -            DIFFERENT\n"+
+		"staging  DIFFERENT    DIFFERENT    -\n")
+}
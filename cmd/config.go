@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds persistent defaults read from the config file. Flags given on
+// the command line always take precedence. Profiles are named sets of the
+// same settings that override the top-level ones when selected.
+type Config struct {
+	BatchSize       int                 `yaml:"batchSize"`
+	Include         []string            `yaml:"include"`
+	Exclude         []string            `yaml:"exclude"`
+	Tags            []string            `yaml:"tags"`
+	Color           string              `yaml:"color"`
+	Timeout         time.Duration       `yaml:"timeout"`
+	SkipUnreachable bool                `yaml:"skipUnreachable"`
+	Discover        []string            `yaml:"discover"`
+	NameFormat      string              `yaml:"nameFormat"`
+	Groups          map[string][]string `yaml:"groups"`
+	Colors          []ColorRule         `yaml:"colors"`
+	DropColumns     []string            `yaml:"dropColumns"`
+	Profiles        map[string]Config   `yaml:"profiles"`
+}
+
+// configPath returns $KUBECTL_X_CONFIG if set, otherwise config.yaml under
+// $XDG_CONFIG_HOME/kubectl-x or ~/.config/kubectl-x.
+func configPath() string {
+	if path := os.Getenv("KUBECTL_X_CONFIG"); path != "" {
+		return path
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "kubectl-x", "config.yaml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "kubectl-x", "config.yaml")
+}
+
+// loadConfig reads the config file. A missing file yields an empty config.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{}
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if !validColor(config.Color) {
+		return nil, fmt.Errorf("invalid color %q in config %s: must be auto, always, or never", config.Color, path)
+	}
+	if config.NameFormat != "" && !strings.Contains(config.NameFormat, "{name}") {
+		return nil, fmt.Errorf("invalid nameFormat %q in config %s: must contain {name}", config.NameFormat, path)
+	}
+	for name, profile := range config.Profiles {
+		if !validColor(profile.Color) {
+			return nil, fmt.Errorf("invalid color %q in profile %q of config %s: must be auto, always, or never", profile.Color, name, path)
+		}
+		if profile.NameFormat != "" && !strings.Contains(profile.NameFormat, "{name}") {
+			return nil, fmt.Errorf("invalid nameFormat %q in profile %q of config %s: must contain {name}", profile.NameFormat, name, path)
+		}
+	}
+	return config, nil
+}
+
+func validColor(color string) bool {
+	switch color {
+	case "", "auto", "always", "never":
+		return true
+	}
+	return false
+}
+
+// withProfile returns the settings with the named profile laid over them.
+func (c *Config) withProfile(name string) (*Config, error) {
+	if name == "" {
+		return c, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		var names []string
+		for known := range c.Profiles {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q: no profiles are defined in %s", name, configPath())
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	merged := *c
+	merged.Profiles = nil
+	if profile.BatchSize > 0 {
+		merged.BatchSize = profile.BatchSize
+	}
+	if len(profile.Include) > 0 {
+		merged.Include = profile.Include
+	}
+	if len(profile.Exclude) > 0 {
+		merged.Exclude = profile.Exclude
+	}
+	if len(profile.Tags) > 0 {
+		merged.Tags = profile.Tags
+	}
+	if profile.Color != "" {
+		merged.Color = profile.Color
+	}
+	if profile.Timeout > 0 {
+		merged.Timeout = profile.Timeout
+	}
+	if profile.SkipUnreachable {
+		merged.SkipUnreachable = true
+	}
+	if len(profile.Discover) > 0 {
+		merged.Discover = profile.Discover
+	}
+	if profile.NameFormat != "" {
+		merged.NameFormat = profile.NameFormat
+	}
+	if len(profile.Colors) > 0 {
+		merged.Colors = profile.Colors
+	}
+	if len(profile.DropColumns) > 0 {
+		merged.DropColumns = profile.DropColumns
+	}
+	return &merged, nil
+}
+
+// applyConfig fills in every setting whose flag was not given on the command
+// line. Include patterns are skipped when contexts are named explicitly.
+func applyConfig(cmd *cobra.Command, config *Config) {
+	flags := rootCmd.PersistentFlags()
+	if config.BatchSize > 0 && !flags.Changed("batch-size") {
+		batchSize = config.BatchSize
+	}
+	if len(config.Include) > 0 && !flags.Changed("include") && !flags.Changed("filter") && len(contextNames) == 0 {
+		filterPatterns = append([]string{}, config.Include...)
+	}
+	if len(config.Exclude) > 0 && !flags.Changed("exclude") {
+		excludePatterns = append([]string{}, config.Exclude...)
+	}
+	if len(config.Discover) > 0 && !flags.Changed("discover") {
+		discoverSpecs = append([]string{}, config.Discover...)
+	}
+	if len(config.Tags) > 0 && !flags.Changed("tag") && len(contextNames) == 0 {
+		tagExpressions = append([]string{}, config.Tags...)
+	}
+	if config.SkipUnreachable && !flags.Changed("skip-unreachable") {
+		skipUnreachable = true
+	}
+	if config.Color != "" && !flags.Changed("color") {
+		colorMode = config.Color
+	}
+	if config.NameFormat != "" {
+		nameFormat = config.NameFormat
+	}
+	if len(config.DropColumns) > 0 {
+		dropColumns = config.DropColumns
+	}
+	if config.Timeout > 0 {
+		if flag := cmd.Flags().Lookup("timeout"); flag != nil && !flag.Changed {
+			flag.Value.Set(config.Timeout.String())
+		}
+	}
+}
+
+func loadAndApplyConfig(cmd *cobra.Command, args []string) error {
+	if err := validateReportFormat(); err != nil {
+		return err
+	}
+	if err := compileGrepPatterns(); err != nil {
+		return err
+	}
+	if err := validateProgressMode(); err != nil {
+		return err
+	}
+	if err := validateMaxLineBytes(); err != nil {
+		return err
+	}
+	if colorMode == "" || !validColor(colorMode) {
+		return fmt.Errorf("invalid --color %q: must be auto, always, or never", colorMode)
+	}
+	config, err := loadConfig(configPath())
+	if err != nil {
+		return err
+	}
+	if profileName == "" {
+		profileName = os.Getenv("KUBECTL_X_PROFILE")
+	}
+	config, err = config.withProfile(profileName)
+	if err != nil {
+		return err
+	}
+	applyConfig(cmd, config)
+
+	contextColorRules, err = compileColorRules(config.Colors)
+	if err != nil {
+		return err
+	}
+
+	if len(groupNames) > 0 {
+		contextGroups, err = loadContextGroups(config.Groups)
+		if err != nil {
+			return err
+		}
+		if err := addGroupKubeconfigs(contextGroups, groupNames); err != nil {
+			return err
+		}
+	}
+
+	if len(discoverSpecs) > 0 && discoversFor(cmd) {
+		path, err := discoverContexts(discoverSpecs)
+		if err != nil {
+			return err
+		}
+		kubeconfigPaths = append(kubeconfigPaths, path)
+	}
+
+	pageOutput(cmd, args)
+	return nil
+}
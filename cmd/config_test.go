@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func resetConfigState(t *testing.T) {
+	t.Cleanup(func() {
+		batchSize = 25
+		filterPatterns = []string{}
+		excludePatterns = []string{}
+		contextNames = []string{}
+		colorMode = "auto"
+		nameFormat = "{context}/{name}"
+		dropColumns = defaultDropColumns
+		skipUnreachable = false
+		for _, name := range []string{"batch-size", "include", "filter", "exclude", "tag", "color"} {
+			rootCmd.PersistentFlags().Lookup(name).Changed = false
+		}
+	})
+}
+
+func TestConfigPath(t *testing.T) {
+	t.Setenv("KUBECTL_X_CONFIG", "/etc/kubectl-x.yaml")
+	assert.Equal(t, "/etc/kubectl-x.yaml", configPath())
+
+	t.Setenv("KUBECTL_X_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	assert.Equal(t, "/xdg/kubectl-x/config.yaml", configPath())
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	assert.Equal(t, "/home/me/.config/kubectl-x/config.yaml", configPath())
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		config, err := loadConfig(filepath.Join(t.TempDir(), "nope.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, &Config{}, config)
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		path := writeConfig(t, "batchSize: 10\ninclude: [prod]\nexclude: [us-west]\ncolor: never\ntimeout: 2s\nskipUnreachable: true\n")
+		config, err := loadConfig(path)
+		require.NoError(t, err)
+		assert.Equal(t, &Config{BatchSize: 10, Include: []string{"prod"}, Exclude: []string{"us-west"}, Color: "never", Timeout: 2 * time.Second, SkipUnreachable: true}, config)
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		_, err := loadConfig(writeConfig(t, "batchSize: [\n"))
+		assert.ErrorContains(t, err, "failed to parse config")
+	})
+
+	t.Run("invalid color", func(t *testing.T) {
+		_, err := loadConfig(writeConfig(t, "color: sometimes\n"))
+		assert.ErrorContains(t, err, `invalid color "sometimes"`)
+	})
+
+	t.Run("name format without name", func(t *testing.T) {
+		_, err := loadConfig(writeConfig(t, "nameFormat: \"{context}\"\n"))
+		assert.ErrorContains(t, err, "must contain {name}")
+	})
+}
+
+func TestApplyConfig(t *testing.T) {
+	config := &Config{BatchSize: 10, Include: []string{"prod"}, Exclude: []string{"us-west"}, Color: "never", Timeout: 2 * time.Second, SkipUnreachable: true}
+
+	t.Run("fills unset flags", func(t *testing.T) {
+		resetConfigState(t)
+		var timeout time.Duration
+		cmd := &cobra.Command{Use: "probe"}
+		cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "")
+
+		applyConfig(cmd, config)
+		assert.Equal(t, 10, batchSize)
+		assert.Equal(t, []string{"prod"}, filterPatterns)
+		assert.Equal(t, []string{"us-west"}, excludePatterns)
+		assert.Equal(t, "never", colorMode)
+		assert.True(t, skipUnreachable)
+		assert.Equal(t, 2*time.Second, timeout)
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		resetConfigState(t)
+		require.NoError(t, rootCmd.PersistentFlags().Set("batch-size", "5"))
+		require.NoError(t, rootCmd.PersistentFlags().Set("include", "staging"))
+		require.NoError(t, rootCmd.PersistentFlags().Set("color", "always"))
+		var timeout time.Duration
+		cmd := &cobra.Command{Use: "probe"}
+		cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "")
+		require.NoError(t, cmd.Flags().Set("timeout", "9s"))
+
+		applyConfig(cmd, config)
+		assert.Equal(t, 5, batchSize)
+		assert.Equal(t, []string{"staging"}, filterPatterns)
+		assert.Equal(t, "always", colorMode)
+		assert.Equal(t, 9*time.Second, timeout)
+	})
+
+	t.Run("explicit contexts skip include", func(t *testing.T) {
+		resetConfigState(t)
+		contextNames = []string{"dev"}
+		applyConfig(&cobra.Command{Use: "get"}, config)
+		assert.Empty(t, filterPatterns)
+	})
+}
+
+func TestConfigWithProfile(t *testing.T) {
+	config, err := loadConfig(writeConfig(t, `batchSize: 10
+include: [prod]
+color: never
+profiles:
+  payments:
+    include: [payments]
+    tags: [env=prod]
+    batchSize: 50
+  eu:
+    exclude: [us]
+    timeout: 1s
+`))
+	require.NoError(t, err)
+
+	same, err := config.withProfile("")
+	require.NoError(t, err)
+	assert.Same(t, config, same)
+
+	payments, err := config.withProfile("payments")
+	require.NoError(t, err)
+	assert.Equal(t, &Config{BatchSize: 50, Include: []string{"payments"}, Tags: []string{"env=prod"}, Color: "never"}, payments)
+
+	eu, err := config.withProfile("eu")
+	require.NoError(t, err)
+	assert.Equal(t, &Config{BatchSize: 10, Include: []string{"prod"}, Exclude: []string{"us"}, Color: "never", Timeout: time.Second}, eu)
+
+	_, err = config.withProfile("apac")
+	assert.EqualError(t, err, `unknown profile "apac" (available: eu, payments)`)
+
+	_, err = (&Config{}).withProfile("apac")
+	assert.ErrorContains(t, err, "no profiles are defined")
+}
+
+func TestLoadConfigInvalidProfileColor(t *testing.T) {
+	_, err := loadConfig(writeConfig(t, "profiles:\n  ci:\n    color: loud\n"))
+	assert.ErrorContains(t, err, `invalid color "loud" in profile "ci"`)
+}
+
+func TestLoadAndApplyConfigProfile(t *testing.T) {
+	resetConfigState(t)
+	t.Cleanup(func() {
+		profileName = ""
+		tagExpressions = []string{}
+	})
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, "profiles:\n  payments:\n    include: [payments]\n    tags: [env=prod]\n"))
+
+	t.Setenv("KUBECTL_X_PROFILE", "payments")
+	require.NoError(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil))
+	assert.Equal(t, []string{"payments"}, filterPatterns)
+	assert.Equal(t, []string{"env=prod"}, tagExpressions)
+
+	profileName = "missing"
+	assert.ErrorContains(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil), `unknown profile "missing"`)
+}
+
+func TestLoadAndApplyConfigInvalidColor(t *testing.T) {
+	resetConfigState(t)
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, ""))
+	colorMode = "rainbow"
+	assert.ErrorContains(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil), `invalid --color "rainbow"`)
+}
+
+func TestLoadAndApplyConfigLoadsGroupsOnlyForGroup(t *testing.T) {
+	resetConfigState(t)
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, ""))
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".kube"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".kube", "kubie.yaml"), []byte("configs: [not, a, map]\n"), 0o600))
+	t.Cleanup(func() { contextGroups = nil })
+
+	require.NoError(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil))
+	assert.Nil(t, contextGroups)
+
+	groupNames = []string{"prod"}
+	t.Cleanup(func() { groupNames = []string{} })
+	assert.ErrorContains(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil), "failed to parse kubie config")
+}
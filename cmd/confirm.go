@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// confirmInput is where prompt answers are read from; tests replace it.
+var confirmInput io.Reader = os.Stdin
+
+var (
+	answerSource io.Reader
+	answerReader *bufio.Reader
+)
+
+// readAnswer reads one line from confirmInput. The buffered reader is kept
+// between prompts so answers typed (or piped) ahead are not lost.
+func readAnswer() string {
+	if answerReader == nil || answerSource != confirmInput {
+		answerSource = confirmInput
+		answerReader = bufio.NewReader(confirmInput)
+	}
+	answer, _ := answerReader.ReadString('\n')
+	return strings.TrimSpace(answer)
+}
+
+// confirm asks a yes/no question on stderr. It returns true without prompting
+// when skip is set (--yes). Anything other than "y" or "yes" declines.
+func confirm(prompt string, skip bool) bool {
+	if skip {
+		return true
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	answer := strings.ToLower(readAnswer())
+	return answer == "y" || answer == "yes"
+}
+
+// confirmContexts lists the contexts a mutating command will touch before
+// asking for confirmation.
+func confirmContexts(description string, contexts []string, skip bool) bool {
+	fmt.Fprintf(os.Stderr, "%s will run against %d contexts:\n", description, len(contexts))
+	for _, ctx := range contexts {
+		fmt.Fprintf(os.Stderr, "  %s\n", colorizeContext(ctx))
+	}
+	return confirm("Proceed?", skip)
+}
+
+// pickOption prints a numbered list on stderr and returns the zero-based
+// index of the option the user chose.
+func pickOption(prompt string, options []string) (int, error) {
+	for i, option := range options {
+		fmt.Fprintf(os.Stderr, "%3d) %s\n", i+1, option)
+	}
+	fmt.Fprintf(os.Stderr, "%s [1-%d]: ", prompt, len(options))
+	answer := readAnswer()
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(options) {
+		return 0, fmt.Errorf("invalid selection %q", answer)
+	}
+	return n - 1, nil
+}
+
+// promptFromTerminal points prompts at the controlling terminal for commands
+// whose stdin carries a manifest. The returned function restores the previous
+// input.
+func promptFromTerminal() (func(), error) {
+	tty, err := os.Open(terminalInput)
+	if err != nil {
+		return nil, fmt.Errorf("stdin is used for the manifest and no terminal is available for confirmation; pass --yes")
+	}
+	previous := confirmInput
+	confirmInput = tty
+	return func() {
+		confirmInput = previous
+		tty.Close()
+	}, nil
+}
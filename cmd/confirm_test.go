@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		skip     bool
+		expected bool
+	}{
+		{name: "yes", input: "yes\n", expected: true},
+		{name: "y uppercase", input: "Y\n", expected: true},
+		{name: "no", input: "n\n", expected: false},
+		{name: "empty answer defaults to no", input: "\n", expected: false},
+		{name: "no input", input: "", expected: false},
+		{name: "skip prompt", input: "", skip: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := confirmInput
+			confirmInput = strings.NewReader(tt.input)
+			t.Cleanup(func() { confirmInput = old })
+
+			var result bool
+			stderr := captureStderr(func() {
+				result = confirm("Proceed?", tt.skip)
+			})
+			assert.Equal(t, tt.expected, result)
+			if tt.skip {
+				assert.Empty(t, stderr)
+			} else {
+				assert.Contains(t, stderr, "Proceed? [y/N]")
+			}
+		})
+	}
+}
+
+func TestConfirmContexts(t *testing.T) {
+	var result bool
+	stderr := captureStderr(func() {
+		result = confirmContexts("kubectl rollout restart", []string{"ctx1", "ctx2"}, true)
+	})
+	assert.True(t, result)
+	assert.Contains(t, stderr, "kubectl rollout restart will run against 2 contexts:")
+	assert.Contains(t, stderr, "  ctx1\n")
+	assert.Contains(t, stderr, "  ctx2\n")
+}
+
+func TestPickOption(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{name: "first option", input: "1\n", expected: 0},
+		{name: "last option", input: "3\n", expected: 2},
+		{name: "out of range", input: "4\n", wantErr: true},
+		{name: "not a number", input: "ctx1\n", wantErr: true},
+		{name: "no input", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := confirmInput
+			confirmInput = strings.NewReader(tt.input)
+			t.Cleanup(func() { confirmInput = old })
+
+			var choice int
+			var err error
+			stderr := captureStderr(func() {
+				choice, err = pickOption("Attach to which target?", []string{"ctx1", "ctx2", "ctx3"})
+			})
+			assert.Contains(t, stderr, "  2) ctx2\n")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, choice)
+		})
+	}
+}
+
+func TestReadAnswerKeepsBufferedInput(t *testing.T) {
+	old := confirmInput
+	confirmInput = strings.NewReader("y\nn\n")
+	t.Cleanup(func() { confirmInput = old })
+
+	assert.Equal(t, "y", readAnswer())
+	assert.Equal(t, "n", readAnswer())
+	assert.Equal(t, "", readAnswer())
+}
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	"k8s.io/client-go/tools/clientcmd"
@@ -15,15 +19,232 @@ type Kubeconfig struct {
 }
 
 type ContextEntry struct {
-	Name string `yaml:"name"`
+	Name    string      `yaml:"name"`
+	Context ContextBody `yaml:"context"`
+}
+
+type ContextBody struct {
+	Namespace  string           `yaml:"namespace"`
+	Extensions []NamedExtension `yaml:"extensions"`
+}
+
+type NamedExtension struct {
+	Name      string                 `yaml:"name"`
+	Extension map[string]interface{} `yaml:"extension"`
 }
 
 func getContexts() ([]string, error) {
-	kubeconfigPath := getKubeconfigPath()
-	if kubeconfigPath == "" {
+	contexts, err := selectContexts()
+	if err != nil || !skipUnreachable {
+		return contexts, err
+	}
+	return skipUnreachableContexts(contexts, preflightTimeout)
+}
+
+// selectContexts applies --context, --group, --include/--exclude, and --tag without
+// checking reachability, for commands that report on reachability themselves.
+func selectContexts() ([]string, error) {
+	if len(contextNames) > 0 {
+		return selectNamedContexts(contextNames)
+	}
+
+	contexts, err := loadContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupNames) > 0 {
+		contexts, err = filterContextsByGroups(contexts, contextGroups, groupNames)
+		if err != nil {
+			return nil, err
+		}
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("no contexts in kubeconfig belong to groups: %s", strings.Join(groupNames, ", "))
+		}
+	}
+
+	if len(filterPatterns) > 0 {
+		contexts, err = filterContexts(contexts, filterPatterns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern: %w", err)
+		}
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("no contexts match filter patterns: %s", strings.Join(filterPatterns, ", "))
+		}
+	}
+
+	if len(excludePatterns) > 0 {
+		contexts, err = excludeContexts(contexts, excludePatterns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+		}
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("all contexts excluded by patterns: %s", strings.Join(excludePatterns, ", "))
+		}
+	}
+
+	if len(tagExpressions) > 0 {
+		tags, err := loadContextTags()
+		if err != nil {
+			return nil, err
+		}
+		contexts, err = filterContextsByTags(contexts, tags, tagExpressions)
+		if err != nil {
+			return nil, err
+		}
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("no contexts match tags: %s", strings.Join(tagExpressions, " or "))
+		}
+	}
+
+	return contexts, nil
+}
+
+const preflightTimeout = 3 * time.Second
+
+// skipUnreachableContexts probes every context and drops, with a warning,
+// those whose API server did not answer at all. A server that answers with
+// an error is still reachable.
+func skipUnreachableContexts(contexts []string, timeout time.Duration) ([]string, error) {
+	var reachable []string
+	for _, probe := range probeContexts(contexts, timeout) {
+		if probe.err != nil && !strings.Contains(probe.output, "Error from server") {
+			fmt.Fprintf(os.Stderr, "Skipping unreachable context %s: %s\n", colorizeContext(probe.context), lastLine(probe.output))
+			continue
+		}
+		reachable = append(reachable, probe.context)
+	}
+	if len(reachable) == 0 {
+		return nil, fmt.Errorf("none of the %d selected contexts are reachable", len(contexts))
+	}
+	return reachable, nil
+}
+
+// selectNamedContexts resolves explicitly named contexts (each value may be a
+// comma-separated list), ignoring include/exclude filters. Unknown names are
+// reported together with the closest existing context names.
+func selectNamedContexts(names []string) ([]string, error) {
+	available, err := loadContexts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contexts: %w", err)
+	}
+	known := make(map[string]bool, len(available))
+	for _, ctx := range available {
+		known[ctx] = true
+	}
+
+	var contexts, problems []string
+	seen := make(map[string]bool)
+	for _, value := range names {
+		for _, ctx := range strings.Split(value, ",") {
+			ctx = strings.TrimSpace(ctx)
+			if ctx == "" || seen[ctx] {
+				continue
+			}
+			seen[ctx] = true
+			if known[ctx] {
+				contexts = append(contexts, ctx)
+				continue
+			}
+			problem := fmt.Sprintf("%q", ctx)
+			if matches := closeMatches(ctx, available); len(matches) > 0 {
+				problem += fmt.Sprintf(" (did you mean %s?)", strings.Join(matches, ", "))
+			}
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("contexts not found in kubeconfig: %s", strings.Join(problems, "; "))
+	}
+	return contexts, nil
+}
+
+// closeMatches returns up to three candidates that contain name (ignoring
+// case) or are within a small edit distance of it, closest first.
+func closeMatches(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	lower := strings.ToLower(name)
+	limit := len(name)/3 + 1
+	var matches []match
+	for _, candidate := range candidates {
+		distance := levenshtein(lower, strings.ToLower(candidate))
+		if distance <= limit || strings.Contains(strings.ToLower(candidate), lower) {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	var names []string
+	for i := 0; i < len(matches) && i < 3; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// loadContexts returns every context in the kubeconfig files, ignoring
+// filters. When a name appears in several files the first one wins, as it
+// does for kubectl.
+func loadContexts() ([]string, error) {
+	paths := getKubeconfigPaths()
+	if len(paths) == 0 {
 		return nil, fmt.Errorf("could not determine kubeconfig path")
 	}
 
+	// Like kubectl, files in KUBECONFIG that don't exist are skipped; files
+	// given with --kubeconfig must exist.
+	explicit := len(kubeconfigPaths) > 0
+	var contexts []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		names, err := loadContextsFromFile(path)
+		if err != nil && !explicit && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				contexts = append(contexts, name)
+			}
+		}
+	}
+
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("no contexts found in kubeconfig")
+	}
+
+	return contexts, nil
+}
+
+func loadContextsFromFile(kubeconfigPath string) ([]string, error) {
 	file, err := os.ReadFile(kubeconfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
@@ -53,32 +274,6 @@ func getContexts() ([]string, error) {
 		}
 	}
 
-	if len(contexts) == 0 {
-		return nil, fmt.Errorf("no contexts found in kubeconfig")
-	}
-
-	if len(filterPatterns) > 0 {
-		var err error
-		contexts, err = filterContexts(contexts, filterPatterns)
-		if err != nil {
-			return nil, fmt.Errorf("invalid filter pattern: %w", err)
-		}
-		if len(contexts) == 0 {
-			return nil, fmt.Errorf("no contexts match filter patterns: %s", strings.Join(filterPatterns, ", "))
-		}
-	}
-
-	if len(excludePatterns) > 0 {
-		var err error
-		contexts, err = excludeContexts(contexts, excludePatterns)
-		if err != nil {
-			return nil, fmt.Errorf("invalid exclude pattern: %w", err)
-		}
-		if len(contexts) == 0 {
-			return nil, fmt.Errorf("all contexts excluded by patterns: %s", strings.Join(excludePatterns, ", "))
-		}
-	}
-
 	return contexts, nil
 }
 
@@ -140,6 +335,46 @@ func excludeContexts(contexts []string, patterns []string) ([]string, error) {
 	return filtered, nil
 }
 
+// loadContextEntries returns the kubeconfig entry of every context by name.
+// As with context names, the first file defining a context wins.
+func loadContextEntries() (map[string]ContextEntry, error) {
+	// Missing KUBECONFIG entries are skipped the same way loadContexts skips
+	// them, so --namespace and tags work wherever context selection does.
+	explicit := len(kubeconfigPaths) > 0
+	entries := make(map[string]ContextEntry)
+	for _, path := range getKubeconfigPaths() {
+		if path == "" {
+			continue
+		}
+		file, err := os.ReadFile(path)
+		if err != nil && !explicit && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+		}
+		var config Kubeconfig
+		if err := yaml.Unmarshal(file, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+		}
+		for _, entry := range config.Contexts {
+			if _, ok := entries[entry.Name]; !ok {
+				entries[entry.Name] = entry
+			}
+		}
+	}
+	return entries, nil
+}
+
+// getKubeconfigPaths returns the --kubeconfig files if any were given,
+// otherwise the entries of KUBECONFIG (or the default path).
+func getKubeconfigPaths() []string {
+	if len(kubeconfigPaths) > 0 {
+		return kubeconfigPaths
+	}
+	return filepath.SplitList(getKubeconfigPath())
+}
+
 func getKubeconfigPath() string {
 	path := os.Getenv("KUBECONFIG")
 	if path != "" {
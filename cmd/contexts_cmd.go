@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var probeTimeout time.Duration
+
+var contextsCmd = &cobra.Command{
+	Use:   "contexts",
+	Short: "List targeted contexts with reachability and API latency",
+	Long:  `List every context that commands would target (after --include/--exclude), checking in parallel whether each API server answers and how long it takes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runContexts()
+	},
+}
+
+func init() {
+	contextsCmd.Flags().DurationVar(&probeTimeout, "timeout", 5*time.Second, "How long to wait for each API server")
+}
+
+type probeResult struct {
+	context string
+	latency time.Duration
+	output  string
+	err     error
+}
+
+// probeContexts asks every context's API server for /readyz, batchSize at a
+// time, and times the round trip.
+func probeContexts(contexts []string, timeout time.Duration) []probeResult {
+	var mu sync.Mutex
+	latencies := make(map[string]time.Duration, len(contexts))
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		start := time.Now()
+		output, err := runKubectlCommand(context, "get", []string{"--raw", "/readyz", fmt.Sprintf("--request-timeout=%s", timeout)})
+		mu.Lock()
+		latencies[context] = time.Since(start)
+		mu.Unlock()
+		return output, err
+	})
+
+	probes := make([]probeResult, len(results))
+	for i, result := range results {
+		probes[i] = probeResult{
+			context: result.context,
+			latency: latencies[result.context],
+			output:  result.output,
+			err:     result.err,
+		}
+	}
+	return probes
+}
+
+func formatLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+func runContexts() error {
+	contexts, err := selectContexts()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, probe := range probeContexts(contexts, probeTimeout) {
+		status := "Reachable"
+		message := ""
+		if probe.err != nil {
+			status = "Unreachable"
+			message = lastLine(probe.output)
+		}
+		rows = append(rows, []string{probe.context, status, formatLatency(probe.latency), message})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "STATUS", "LATENCY", "MESSAGE"}, rows)
+	return nil
+}
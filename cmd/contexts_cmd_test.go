@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextsCmd(t *testing.T) {
+	require.NotNil(t, contextsCmd)
+	assert.Equal(t, "contexts", contextsCmd.Use)
+	timeoutFlag := contextsCmd.Flags().Lookup("timeout")
+	require.NotNil(t, timeoutFlag)
+	assert.Equal(t, "5s", timeoutFlag.DefValue)
+}
+
+func TestFormatLatency(t *testing.T) {
+	assert.Equal(t, "124ms", formatLatency(123600*time.Microsecond))
+	assert.Equal(t, "1.5s", formatLatency(1500*time.Millisecond))
+}
+
+func TestProbeContexts(t *testing.T) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"--raw", "/readyz", "--request-timeout=2s"}, extraArgs)
+		if context == "ctx2" {
+			return "Unable to connect to the server: dial tcp: i/o timeout\n", fmt.Errorf("exit status 1")
+		}
+		return "ok", nil
+	})
+
+	probes := probeContexts([]string{"ctx1", "ctx2"}, 2*time.Second)
+	require.Len(t, probes, 2)
+	assert.Equal(t, "ctx1", probes[0].context)
+	assert.NoError(t, probes[0].err)
+	assert.Equal(t, "ctx2", probes[1].context)
+	assert.Error(t, probes[1].err)
+}
+
+func TestRunContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if context == "ctx2" {
+			return "Unable to connect to the server\n", fmt.Errorf("exit status 1")
+		}
+		return "ok", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runContexts())
+	})
+	assert.Regexp(t, regexp.MustCompile(`(?m)^ctx1\s+Reachable\s+\d+(\.\d+)?[mµn]?s$`), output)
+	assert.Regexp(t, regexp.MustCompile(`(?m)^ctx2\s+Unreachable\s+\S+\s+Unable to connect to the server$`), output)
+}
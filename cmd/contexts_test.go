@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -287,3 +288,143 @@ func TestFilterAndExcludeCombined(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{"prod-us-east", "dev-us-east"}, result)
 }
+
+func TestSelectNamedContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu", "staging"})
+	t.Setenv("KUBECONFIG", path)
+
+	t.Run("named contexts in order", func(t *testing.T) {
+		contexts, err := selectNamedContexts([]string{"staging,prod-us", "staging"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"staging", "prod-us"}, contexts)
+	})
+
+	t.Run("unknown context suggests close matches", func(t *testing.T) {
+		_, err := selectNamedContexts([]string{"prod-uk", "stagin"})
+		require.Error(t, err)
+		assert.Equal(t, `contexts not found in kubeconfig: "prod-uk" (did you mean prod-us, prod-eu?); "stagin" (did you mean staging?)`, err.Error())
+	})
+
+	t.Run("unknown context without close matches", func(t *testing.T) {
+		_, err := selectNamedContexts([]string{"qa"})
+		assert.EqualError(t, err, `contexts not found in kubeconfig: "qa"`)
+	})
+}
+
+func TestGetContextsWithExplicitContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	contextNames = []string{"staging"}
+	excludePatterns = []string{"staging"}
+	t.Cleanup(func() {
+		contextNames = []string{}
+		excludePatterns = []string{}
+	})
+
+	contexts, err := getContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, contexts)
+}
+
+func TestCloseMatches(t *testing.T) {
+	candidates := []string{"prod-us-east-1", "prod-us-west-2", "dev"}
+	assert.Equal(t, []string{"prod-us-east-1", "prod-us-west-2"}, closeMatches("us", candidates))
+	assert.Equal(t, []string{"prod-us-east-1", "prod-us-west-2"}, closeMatches("prod-us-eats-1", candidates))
+	assert.Equal(t, []string{"dev"}, closeMatches("DEV", candidates))
+	assert.Empty(t, closeMatches("kind", candidates))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("prod", "prod"))
+	assert.Equal(t, 1, levenshtein("prod", "prd"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, levenshtein("", "prod"))
+}
+
+func TestGetKubeconfigPaths(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/a/config"+string(filepath.ListSeparator)+"/b/config")
+	assert.Equal(t, []string{"/a/config", "/b/config"}, getKubeconfigPaths())
+
+	kubeconfigPaths = []string{"/fleet/config"}
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+	assert.Equal(t, []string{"/fleet/config"}, getKubeconfigPaths())
+}
+
+func TestLoadContextsFromMultipleKubeconfigs(t *testing.T) {
+	first := writeMinimalKubeconfig(t, []string{"prod-us", "shared"})
+	second := writeMinimalKubeconfig(t, []string{"shared", "fleet-1"})
+
+	kubeconfigPaths = []string{first, second}
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+
+	contexts, err := loadContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-us", "shared", "fleet-1"}, contexts)
+
+	kubeconfigPaths = []string{first, filepath.Join(t.TempDir(), "missing")}
+	_, err = loadContexts()
+	assert.ErrorContains(t, err, "failed to read kubeconfig")
+}
+
+func TestLoadContextsSkipsMissingKubeconfigEntries(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us"})
+	missing := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("KUBECONFIG", missing+string(filepath.ListSeparator)+path+string(filepath.ListSeparator))
+
+	contexts, err := loadContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-us"}, contexts)
+
+	t.Setenv("KUBECONFIG", missing)
+	_, err = loadContexts()
+	assert.EqualError(t, err, "no contexts found in kubeconfig")
+}
+
+func TestLoadContextEntriesSkipsMissingKubeconfigEntries(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us"})
+	missing := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("KUBECONFIG", path+string(filepath.ListSeparator)+missing+string(filepath.ListSeparator))
+
+	entries, err := loadContextEntries()
+	require.NoError(t, err)
+	assert.Contains(t, entries, "prod-us")
+
+	kubeconfigPaths = []string{path, missing}
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+	_, err = loadContextEntries()
+	assert.ErrorContains(t, err, "failed to read kubeconfig")
+}
+
+func TestSkipUnreachableContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu", "dead"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"--raw", "/readyz", "--request-timeout=3s"}, extraArgs)
+		switch context {
+		case "dead":
+			return "Unable to connect to the server: dial tcp 10.0.0.1:443: i/o timeout\n", fmt.Errorf("exit status 1")
+		case "prod-eu":
+			return "Error from server (Forbidden): forbidden\n", fmt.Errorf("exit status 1")
+		}
+		return "ok", nil
+	})
+
+	skipUnreachable = true
+	t.Cleanup(func() { skipUnreachable = false })
+
+	var contexts []string
+	stderr := captureStderr(func() {
+		var err error
+		contexts, err = getContexts()
+		require.NoError(t, err)
+	})
+	assert.Equal(t, []string{"prod-us", "prod-eu"}, contexts)
+	assert.Contains(t, stderr, "Skipping unreachable context dead: Unable to connect to the server")
+
+	contextNames = []string{"dead"}
+	t.Cleanup(func() { contextNames = []string{} })
+	captureStderr(func() {
+		_, err := getContexts()
+		assert.EqualError(t, err, "none of the 1 selected contexts are reachable")
+	})
+}
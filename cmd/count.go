@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// countedName matches a line of -o name output, such as pod/web-1. Notices
+// kubectl mixes into the output, such as "No resources found", do not match.
+var countedName = regexp.MustCompile(`^\S+/\S+$`)
+
+// countArgs asks kubectl for -o name, whose lines are counted, in place of
+// any other output format.
+func countArgs(args []string) []string {
+	_, args = extractStringFlag(args, "-o", "--output")
+	return append(args, "-o", "name")
+}
+
+// countNames counts the objects in -o name output that pass --grep and
+// --grep-v, matched against context/name as for -o name output.
+func countNames(context, output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if countedName.MatchString(name) && keepRow(context+"/"+name) {
+			count++
+		}
+	}
+	return count
+}
+
+func runCountCommand(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+	warnNamespaceSkew(contexts, "get", args)
+	return formatCountOutput(runAcrossContexts(contexts, "get", countArgs(args)))
+}
+
+// formatCountOutput prints a CONTEXT / COUNT table. Failed contexts are
+// reported on stderr and left out, rather than counted as zero.
+func formatCountOutput(results []contextResult) error {
+	if onlyErrors {
+		return formatOnlyErrors(results)
+	}
+	rows := [][]string{{"CONTEXT", "COUNT"}}
+	if noHeaders {
+		rows = nil
+	}
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		rows = append(rows, []string{colorizeContext(result.context), strconv.Itoa(countNames(result.context, result.output))})
+	}
+	printAligned(os.Stdout, rows, "  ")
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountArgs(t *testing.T) {
+	assert.Equal(t, []string{"pods", "-A", "-o", "name"}, countArgs([]string{"pods", "-A"}))
+	assert.Equal(t, []string{"pods", "-o", "name"}, countArgs([]string{"pods", "-o", "wide"}))
+	assert.Equal(t, []string{"pods", "-o", "name"}, countArgs([]string{"pods", "--output=json"}))
+}
+
+func TestCountNames(t *testing.T) {
+	assert.Equal(t, 2, countNames("prod", "pod/web-1\npod/web-2\n"))
+	assert.Equal(t, 0, countNames("prod", "No resources found in default namespace.\n"))
+	assert.Equal(t, 1, countNames("prod", "Warning: v1 ComponentStatus is deprecated\ncomponentstatus/etcd-0\n"))
+
+	grepRegexes = []*regexp.Regexp{regexp.MustCompile("web")}
+	t.Cleanup(func() { grepRegexes = nil })
+	assert.Equal(t, 1, countNames("prod", "pod/web-1\npod/db-1\n"))
+}
+
+func TestRunCountCommand(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2", "ctx3"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"pods", "-o", "name"}, extraArgs)
+		switch context {
+		case "ctx1":
+			return "pod/web-1\npod/web-2\npod/db-1\n", nil
+		case "ctx2":
+			return "No resources found in default namespace.\n", nil
+		}
+		return "Unable to connect to the server", fmt.Errorf("exit status 1")
+	})
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, runCountCommand([]string{"pods", "-o", "wide"}))
+		})
+	})
+	assert.Equal(t, "CONTEXT  COUNT\nctx1     3\nctx2     0\n", output)
+	assert.Contains(t, stderr, "Context ctx3: Error: exit status 1")
+}
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var dashCmd = &cobra.Command{
+	Use:   "dash",
+	Short: "Interactive, auto-refreshing table of a resource across all contexts",
+	Long: `Show a live table of kubectl get <resource> across all contexts in a terminal UI.
+
+Keys: up/down or j/k to move, / to filter by context, enter to describe the selected row, r to refresh, esc to go back, q to quit.
+--interval sets how often the table refreshes (default 5s).`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		intervals, args := extractStringFlag(args, "--interval")
+		interval := 5 * time.Second
+		if len(intervals) > 0 {
+			parsed, err := time.ParseDuration(intervals[len(intervals)-1])
+			if err != nil || parsed <= 0 {
+				return fmt.Errorf("invalid --interval %q", intervals[len(intervals)-1])
+			}
+			interval = parsed
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("dash requires a resource, e.g. kubectl x dash pods")
+		}
+		return runDash(args, interval)
+	},
+}
+
+type dashRow struct {
+	context string
+	columns []string
+}
+
+type dashAction int
+
+const (
+	dashNone dashAction = iota
+	dashQuit
+	dashRefresh
+	dashDescribe
+)
+
+type dashModel struct {
+	resource    string
+	header      []string
+	rows        []dashRow
+	errors      []string
+	refreshed   time.Time
+	cursor      int
+	filter      string
+	filtering   bool
+	detail      []string
+	detailTitle string
+	scroll      int
+}
+
+var dashColumnSeparator = regexp.MustCompile(`[ \t]{2,}`)
+
+// update replaces the table with fresh results. Contexts that print
+// different columns are merged under the union of their headers, as in
+// formatTableOutput, so every cell stays under its own column.
+func (m *dashModel) update(results []contextResult, at time.Time) {
+	m.header = nil
+	m.rows = nil
+	m.errors = nil
+	m.refreshed = at
+	var headers [][]string
+	headerOf := make(map[string][]string)
+	for _, result := range results {
+		if result.err != nil {
+			m.errors = append(m.errors, fmt.Sprintf("%s: %s", result.context, lastLine(result.output)))
+			continue
+		}
+		lines := strings.Split(strings.TrimSpace(result.output), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		header := dashColumnSeparator.Split(strings.TrimSpace(lines[0]), -1)
+		headers = append(headers, header)
+		headerOf[result.context] = header
+		for _, line := range lines[1:] {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			m.rows = append(m.rows, dashRow{context: result.context, columns: dashColumnSeparator.Split(strings.TrimSpace(line), -1)})
+		}
+	}
+	if len(headers) > 0 {
+		m.header = unionHeader(headers)
+		for i, row := range m.rows {
+			m.rows[i].columns = remapColumns(row.columns, headerOf[row.context], m.header)
+		}
+	}
+	m.clampCursor()
+}
+
+func (m *dashModel) visibleRows() []dashRow {
+	if m.filter == "" {
+		return m.rows
+	}
+	var rows []dashRow
+	for _, row := range m.rows {
+		if strings.Contains(row.context, m.filter) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (m *dashModel) clampCursor() {
+	if n := len(m.visibleRows()); m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// selected returns the context, namespace, and name of the highlighted row.
+// The namespace is only known when the table has a NAMESPACE column.
+func (m *dashModel) selected() (string, string, string, bool) {
+	rows := m.visibleRows()
+	if len(rows) == 0 {
+		return "", "", "", false
+	}
+	row := rows[m.cursor]
+	cell := func(i int) string {
+		if i < 0 || i >= len(row.columns) {
+			return ""
+		}
+		return row.columns[i]
+	}
+	name := max(slices.Index(m.header, "NAME"), 0)
+	return row.context, cell(slices.Index(m.header, "NAMESPACE")), cell(name), true
+}
+
+func (m *dashModel) handleKey(key string) dashAction {
+	if m.filtering {
+		switch key {
+		case "\r", "\x1b":
+			m.filtering = false
+		case "\x7f", "\b":
+			if len(m.filter) > 0 {
+				m.filter = m.filter[:len(m.filter)-1]
+			}
+		default:
+			if len(key) == 1 && key[0] >= ' ' {
+				m.filter += key
+			}
+		}
+		m.clampCursor()
+		return dashNone
+	}
+
+	if m.detail != nil {
+		switch key {
+		case "\x1b", "q":
+			m.detail = nil
+			m.scroll = 0
+		case "\x1b[A", "k":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "\x1b[B", "j":
+			if m.scroll < len(m.detail)-1 {
+				m.scroll++
+			}
+		case "\x03":
+			return dashQuit
+		}
+		return dashNone
+	}
+
+	switch key {
+	case "q", "\x03":
+		return dashQuit
+	case "r":
+		return dashRefresh
+	case "/":
+		m.filtering = true
+	case "\x1b":
+		m.filter = ""
+	case "\x1b[A", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "\x1b[B", "j":
+		if m.cursor < len(m.visibleRows())-1 {
+			m.cursor++
+		}
+	case "\r":
+		if _, _, _, ok := m.selected(); ok {
+			return dashDescribe
+		}
+	}
+	return dashNone
+}
+
+// render draws the current view as height lines at most.
+func (m *dashModel) render(width, height int) []string {
+	truncate := func(line string) string {
+		return truncateVisible(line, width)
+	}
+
+	if m.detail != nil {
+		lines := []string{truncate(m.detailTitle)}
+		end := m.scroll + height - 2
+		if end > len(m.detail) {
+			end = len(m.detail)
+		}
+		for _, line := range m.detail[m.scroll:end] {
+			lines = append(lines, truncate(line))
+		}
+		return append(lines, colorGray+truncate("up/down scroll  esc back")+colorReset)
+	}
+
+	title := fmt.Sprintf("kubectl x dash: %s", m.resource)
+	if !m.refreshed.IsZero() {
+		title += fmt.Sprintf("  (refreshed %s)", m.refreshed.Format("15:04:05"))
+	}
+	if m.filtering || m.filter != "" {
+		title += fmt.Sprintf("  filter: %s", m.filter)
+		if m.filtering {
+			title += "_"
+		}
+	}
+	lines := []string{truncate(title)}
+
+	rows := m.visibleRows()
+	table := [][]string{append([]string{"CONTEXT"}, m.header...)}
+	for _, row := range rows {
+		table = append(table, append([]string{row.context}, row.columns...))
+	}
+	widths := columnWidths(table)
+	formatCells := func(cells []string) string {
+		return alignedLine(cells, widths, "   ")
+	}
+
+	if m.header != nil {
+		lines = append(lines, truncate(formatCells(append([]string{"CONTEXT"}, m.header...))))
+	}
+
+	available := height - len(lines) - 1 - len(m.errors)
+	if available < 1 {
+		available = 1
+	}
+	start := 0
+	if m.cursor >= available {
+		start = m.cursor - available + 1
+	}
+	for i := start; i < len(rows) && i < start+available; i++ {
+		line := truncate(formatCells(append([]string{rows[i].context}, rows[i].columns...)))
+		if i == m.cursor {
+			line = "\033[7m" + line + colorReset
+		}
+		lines = append(lines, line)
+	}
+
+	for _, e := range m.errors {
+		lines = append(lines, colorRed+truncate(e)+colorReset)
+	}
+	return append(lines, colorGray+truncate("up/down move  enter describe  / filter  r refresh  q quit")+colorReset)
+}
+
+// readKeys forwards keypresses from the terminal; escape sequences such as
+// arrow keys arrive in a single read and are passed through whole.
+func readKeys(keys chan<- string) {
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		keys <- string(buf[:n])
+	}
+}
+
+// dashValueFlags are kubectl get flags that take a separate value, which
+// must not be mistaken for the resource type.
+var dashValueFlags = []string{"-n", "--namespace", "-l", "--selector", "--field-selector", "-o", "--output", "-L", "--label-columns", "--sort-by", "--chunk-size"}
+
+// dashKind returns the resource type the dashboard lists: its first
+// positional argument, without a /name, for kubectl describe.
+func dashKind(args []string) string {
+	_, rest := extractStringFlag(args, dashValueFlags...)
+	for _, arg := range rest {
+		if !strings.HasPrefix(arg, "-") {
+			kind, _, _ := strings.Cut(arg, "/")
+			return kind
+		}
+	}
+	return ""
+}
+
+// dashDescribeArgs describes the selected row. Names that kubectl printed
+// with their type, as it does when listing several types, are used as is.
+func dashDescribeArgs(kind, name string) []string {
+	if strings.Contains(name, "/") {
+		return []string{name}
+	}
+	return []string{kind, name}
+}
+
+func runDash(args []string, interval time.Duration) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !isTerminal() {
+		return fmt.Errorf("dash requires an interactive terminal")
+	}
+	if !ansiStdout {
+		return fmt.Errorf("dash requires a terminal that supports ANSI escape sequences")
+	}
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	progressDisabled = true
+	fmt.Print("\033[?1049h\033[?25l")
+	defer func() {
+		fmt.Print("\033[?25h\033[?1049l")
+		term.Restore(int(os.Stdin.Fd()), state)
+	}()
+
+	namespaces, _ := extractStringFlag(args, "-n", "--namespace")
+	kind := dashKind(args)
+	model := &dashModel{resource: strings.Join(args, " ")}
+	draw := func() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width, height = 0, 24
+		}
+		fmt.Print("\033[H\033[2J" + strings.Join(model.render(width, height), "\r\n"))
+	}
+
+	results := make(chan []contextResult, 1)
+	fetching := false
+	fetch := func() {
+		if fetching {
+			return
+		}
+		fetching = true
+		go func() { results <- runAcrossContexts(contexts, "get", args) }()
+	}
+
+	details := make(chan []string, 1)
+	keys := make(chan string)
+	go readKeys(keys)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fetch()
+	draw()
+	for {
+		select {
+		case res := <-results:
+			fetching = false
+			model.update(res, time.Now())
+		case lines := <-details:
+			model.detail = lines
+			model.scroll = 0
+		case <-ticker.C:
+			fetch()
+			continue
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch model.handleKey(key) {
+			case dashQuit:
+				return nil
+			case dashRefresh:
+				fetch()
+			case dashDescribe:
+				context, namespace, name, _ := model.selected()
+				describeArgs := dashDescribeArgs(kind, name)
+				if namespace != "" {
+					describeArgs = append(describeArgs, "--namespace", namespace)
+				} else if len(namespaces) > 0 {
+					describeArgs = append(describeArgs, "--namespace", namespaces[len(namespaces)-1])
+				}
+				model.detailTitle = fmt.Sprintf("%s: describe %s", context, strings.Join(describeArgs, " "))
+				model.detail = []string{"Loading..."}
+				go func() {
+					output, _ := runKubectlCommand(context, "describe", describeArgs)
+					details <- strings.Split(strings.TrimRight(output, "\n"), "\n")
+				}()
+			}
+		}
+		draw()
+	}
+}
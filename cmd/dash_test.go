@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashCmd(t *testing.T) {
+	require.NotNil(t, dashCmd)
+	assert.Equal(t, "dash", dashCmd.Use)
+	assert.True(t, dashCmd.DisableFlagParsing)
+	assert.ErrorContains(t, dashCmd.RunE(dashCmd, nil), "requires a resource")
+	assert.ErrorContains(t, dashCmd.RunE(dashCmd, []string{"pods", "--interval", "soon"}), "invalid --interval")
+	assert.ErrorContains(t, dashCmd.RunE(dashCmd, []string{"pods"}), "interactive terminal")
+}
+
+func newTestDashModel() *dashModel {
+	m := &dashModel{resource: "pods"}
+	m.update([]contextResult{
+		{context: "prod-us", output: "NAMESPACE   NAME    READY   STATUS\ndefault     api-1   1/1     Running\ndefault     api-2   0/1     Pending\n"},
+		{context: "prod-eu", output: "NAMESPACE   NAME        READY   STATUS\nweb         frontend    1/1     Running\n"},
+		{context: "staging", output: "Unable to connect to the server", err: fmt.Errorf("exit status 1")},
+	}, time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC))
+	return m
+}
+
+func TestDashKind(t *testing.T) {
+	assert.Equal(t, "pods", dashKind([]string{"pods", "-A"}))
+	assert.Equal(t, "deployments", dashKind([]string{"-n", "payments", "deployments"}))
+	assert.Equal(t, "pods", dashKind([]string{"-l", "app=web", "--namespace=web", "pods"}))
+	assert.Equal(t, "pod", dashKind([]string{"pod/web-1"}))
+	assert.Equal(t, "", dashKind([]string{"-A"}))
+}
+
+func TestDashDescribeArgs(t *testing.T) {
+	assert.Equal(t, []string{"pods", "web-1"}, dashDescribeArgs("pods", "web-1"))
+	assert.Equal(t, []string{"service/api"}, dashDescribeArgs("pods,svc", "service/api"))
+}
+
+func TestDashModelUpdate(t *testing.T) {
+	m := newTestDashModel()
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "READY", "STATUS"}, m.header)
+	require.Len(t, m.rows, 3)
+	assert.Equal(t, dashRow{context: "prod-eu", columns: []string{"web", "frontend", "1/1", "Running"}}, m.rows[2])
+	assert.Equal(t, []string{"staging: Unable to connect to the server"}, m.errors)
+}
+
+func TestDashModelNavigationAndFilter(t *testing.T) {
+	m := newTestDashModel()
+
+	assert.Equal(t, dashNone, m.handleKey("j"))
+	assert.Equal(t, dashNone, m.handleKey("\x1b[B"))
+	assert.Equal(t, dashNone, m.handleKey("\x1b[B"))
+	assert.Equal(t, 2, m.cursor)
+
+	context, namespace, name, ok := m.selected()
+	require.True(t, ok)
+	assert.Equal(t, []string{"prod-eu", "web", "frontend"}, []string{context, namespace, name})
+
+	m.handleKey("/")
+	for _, key := range []string{"u", "s", "x", "\x7f"} {
+		m.handleKey(key)
+	}
+	m.handleKey("\r")
+	assert.Equal(t, "us", m.filter)
+	assert.False(t, m.filtering)
+	assert.Len(t, m.visibleRows(), 2)
+	assert.Equal(t, 0, m.cursor)
+
+	m.handleKey("\x1b")
+	assert.Equal(t, "", m.filter)
+	assert.Len(t, m.visibleRows(), 3)
+
+	assert.Equal(t, dashRefresh, m.handleKey("r"))
+	assert.Equal(t, dashDescribe, m.handleKey("\r"))
+	assert.Equal(t, dashQuit, m.handleKey("q"))
+}
+
+func TestDashModelDetail(t *testing.T) {
+	m := newTestDashModel()
+	m.detail = []string{"Name: api-1", "Namespace: default", "Status: Running"}
+	m.detailTitle = "prod-us: describe pods api-1"
+
+	m.handleKey("j")
+	assert.Equal(t, 1, m.scroll)
+	lines := m.render(80, 10)
+	assert.Equal(t, "prod-us: describe pods api-1", lines[0])
+	assert.Equal(t, "Namespace: default", lines[1])
+
+	assert.Equal(t, dashNone, m.handleKey("q"))
+	assert.Nil(t, m.detail)
+	assert.Equal(t, dashQuit, m.handleKey("q"))
+}
+
+func TestDashModelRender(t *testing.T) {
+	m := newTestDashModel()
+	m.handleKey("j")
+
+	lines := m.render(0, 20)
+	assert.Equal(t, "kubectl x dash: pods  (refreshed 12:30:00)", lines[0])
+	assert.Equal(t, "CONTEXT   NAMESPACE   NAME       READY   STATUS", lines[1])
+	assert.Equal(t, "prod-us   default     api-1      1/1     Running", lines[2])
+	assert.Equal(t, "\033[7mprod-us   default     api-2      0/1     Pending"+colorReset, lines[3])
+	assert.Contains(t, lines[5], "staging: Unable to connect")
+	assert.Contains(t, lines[len(lines)-1], "q quit")
+
+	short := m.render(20, 5)
+	for _, line := range short {
+		for _, code := range []string{"\033[7m", colorGray, colorRed, colorReset} {
+			line = strings.ReplaceAll(line, code, "")
+		}
+		assert.LessOrEqual(t, len(line), 20)
+	}
+	assert.Len(t, short, 5)
+}
+
+func TestDashModelMergesHeaders(t *testing.T) {
+	m := &dashModel{resource: "widgets"}
+	m.update([]contextResult{
+		{context: "ctx1", output: "NAME    READY\nweb     1/1\n"},
+		{context: "ctx2", output: "NAME    SIZE   READY\napi     big    0/1\n"},
+	}, time.Time{})
+	assert.Equal(t, []string{"NAME", "SIZE", "READY"}, m.header)
+	assert.Equal(t, []string{"web", "", "1/1"}, m.rows[0].columns)
+	assert.Equal(t, []string{"api", "big", "0/1"}, m.rows[1].columns)
+
+	context, namespace, name, ok := m.selected()
+	assert.True(t, ok)
+	assert.Equal(t, []string{"ctx1", "", "web"}, []string{context, namespace, name})
+}
+
+func TestDashModelRenderTruncatesByVisibleWidth(t *testing.T) {
+	m := &dashModel{resource: "pods"}
+	m.update([]contextResult{{context: "ctx1", output: "NAME    STATUS\nwéb-ünïcode    Running\n"}}, time.Time{})
+	for _, line := range m.render(12, 10) {
+		assert.True(t, utf8.ValidString(line), line)
+		assert.LessOrEqual(t, visibleWidth(line), 12, line)
+	}
+}
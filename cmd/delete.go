@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:                "delete",
+	Short:              "Run kubectl delete against selected contexts",
+	Long:               `Run kubectl delete against an explicit selection of contexts: --all-contexts (every context matching --include/--exclude), --contexts a,b, or the contexts named with -c. The targets must be confirmed (skip with --yes) unless --dry-run is used. --canary deletes from a subset of contexts first.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDelete(args)
+	},
+}
+
+func runDelete(args []string) error {
+	allContexts, args := extractBoolFlag(args, "--all-contexts")
+	named, args := extractStringFlag(args, "--contexts")
+	yes, args := extractBoolFlag(args, "--yes", "-y")
+	canary, args := extractCanary(args)
+	args, dryRun := normalizeDryRun(args)
+
+	contexts, err := selectDeleteContexts(allContexts, named)
+	if err != nil {
+		return err
+	}
+
+	if readsStdin(args) && !yes && !dryRun {
+		restore, err := promptFromTerminal()
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	description := strings.TrimSpace("kubectl delete " + strings.Join(args, " "))
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "Dry run: %s will run against %d contexts\n", description, len(contexts))
+	} else if !confirmContexts(description, contexts, yes) {
+		return fmt.Errorf("aborted")
+	}
+
+	results, canaryErr := runWithCanary(contexts, canary, yes || dryRun, func(contexts []string) []contextResult {
+		return clearNotFoundErrors(runAcrossContexts(contexts, "delete", args))
+	})
+	if results == nil {
+		return canaryErr
+	}
+	if err := formatDeleteOutput(results); canaryErr == nil {
+		return err
+	}
+	return canaryErr
+}
+
+// clearNotFoundErrors drops the error of contexts where kubectl failed only
+// because objects were already gone, so a canary that found nothing to
+// delete still lets the remaining contexts run.
+func clearNotFoundErrors(results []contextResult) []contextResult {
+	var notFound []contextResult
+	for i, result := range results {
+		if result.err != nil && onlyNotFound(countDeleteResults(result.output)) {
+			notFound = append(notFound, result)
+			results[i].err = nil
+		}
+	}
+	recordSucceeded(notFound)
+	return results
+}
+
+// onlyNotFound reports whether kubectl's only complaint was missing objects.
+func onlyNotFound(counts deleteCounts) bool {
+	return counts.other == 0 && counts.notFound > 0
+}
+
+// normalizeDryRun rewrites a bare --dry-run to --dry-run=client, which kubectl
+// no longer accepts without a value, and reports whether this is a dry run.
+func normalizeDryRun(args []string) ([]string, bool) {
+	dryRun := false
+	normalized := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			arg = "--dry-run=client"
+			dryRun = true
+		case strings.HasPrefix(arg, "--dry-run="):
+			dryRun = isDryRunValue(strings.TrimPrefix(arg, "--dry-run="))
+		}
+		normalized = append(normalized, arg)
+	}
+	return normalized, dryRun
+}
+
+// isDryRunValue reads a --dry-run value as kubectl does: client, server, and
+// booleans that are true are dry runs. none, false, and values kubectl
+// rejects are not, so they still ask for confirmation.
+func isDryRunValue(value string) bool {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	return value == "client" || value == "server"
+}
+
+// selectDeleteContexts requires the caller to state which contexts to delete
+// from, so an unfiltered kubeconfig is never targeted by accident. The root
+// -c/--context flag names contexts as explicitly as --contexts does.
+func selectDeleteContexts(allContexts bool, named []string) ([]string, error) {
+	if allContexts && len(named) > 0 {
+		return nil, fmt.Errorf("--all-contexts and --contexts cannot be used together")
+	}
+	if allContexts {
+		contexts, err := getContexts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contexts: %w", err)
+		}
+		return contexts, nil
+	}
+	named = append(append([]string{}, contextNames...), named...)
+	if len(named) == 0 {
+		return nil, fmt.Errorf("delete requires an explicit context selection: pass --all-contexts or --contexts <a,b>, or name contexts with -c")
+	}
+
+	contexts, err := selectNamedContexts(named)
+	if err != nil {
+		return nil, err
+	}
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("--contexts did not name any contexts")
+	}
+	return contexts, nil
+}
+
+type deleteCounts struct {
+	deleted  int
+	notFound int
+	other    int
+}
+
+func countDeleteResults(output string) deleteCounts {
+	var counts deleteCounts
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.Contains(line, "(NotFound)"):
+			counts.notFound++
+		case strings.HasSuffix(strings.TrimSuffix(strings.TrimSuffix(line, " (server dry run)"), " (dry run)"), " deleted"):
+			counts.deleted++
+		case strings.HasPrefix(line, "Error") || strings.HasPrefix(line, "error"):
+			counts.other++
+		}
+	}
+	return counts
+}
+
+func formatDeleteOutput(results []contextResult) error {
+	var rows [][]string
+	var notFound []contextResult
+	failed := 0
+	for _, result := range results {
+		counts := countDeleteResults(result.output)
+		status := "OK"
+		// kubectl exits non-zero when any object is missing; that alone is
+		// reported in the NOT-FOUND column rather than as a failure.
+		if result.err != nil && !onlyNotFound(counts) {
+			status = "ERROR"
+			failed++
+			printContextError(result)
+		} else if result.err != nil {
+			notFound = append(notFound, result)
+		}
+		if status == "OK" && onlyErrors {
+			continue
+		}
+		rows = append(rows, []string{
+			result.context,
+			strconv.Itoa(counts.deleted),
+			strconv.Itoa(counts.notFound),
+			status,
+		})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "DELETED", "NOT-FOUND", "RESULT"}, rows)
+	recordSucceeded(notFound)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteCmd(t *testing.T) {
+	require.NotNil(t, deleteCmd)
+	assert.Equal(t, "delete", deleteCmd.Use)
+	assert.True(t, deleteCmd.DisableFlagParsing)
+}
+
+func TestNormalizeDryRun(t *testing.T) {
+	args, dryRun := normalizeDryRun([]string{"pod", "web", "--dry-run"})
+	assert.True(t, dryRun)
+	assert.Equal(t, []string{"pod", "web", "--dry-run=client"}, args)
+
+	args, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=server"})
+	assert.True(t, dryRun)
+	assert.Equal(t, []string{"pod", "web", "--dry-run=server"}, args)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=none"})
+	assert.False(t, dryRun)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=false"})
+	assert.False(t, dryRun)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=0"})
+	assert.False(t, dryRun)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=bogus"})
+	assert.False(t, dryRun)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web", "--dry-run=true"})
+	assert.True(t, dryRun)
+
+	_, dryRun = normalizeDryRun([]string{"pod", "web"})
+	assert.False(t, dryRun)
+}
+
+func TestSelectDeleteContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"dev", "prod-us", "prod-eu"})
+	t.Setenv("KUBECONFIG", path)
+
+	t.Run("requires a selection", func(t *testing.T) {
+		_, err := selectDeleteContexts(false, nil)
+		assert.ErrorContains(t, err, "--all-contexts or --contexts")
+	})
+
+	t.Run("rejects both selections", func(t *testing.T) {
+		_, err := selectDeleteContexts(true, []string{"dev"})
+		assert.Error(t, err)
+	})
+
+	t.Run("all contexts respects include filter", func(t *testing.T) {
+		filterPatterns = []string{"prod"}
+		t.Cleanup(func() { filterPatterns = []string{} })
+		contexts, err := selectDeleteContexts(true, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod-us", "prod-eu"}, contexts)
+	})
+
+	t.Run("named contexts", func(t *testing.T) {
+		contexts, err := selectDeleteContexts(false, []string{"prod-eu,dev", "dev"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod-eu", "dev"}, contexts)
+	})
+
+	t.Run("root context flag", func(t *testing.T) {
+		contextNames = []string{"prod-us"}
+		t.Cleanup(func() { contextNames = []string{} })
+		contexts, err := selectDeleteContexts(false, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod-us"}, contexts)
+	})
+
+	t.Run("unknown named context", func(t *testing.T) {
+		_, err := selectDeleteContexts(false, []string{"prod-ap,dev"})
+		assert.ErrorContains(t, err, "prod-ap")
+	})
+}
+
+func TestCountDeleteResults(t *testing.T) {
+	output := "pod \"web-1\" deleted\n" +
+		"pod \"web-2\" deleted (dry run)\n" +
+		"Error from server (NotFound): pods \"web-3\" not found\n"
+	assert.Equal(t, deleteCounts{deleted: 2, notFound: 1}, countDeleteResults(output))
+	assert.Equal(t, deleteCounts{other: 1}, countDeleteResults("error: the server doesn't have a resource type \"widgets\"\n"))
+}
+
+func TestFormatDeleteOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "pod \"web\" deleted\n"},
+		{context: "ctx2", output: "Error from server (NotFound): pods \"web\" not found\n", err: fmt.Errorf("exit status 1")},
+		{context: "ctx3", output: "error: You must be logged in to the server\n", err: fmt.Errorf("exit status 1")},
+	}
+	resetRecordedResults()
+	t.Cleanup(resetRecordedResults)
+	recordResults(results)
+	var err error
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			err = formatDeleteOutput(results)
+		})
+	})
+	require.Error(t, err)
+	assert.Equal(t, "1 of 3 contexts failed", err.Error())
+	assert.Equal(t, "CONTEXT  DELETED    NOT-FOUND    RESULT\n"+
+		"ctx1     1          0            OK\n"+
+		"ctx2     0          1            OK\n"+
+		"ctx3     0          0            ERROR\n", output)
+
+	recorded := recordedResults()
+	assert.NoError(t, recorded[1].err, "a NotFound-only delete is recorded as OK")
+	assert.Error(t, recorded[2].err)
+}
+
+func TestRunDeleteDryRunSkipsConfirmation(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, context+" "+strings.Join(extraArgs, " "))
+		return "pod \"web\" deleted (dry run)\n", nil
+	})
+
+	var err error
+	captureStdout(func() {
+		captureStderr(func() {
+			err = runDelete([]string{"pod", "web", "--contexts", "ctx2", "--dry-run"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctx2 pod web --dry-run=client"}, calls)
+}
+
+func TestRunDeleteDryRunFalseAsksForConfirmation(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	old := confirmInput
+	confirmInput = strings.NewReader("n\n")
+	t.Cleanup(func() { confirmInput = old })
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		t.Errorf("unexpected kubectl call in %s", context)
+		return "", nil
+	})
+
+	var err error
+	stderr := captureStderr(func() {
+		err = runDelete([]string{"pod", "web", "--contexts", "ctx2", "--dry-run=false"})
+	})
+	assert.EqualError(t, err, "aborted")
+	assert.Contains(t, stderr, "Proceed?")
+}
+
+func TestRunDeleteCanary(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	resetRecordedResults()
+
+	var mu sync.Mutex
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, context+" "+strings.Join(extraArgs, " "))
+		if context == "ctx2" {
+			return "Error from server (NotFound): pods \"web\" not found\n", fmt.Errorf("exit status 1")
+		}
+		return "pod \"web\" deleted\n", nil
+	})
+
+	var err error
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			err = runDelete([]string{"pod", "web", "--all-contexts", "--canary", "ctx2", "--yes"})
+		})
+	})
+	require.NoError(t, err, "a canary that found nothing to delete lets the rest run")
+	assert.Equal(t, []string{"ctx2 pod web", "ctx1 pod web"}, calls)
+	assert.Regexp(t, `ctx2\s+0\s+1\s+OK`, output)
+	assert.Regexp(t, `ctx1\s+1\s+0\s+OK`, output)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:                "diff",
+	Short:              "Run kubectl diff against all contexts",
+	Long:               `Run kubectl diff against all contexts in parallel, print each context's diff prefixed by context, and summarize which contexts would change. Exits 1 if any context has drift and 2 if any context failed.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDiff(args)
+	},
+}
+
+const (
+	diffUnchanged = "unchanged"
+	diffChanged   = "changed"
+	diffError     = "error"
+)
+
+func runDiff(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	results := runAcrossContexts(contexts, "diff", args)
+	return formatDiffOutput(results)
+}
+
+// exitCode extracts a process exit code from err, or -1 if err did not come
+// from a process that ran to completion.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// classifyDiff maps kubectl diff's exit code convention (0 no diff, 1 diff
+// found, >1 failure) to a status.
+func classifyDiff(result contextResult) string {
+	switch exitCode(result.err) {
+	case 0:
+		return diffUnchanged
+	case 1:
+		return diffChanged
+	default:
+		return diffError
+	}
+}
+
+func formatDiffOutput(results []contextResult) error {
+	maxContextWidth := 0
+	for _, result := range results {
+		if len(result.context) > maxContextWidth {
+			maxContextWidth = len(result.context)
+		}
+	}
+
+	var rows [][]string
+	var drifted []contextResult
+	changed, failed := 0, 0
+	for _, result := range results {
+		status := classifyDiff(result)
+		if status == diffError || !onlyErrors {
+			rows = append(rows, []string{result.context, status})
+		}
+
+		switch status {
+		case diffError:
+			failed++
+			printContextError(result)
+		case diffChanged:
+			changed++
+			drifted = append(drifted, result)
+			if onlyErrors {
+				continue
+			}
+			coloredContext := colorizeContext(result.context)
+			padding := fillWidth(result.context, maxContextWidth)
+			for _, line := range strings.Split(strings.TrimRight(result.output, "\n"), "\n") {
+				fmt.Printf("%s%s\n", contextPrefix(coloredContext, padding), line)
+			}
+		}
+	}
+
+	if changed > 0 && !onlyErrors {
+		fmt.Println()
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "DIFF"}, rows)
+	recordSucceeded(drifted)
+
+	if failed > 0 {
+		return &ExitError{Code: 2, Err: fmt.Errorf("diff failed in %d of %d contexts", failed, len(results))}
+	}
+	if changed > 0 {
+		return &ExitError{Code: 1, Err: fmt.Errorf("%d of %d contexts have drift", changed, len(results))}
+	}
+	return nil
+}
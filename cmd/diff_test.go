@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exitStatus(t *testing.T, code int) error {
+	t.Helper()
+	err := exec.Command("sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+	require.Error(t, err)
+	return err
+}
+
+func TestDiffCmd(t *testing.T) {
+	require.NotNil(t, diffCmd)
+	assert.Equal(t, "diff", diffCmd.Use)
+	assert.True(t, diffCmd.DisableFlagParsing)
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, exitCode(nil))
+	assert.Equal(t, 1, exitCode(exitStatus(t, 1)))
+	assert.Equal(t, 2, exitCode(fmt.Errorf("wrapped: %w", exitStatus(t, 2))))
+	assert.Equal(t, -1, exitCode(fmt.Errorf("kubectl not found")))
+}
+
+func TestClassifyDiff(t *testing.T) {
+	assert.Equal(t, diffUnchanged, classifyDiff(contextResult{}))
+	assert.Equal(t, diffChanged, classifyDiff(contextResult{err: exitStatus(t, 1)}))
+	assert.Equal(t, diffError, classifyDiff(contextResult{err: exitStatus(t, 2)}))
+}
+
+func TestFormatDiffOutput(t *testing.T) {
+	t.Run("no drift", func(t *testing.T) {
+		var err error
+		output := captureStdout(func() {
+			err = formatDiffOutput([]contextResult{{context: "ctx1"}, {context: "ctx2"}})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "CONTEXT  DIFF\nctx1     unchanged\nctx2     unchanged\n", output)
+	})
+
+	t.Run("drift in one context", func(t *testing.T) {
+		results := []contextResult{
+			{context: "ctx1"},
+			{context: "ctx2", output: "-  replicas: 2\n+  replicas: 3\n", err: exitStatus(t, 1)},
+		}
+		resetRecordedResults()
+		t.Cleanup(resetRecordedResults)
+		recordResults(results)
+		var err error
+		output := captureStdout(func() {
+			err = formatDiffOutput(results)
+		})
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.Code)
+		assert.Equal(t, "ctx2  -  replicas: 2\nctx2  +  replicas: 3\n\n"+
+			"CONTEXT  DIFF\nctx1     unchanged\nctx2     changed\n", output)
+		assert.NoError(t, recordedResults()[1].err, "drift is recorded as OK")
+	})
+
+	t.Run("hide context", func(t *testing.T) {
+		hideContext = true
+		t.Cleanup(func() { hideContext = false })
+		output := captureStdout(func() {
+			formatDiffOutput([]contextResult{{context: "ctx1", output: "+  replicas: 3\n", err: exitStatus(t, 1)}})
+		})
+		assert.True(t, strings.HasPrefix(output, "+  replicas: 3\n"), output)
+	})
+
+	t.Run("failure takes precedence over drift", func(t *testing.T) {
+		var err error
+		captureOutputCombined(func() {
+			err = formatDiffOutput([]contextResult{
+				{context: "ctx1", output: "+  replicas: 3\n", err: exitStatus(t, 1)},
+				{context: "ctx2", output: "error: unable to connect", err: exitStatus(t, 2)},
+			})
+		})
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 2, exitErr.Code)
+	})
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// runCloudCLI runs a cloud provider CLI with extra environment variables and
+// returns its stdout. It is a variable so tests can replace it.
+var runCloudCLI = func(env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+type discoverySource struct {
+	provider string
+	params   map[string]string
+}
+
+type discoveredCluster struct {
+	name     string
+	location string
+}
+
+// clusterSource is a discovery backend: it adds a kubeconfig context for every
+// cluster it knows about to the file at path and reports how many it added.
+type clusterSource interface {
+	discover(path string) (int, error)
+}
+
+// discoveryParams lists the scope parameters each provider accepts.
+var discoveryParams = map[string][]string{
+	"eks":      {"profile", "region"},
+	"gke":      {"project"},
+	"aks":      {"subscription"},
+	"capi":     {"hub", "namespace"},
+	"argocd":   {"hub", "namespace"},
+	"rancher":  {"url"},
+	"teleport": {"proxy", "cluster"},
+}
+
+// newClusterSource picks the backend for a parsed discovery spec.
+func newClusterSource(spec discoverySource) (clusterSource, error) {
+	switch spec.provider {
+	case "capi":
+		if spec.params["hub"] == "" {
+			return nil, fmt.Errorf("capi discovery requires hub=<context>")
+		}
+		return capiSource{hub: spec.params["hub"], namespace: spec.params["namespace"]}, nil
+	case "argocd":
+		if spec.params["hub"] == "" {
+			return nil, fmt.Errorf("argocd discovery requires hub=<context>")
+		}
+		namespace := spec.params["namespace"]
+		if namespace == "" {
+			namespace = "argocd"
+		}
+		return argoCDSource{hub: spec.params["hub"], namespace: namespace}, nil
+	case "teleport":
+		return teleportSource{proxy: spec.params["proxy"], cluster: spec.params["cluster"]}, nil
+	case "rancher":
+		if spec.params["url"] == "" {
+			return nil, fmt.Errorf("rancher discovery requires url=<rancher server URL>")
+		}
+		token := os.Getenv("RANCHER_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("rancher discovery requires an API token in RANCHER_TOKEN")
+		}
+		return rancherSource{url: strings.TrimRight(spec.params["url"], "/"), token: token}, nil
+	}
+	return spec, nil
+}
+
+// parseDiscoverySpec parses "provider" or "provider:key=value,key=value",
+// e.g. "eks:profile=prod,region=eu-west-1".
+func parseDiscoverySpec(spec string) (discoverySource, error) {
+	provider, rest, _ := strings.Cut(spec, ":")
+	allowed, ok := discoveryParams[provider]
+	if !ok {
+		return discoverySource{}, fmt.Errorf("unknown discovery provider %q in %q: must be eks, gke, aks, capi, argocd, rancher, or teleport", provider, spec)
+	}
+	source := discoverySource{provider: provider, params: map[string]string{}}
+	for _, pair := range strings.Split(rest, ",") {
+		if pair == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		if !found || !contains(allowed, key) {
+			return discoverySource{}, fmt.Errorf("invalid %s discovery parameter %q: expected one of %s as key=value", provider, pair, strings.Join(allowed, ", "))
+		}
+		source.params[key] = value
+	}
+	return source, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeArgs turns discovery parameters into the provider CLI's own flags.
+func (s discoverySource) scopeArgs() []string {
+	var args []string
+	for _, key := range discoveryParams[s.provider] {
+		if value, ok := s.params[key]; ok {
+			args = append(args, "--"+key, value)
+		}
+	}
+	return args
+}
+
+func (s discoverySource) listClusters() ([]discoveredCluster, error) {
+	var clusters []discoveredCluster
+	switch s.provider {
+	case "eks":
+		output, err := runCloudCLI(nil, "aws", append([]string{"eks", "list-clusters", "--output", "json"}, s.scopeArgs()...)...)
+		if err != nil {
+			return nil, fmt.Errorf("aws eks list-clusters failed: %w", err)
+		}
+		var response struct {
+			Clusters []string `json:"clusters"`
+		}
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse aws eks list-clusters output: %w", err)
+		}
+		for _, name := range response.Clusters {
+			clusters = append(clusters, discoveredCluster{name: name})
+		}
+	case "gke":
+		output, err := runCloudCLI(nil, "gcloud", append([]string{"container", "clusters", "list", "--format", "json"}, s.scopeArgs()...)...)
+		if err != nil {
+			return nil, fmt.Errorf("gcloud container clusters list failed: %w", err)
+		}
+		var response []struct {
+			Name     string `json:"name"`
+			Location string `json:"location"`
+		}
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse gcloud container clusters list output: %w", err)
+		}
+		for _, cluster := range response {
+			clusters = append(clusters, discoveredCluster{name: cluster.Name, location: cluster.Location})
+		}
+	case "aks":
+		output, err := runCloudCLI(nil, "az", append([]string{"aks", "list", "--output", "json"}, s.scopeArgs()...)...)
+		if err != nil {
+			return nil, fmt.Errorf("az aks list failed: %w", err)
+		}
+		var response []struct {
+			Name          string `json:"name"`
+			ResourceGroup string `json:"resourceGroup"`
+		}
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse az aks list output: %w", err)
+		}
+		for _, cluster := range response {
+			clusters = append(clusters, discoveredCluster{name: cluster.Name, location: cluster.ResourceGroup})
+		}
+	}
+	return clusters, nil
+}
+
+// writeCredentials has the provider CLI add a context for cluster to the
+// kubeconfig at path. Contexts are named <provider>_<scope>_<cluster>, which
+// matches what gcloud generates.
+func (s discoverySource) writeCredentials(cluster discoveredCluster, path string) error {
+	var err error
+	switch s.provider {
+	case "eks":
+		region := s.params["region"]
+		if region == "" {
+			region = "default"
+		}
+		alias := fmt.Sprintf("eks_%s_%s", region, cluster.name)
+		_, err = runCloudCLI(nil, "aws", append([]string{"eks", "update-kubeconfig", "--name", cluster.name, "--alias", alias, "--kubeconfig", path}, s.scopeArgs()...)...)
+	case "gke":
+		_, err = runCloudCLI([]string{"KUBECONFIG=" + path}, "gcloud", append([]string{"container", "clusters", "get-credentials", cluster.name, "--location", cluster.location}, s.scopeArgs()...)...)
+	case "aks":
+		name := fmt.Sprintf("aks_%s_%s", cluster.location, cluster.name)
+		_, err = runCloudCLI(nil, "az", append([]string{"aks", "get-credentials", "--name", cluster.name, "--resource-group", cluster.location, "--context", name, "--file", path, "--overwrite-existing"}, s.scopeArgs()...)...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s credentials for %s: %w", s.provider, cluster.name, err)
+	}
+	return nil
+}
+
+// discover lists the account's clusters and has the cloud CLI write
+// credentials for each of them.
+func (s discoverySource) discover(path string) (int, error) {
+	clusters, err := s.listClusters()
+	if err != nil {
+		return 0, err
+	}
+	for _, cluster := range clusters {
+		if err := s.writeCredentials(cluster, path); err != nil {
+			return 0, err
+		}
+	}
+	return len(clusters), nil
+}
+
+// discoveredKubeconfigPath returns a cache file dedicated to this set of
+// discovery specs, so separate invocations don't overwrite each other.
+func discoveredKubeconfigPath(specs []string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory: %w", err)
+	}
+	sorted := append([]string{}, specs...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return filepath.Join(dir, "kubectl-x", "discovery", hex.EncodeToString(sum[:8])+".yaml"), nil
+}
+
+// discoveryCacheTTL is how long a discovered kubeconfig is reused before the
+// clusters are enumerated again.
+const discoveryCacheTTL = time.Hour
+
+// discoverContexts enumerates clusters for every spec and writes a kubeconfig
+// holding a context for each, returning its path. A kubeconfig discovered
+// less than discoveryCacheTTL ago is reused as is.
+func discoverContexts(specs []string) (string, error) {
+	var sources []clusterSource
+	for _, spec := range specs {
+		parsed, err := parseDiscoverySpec(spec)
+		if err != nil {
+			return "", err
+		}
+		source, err := newClusterSource(parsed)
+		if err != nil {
+			return "", err
+		}
+		sources = append(sources, source)
+	}
+
+	path, err := discoveredKubeconfigPath(specs)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < discoveryCacheTTL {
+		return path, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create discovery cache: %w", err)
+	}
+
+	// Discover into a scratch file so that a failed run never leaves a
+	// partial kubeconfig behind for later runs to reuse.
+	file, err := os.CreateTemp(filepath.Dir(path), "discover-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to write discovered kubeconfig: %w", err)
+	}
+	scratch := file.Name()
+	defer os.Remove(scratch)
+	_, err = file.WriteString("apiVersion: v1\nkind: Config\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write discovered kubeconfig: %w", err)
+	}
+
+	found := 0
+	for _, source := range sources {
+		count, err := source.discover(scratch)
+		if err != nil {
+			return "", err
+		}
+		found += count
+	}
+	if found == 0 {
+		return "", fmt.Errorf("discovery found no clusters for %s", strings.Join(specs, ", "))
+	}
+	if err := os.Rename(scratch, path); err != nil {
+		return "", fmt.Errorf("failed to write discovered kubeconfig: %w", err)
+	}
+	return path, nil
+}
+
+// localCommands don't fan out to clusters, so they don't start discovery.
+var localCommands = map[string]bool{
+	"list": true, "version": true, "help": true, "completion": true,
+	cobra.ShellCompRequestCmd: true, cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// discoversFor reports whether cmd fans out and so needs --discover's
+// clusters. Subcommands such as "completion bash" go by their top-level
+// command.
+func discoversFor(cmd *cobra.Command) bool {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return !localCommands[cmd.Name()]
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cloudCall struct {
+	env  []string
+	name string
+	args []string
+}
+
+func fakeCloudCLI(t *testing.T, fn func(call cloudCall) ([]byte, error)) *[]cloudCall {
+	t.Helper()
+	var calls []cloudCall
+	old := runCloudCLI
+	runCloudCLI = func(env []string, name string, args ...string) ([]byte, error) {
+		call := cloudCall{env: env, name: name, args: args}
+		calls = append(calls, call)
+		return fn(call)
+	}
+	t.Cleanup(func() { runCloudCLI = old })
+	return &calls
+}
+
+func argValue(args []string, flag string) string {
+	for i, arg := range args {
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func TestParseDiscoverySpec(t *testing.T) {
+	source, err := parseDiscoverySpec("eks:profile=prod,region=eu-west-1")
+	require.NoError(t, err)
+	assert.Equal(t, discoverySource{provider: "eks", params: map[string]string{"profile": "prod", "region": "eu-west-1"}}, source)
+	assert.Equal(t, []string{"--profile", "prod", "--region", "eu-west-1"}, source.scopeArgs())
+
+	source, err = parseDiscoverySpec("aks")
+	require.NoError(t, err)
+	assert.Empty(t, source.scopeArgs())
+
+	_, err = parseDiscoverySpec("doks:project=x")
+	assert.ErrorContains(t, err, "unknown discovery provider")
+	_, err = parseDiscoverySpec("gke:region=x")
+	assert.ErrorContains(t, err, "expected one of project")
+}
+
+func TestListClusters(t *testing.T) {
+	tests := []struct {
+		spec     string
+		output   string
+		command  string
+		expected []discoveredCluster
+	}{
+		{
+			spec:     "eks:region=us-east-1",
+			output:   `{"clusters":["web","batch"]}`,
+			command:  "aws eks list-clusters --output json --region us-east-1",
+			expected: []discoveredCluster{{name: "web"}, {name: "batch"}},
+		},
+		{
+			spec:     "gke:project=acme",
+			output:   `[{"name":"web","location":"europe-west1"}]`,
+			command:  "gcloud container clusters list --format json --project acme",
+			expected: []discoveredCluster{{name: "web", location: "europe-west1"}},
+		},
+		{
+			spec:     "aks",
+			output:   `[{"name":"web","resourceGroup":"rg-prod"}]`,
+			command:  "az aks list --output json",
+			expected: []discoveredCluster{{name: "web", location: "rg-prod"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			calls := fakeCloudCLI(t, func(call cloudCall) ([]byte, error) { return []byte(tt.output), nil })
+			source, err := parseDiscoverySpec(tt.spec)
+			require.NoError(t, err)
+			clusters, err := source.listClusters()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, clusters)
+			require.Len(t, *calls, 1)
+			assert.Equal(t, tt.command, (*calls)[0].name+" "+strings.Join((*calls)[0].args, " "))
+		})
+	}
+
+	t.Run("cli failure", func(t *testing.T) {
+		fakeCloudCLI(t, func(call cloudCall) ([]byte, error) { return nil, fmt.Errorf("exit status 255") })
+		_, err := discoverySource{provider: "eks"}.listClusters()
+		assert.ErrorContains(t, err, "aws eks list-clusters failed")
+	})
+}
+
+func TestDiscoverContexts(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	fakeCloudCLI(t, func(call cloudCall) ([]byte, error) {
+		var path, name string
+		switch {
+		case call.name == "aws" && call.args[1] == "list-clusters":
+			return []byte(`{"clusters":["web"]}`), nil
+		case call.name == "gcloud" && call.args[2] == "list":
+			return []byte(`[{"name":"api","location":"europe-west1"}]`), nil
+		case call.name == "aws":
+			path, name = argValue(call.args, "--kubeconfig"), argValue(call.args, "--alias")
+		case call.name == "gcloud":
+			path, name = strings.TrimPrefix(call.env[0], "KUBECONFIG="), "gke_acme_europe-west1_"+call.args[3]
+		}
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		defer file.Close()
+		if !strings.Contains(readFile(t, path), "contexts:") {
+			fmt.Fprintln(file, "contexts:")
+		}
+		fmt.Fprintf(file, "- name: %s\n", name)
+		return nil, nil
+	})
+
+	path, err := discoverContexts([]string{"eks:region=us-east-1", "gke:project=acme"})
+	require.NoError(t, err)
+
+	kubeconfigPaths = []string{path}
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+	contexts, err := loadContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eks_us-east-1_web", "gke_acme_europe-west1_api"}, contexts)
+
+	again, err := discoveredKubeconfigPath([]string{"gke:project=acme", "eks:region=us-east-1"})
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+}
+
+func TestDiscoverContextsNoClusters(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	fakeCloudCLI(t, func(call cloudCall) ([]byte, error) { return []byte(`[]`), nil })
+	_, err := discoverContexts([]string{"aks:subscription=abc"})
+	assert.EqualError(t, err, "discovery found no clusters for aks:subscription=abc")
+}
+
+func TestDiscoverContextsReusesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	calls := fakeCloudCLI(t, func(call cloudCall) ([]byte, error) {
+		if call.args[1] == "list-clusters" {
+			return []byte(`{"clusters":["web"]}`), nil
+		}
+		return nil, nil
+	})
+	specs := []string{"eks:region=us-east-1"}
+
+	path, err := discoverContexts(specs)
+	require.NoError(t, err)
+	assert.Len(t, *calls, 2)
+
+	_, err = discoverContexts(specs)
+	require.NoError(t, err)
+	assert.Len(t, *calls, 2)
+
+	stale := time.Now().Add(-discoveryCacheTTL - time.Minute)
+	require.NoError(t, os.Chtimes(path, stale, stale))
+	_, err = discoverContexts(specs)
+	require.NoError(t, err)
+	assert.Len(t, *calls, 4)
+}
+
+func TestDiscoverContextsFailureKeepsNoCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	fakeCloudCLI(t, func(call cloudCall) ([]byte, error) { return []byte(`[]`), nil })
+	_, err := discoverContexts([]string{"aks:subscription=abc"})
+	require.Error(t, err)
+
+	path, err := discoveredKubeconfigPath([]string{"aks:subscription=abc"})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDiscoversFor(t *testing.T) {
+	root := &cobra.Command{Use: "kubectl-x"}
+	get := &cobra.Command{Use: "get"}
+	list := &cobra.Command{Use: "list"}
+	completion := &cobra.Command{Use: "completion"}
+	bash := &cobra.Command{Use: "bash"}
+	completion.AddCommand(bash)
+	root.AddCommand(get, list, completion)
+
+	assert.True(t, discoversFor(get))
+	assert.False(t, discoversFor(list))
+	assert.False(t, discoversFor(bash))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:                "edit",
+	Short:              "Edit a resource in each context, one at a time",
+	Long:               `Open the resource from each context in $KUBE_EDITOR or $EDITOR one context at a time. Each context can be skipped, and the diff is shown for confirmation before the edited object is saved with kubectl replace.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEdit(args)
+	},
+}
+
+const (
+	editSaved     = "SAVED"
+	editUnchanged = "UNCHANGED"
+	editSkipped   = "SKIPPED"
+	editError     = "ERROR"
+)
+
+func editorCommand() []string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// launchEditor opens path in the user's editor attached to the terminal. It is
+// a variable so tests can replace it.
+var launchEditor = func(path string) error {
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func unifiedDiff(original, edited, fromName, toName string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(original),
+		B:        difflib.SplitLines(edited),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	return diff
+}
+
+func runEdit(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("edit requires a resource, e.g. deployment/web")
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kubectl-x-edit-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var rows [][]string
+	failed := 0
+	for i, ctx := range contexts {
+		status, message := editInContext(ctx, args, filepath.Join(tmpDir, fmt.Sprintf("%d.yaml", i)), i+1, len(contexts))
+		if status == editError {
+			failed++
+		}
+		rows = append(rows, []string{ctx, status, message})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "RESULT", "MESSAGE"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts failed", failed, len(contexts))
+	}
+	return nil
+}
+
+func editInContext(ctx string, args []string, path string, position, total int) (string, string) {
+	coloredCtx := colorizeContext(ctx)
+	if !confirm(fmt.Sprintf("Edit in context %s (%d/%d)?", coloredCtx, position, total), false) {
+		return editSkipped, ""
+	}
+
+	original, err := runKubectlCommand(ctx, "get", append(append([]string{}, args...), "-o", "yaml"))
+	if err != nil {
+		return editError, lastLine(original)
+	}
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		return editError, err.Error()
+	}
+	if err := launchEditor(path); err != nil {
+		return editError, fmt.Sprintf("editor failed: %v", err)
+	}
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return editError, err.Error()
+	}
+	if string(edited) == original {
+		return editUnchanged, ""
+	}
+
+	fmt.Fprint(os.Stderr, unifiedDiff(original, string(edited), ctx+" (live)", ctx+" (edited)"))
+	if !confirm(fmt.Sprintf("Save changes to %s?", coloredCtx), false) {
+		return editSkipped, "changes discarded"
+	}
+
+	output, err := runKubectlCommand(ctx, "replace", []string{"-f", path})
+	if err != nil {
+		return editError, lastLine(output)
+	}
+	return editSaved, lastLine(output)
+}
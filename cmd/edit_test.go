@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditCmd(t *testing.T) {
+	require.NotNil(t, editCmd)
+	assert.Equal(t, "edit", editCmd.Use)
+	assert.True(t, editCmd.DisableFlagParsing)
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("KUBE_EDITOR", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, []string{"vi"}, editorCommand())
+
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, editorCommand())
+
+	t.Setenv("KUBE_EDITOR", "nano")
+	assert.Equal(t, []string{"nano"}, editorCommand())
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	diff := unifiedDiff("replicas: 2\nimage: web:1\n", "replicas: 3\nimage: web:1\n", "live", "edited")
+	assert.Contains(t, diff, "--- live")
+	assert.Contains(t, diff, "+++ edited")
+	assert.Contains(t, diff, "-replicas: 2")
+	assert.Contains(t, diff, "+replicas: 3")
+	assert.Empty(t, unifiedDiff("a\n", "a\n", "live", "edited"))
+}
+
+func TestRunEdit(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2", "ctx3"})
+	t.Setenv("KUBECONFIG", path)
+
+	var replaced []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if subcommand == "get" {
+			assert.Equal(t, []string{"deploy/web", "-n", "web", "-o", "yaml"}, extraArgs)
+			return "spec:\n  replicas: 2\n", nil
+		}
+		replaced = append(replaced, context)
+		return "deployment.apps/web replaced\n", nil
+	})
+
+	edits := map[string]bool{}
+	old := launchEditor
+	launchEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		edits[path] = true
+		if len(edits) == 1 {
+			return os.WriteFile(path, []byte(strings.Replace(string(data), "2", "3", 1)), 0600)
+		}
+		return nil
+	}
+	t.Cleanup(func() { launchEditor = old })
+
+	oldInput := confirmInput
+	// ctx1: edit and save; ctx2: edit but leave unchanged; ctx3: skip.
+	confirmInput = strings.NewReader("y\ny\ny\nn\n")
+	t.Cleanup(func() { confirmInput = oldInput })
+
+	var err error
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			err = runEdit([]string{"deploy/web", "-n", "web"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctx1"}, replaced)
+	assert.Contains(t, stderr, "+  replicas: 3")
+	assert.Equal(t, "CONTEXT  RESULT       MESSAGE\n"+
+		"ctx1     SAVED        deployment.apps/web replaced\n"+
+		"ctx2     UNCHANGED\n"+
+		"ctx3     SKIPPED\n", output)
+}
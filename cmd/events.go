@@ -1,18 +1,233 @@
 package cmd
 
 import (
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 var eventsCmd = &cobra.Command{
 	Use:                "events",
 	Short:              "Run kubectl events against all contexts",
-	Long:               `Run kubectl events command against all contexts in parallel. Supports streaming with -w/--watch flag.`,
+	Long:               `Run kubectl events command against all contexts in parallel. Supports streaming with -w/--watch flag; watched events from all contexts are merged in timestamp order, holding lines for --reorder-window (default 2s) to absorb late arrivals.`,
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if isWatchMode(args) {
-			return runStreamingCommand("events", args, false)
+			return runEventsWatch(args)
 		}
 		return runCommand("events", args)
 	},
 }
+
+const defaultReorderWindow = 2 * time.Second
+
+// reorderWindow extracts --reorder-window, the time merged streams hold
+// lines for before printing them in timestamp order.
+func reorderWindow(args []string) (time.Duration, []string, error) {
+	windows, args := extractStringFlag(args, "--reorder-window")
+	if len(windows) == 0 {
+		return defaultReorderWindow, args, nil
+	}
+	window, err := time.ParseDuration(windows[len(windows)-1])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid --reorder-window: %w", err)
+	}
+	return window, args, nil
+}
+
+func runEventsWatch(args []string) error {
+	window, args, err := reorderWindow(args)
+	if err != nil {
+		return err
+	}
+	if window <= 0 {
+		return runStreamingCommand("events", args, false)
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	headerWidth := len("CONTEXT")
+	for _, ctx := range contexts {
+		if len(ctx) > headerWidth {
+			headerWidth = len(ctx)
+		}
+	}
+	contextHeader := padRight("CONTEXT", headerWidth)
+
+	merger := newEventMerger(os.Stdout, window)
+	stop := make(chan struct{})
+	flushed := make(chan struct{})
+	go func() {
+		merger.run(stop)
+		close(flushed)
+	}()
+
+	var headerOnce sync.Once
+	err = streamAcrossContexts(contexts, "events", func(int, string) []string { return args }, streamOptions{
+		filterHeaders: true,
+		handleStdout: func(reader io.Reader, coloredCtx, padding string) {
+			scanner := newLineScanner(reader)
+			firstLine := true
+			for scanner.Scan() {
+				line := scanner.Text()
+				if firstLine {
+					firstLine = false
+					headerOnce.Do(func() {
+						if noHeaders {
+							return
+						}
+						merger.writeNow(contextPrefix(contextHeader, "") + line)
+					})
+					continue
+				}
+				if !keepRow(stripColor(coloredCtx) + padding + "  " + line) {
+					continue
+				}
+				merger.add(eventTime(line, time.Now()), contextPrefix(coloredCtx, padding)+line)
+			}
+			finishScan(scanner, reader, coloredCtx)
+		},
+	})
+
+	close(stop)
+	<-flushed
+	return err
+}
+
+var (
+	agePattern   = regexp.MustCompile(`^(\d+[smhdy])+$`)
+	ageComponent = regexp.MustCompile(`(\d+)([smhdy])`)
+)
+
+// parseEventAge parses kubectl's human-readable ages such as "45s", "3m10s",
+// or "2d5h".
+func parseEventAge(age string) (time.Duration, bool) {
+	if !agePattern.MatchString(age) {
+		return 0, false
+	}
+	units := map[string]time.Duration{
+		"s": time.Second,
+		"m": time.Minute,
+		"h": time.Hour,
+		"d": 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+	var total time.Duration
+	for _, match := range ageComponent.FindAllStringSubmatch(age, -1) {
+		n, _ := strconv.Atoi(match[1])
+		total += time.Duration(n) * units[match[2]]
+	}
+	return total, true
+}
+
+// eventTime estimates when an event happened from its LAST SEEN column,
+// falling back to the arrival time when the age can't be parsed.
+func eventTime(line string, arrived time.Time) time.Time {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return arrived
+	}
+	age, ok := parseEventAge(fields[0])
+	if !ok {
+		return arrived
+	}
+	return arrived.Add(-age)
+}
+
+type timedLine struct {
+	at   time.Time
+	seq  int
+	text string
+}
+
+type timedLineHeap []timedLine
+
+func (h timedLineHeap) Len() int { return len(h) }
+func (h timedLineHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+func (h timedLineHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *timedLineHeap) Push(x interface{}) { *h = append(*h, x.(timedLine)) }
+func (h *timedLineHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// eventMerger holds lines for a short window and emits them in timestamp
+// order, so lines from different contexts read as one timeline.
+type eventMerger struct {
+	mu      sync.Mutex
+	pending timedLineHeap
+	seq     int
+	window  time.Duration
+	dest    io.Writer
+}
+
+func newEventMerger(dest io.Writer, window time.Duration) *eventMerger {
+	return &eventMerger{dest: dest, window: window}
+}
+
+func (m *eventMerger) add(at time.Time, text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	heap.Push(&m.pending, timedLine{at: at, seq: m.seq, text: text})
+}
+
+// writeNow prints text immediately, ahead of anything still pending.
+func (m *eventMerger) writeNow(text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fmt.Fprintln(m.dest, text)
+}
+
+// flush emits every pending line that happened at or before cutoff.
+func (m *eventMerger) flush(cutoff time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.pending.Len() > 0 && !m.pending[0].at.After(cutoff) {
+		fmt.Fprintln(m.dest, heap.Pop(&m.pending).(timedLine).text)
+	}
+}
+
+func (m *eventMerger) flushAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.pending.Len() > 0 {
+		fmt.Fprintln(m.dest, heap.Pop(&m.pending).(timedLine).text)
+	}
+}
+
+// minMergeTick keeps tiny windows, such as --reorder-window=1ns, from
+// flushing in a busy loop.
+const minMergeTick = 10 * time.Millisecond
+
+func (m *eventMerger) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(max(m.window/4, minMergeTick))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			m.flushAll()
+			return
+		case now := <-ticker.C:
+			m.flush(now.Add(-m.window))
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,3 +17,163 @@ func TestEventsCmd(t *testing.T) {
 	assert.Equal(t, "events", eventsCmd.Use)
 	assert.True(t, eventsCmd.DisableFlagParsing)
 }
+
+func TestParseEventAge(t *testing.T) {
+	tests := []struct {
+		age      string
+		expected time.Duration
+		ok       bool
+	}{
+		{age: "45s", expected: 45 * time.Second, ok: true},
+		{age: "3m10s", expected: 3*time.Minute + 10*time.Second, ok: true},
+		{age: "2d5h", expected: 53 * time.Hour, ok: true},
+		{age: "0s", expected: 0, ok: true},
+		{age: "<unknown>", ok: false},
+		{age: "Normal", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			age, ok := parseEventAge(tt.age)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, age)
+		})
+	}
+}
+
+func TestEventTime(t *testing.T) {
+	arrived := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, arrived.Add(-30*time.Second), eventTime("30s    Normal   Scheduled   pod/web   Assigned", arrived))
+	assert.Equal(t, arrived, eventTime("<unknown>   Normal   Scheduled", arrived))
+	assert.Equal(t, arrived, eventTime("", arrived))
+}
+
+func TestEventMergerFlushesInTimestampOrder(t *testing.T) {
+	var buf bytes.Buffer
+	m := newEventMerger(&buf, time.Second)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	m.writeNow("CONTEXT  LAST SEEN")
+	m.add(base.Add(2*time.Second), "ctx1  third")
+	m.add(base, "ctx2  first")
+	m.add(base.Add(time.Second), "ctx1  second")
+	m.add(base.Add(time.Second), "ctx2  second-tie")
+	m.add(base.Add(10*time.Second), "ctx2  later")
+
+	m.flush(base.Add(5 * time.Second))
+	assert.Equal(t, "CONTEXT  LAST SEEN\nctx2  first\nctx1  second\nctx2  second-tie\nctx1  third\n", buf.String())
+
+	m.flush(base.Add(10 * time.Second))
+	assert.Contains(t, buf.String(), "ctx2  later\n")
+}
+
+func TestEventMergerRunFlushesOnStop(t *testing.T) {
+	var buf bytes.Buffer
+	m := newEventMerger(&buf, time.Hour)
+	m.add(time.Now(), "ctx1  pending")
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		m.run(stop)
+		close(done)
+	}()
+	close(stop)
+	<-done
+
+	assert.Equal(t, "ctx1  pending\n", buf.String())
+}
+
+func TestEventMergerRunTinyWindow(t *testing.T) {
+	var buf bytes.Buffer
+	m := newEventMerger(&buf, time.Nanosecond)
+	m.add(time.Now(), "ctx1  flushed")
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		m.run(stop)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return buf.String() == "ctx1  flushed\n"
+	}, time.Second, minMergeTick)
+	close(stop)
+	<-done
+}
+
+func TestReorderWindow(t *testing.T) {
+	window, args, err := reorderWindow([]string{"-w", "--reorder-window=5s"})
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, window)
+	assert.Equal(t, []string{"-w"}, args)
+
+	window, _, err = reorderWindow(nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultReorderWindow, window)
+
+	_, _, err = reorderWindow([]string{"--reorder-window", "soon"})
+	assert.Error(t, err)
+}
+
+// fakeEventsKubectl puts a kubectl on PATH that prints a header and two
+// events, and targets contexts ctx1 and ctx2.
+func fakeEventsKubectl(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as kubectl")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"echo 'LAST SEEN   TYPE      REASON    OBJECT    MESSAGE'\n" +
+		"echo '5s          Normal    Pulled    pod/web   pulled'\n" +
+		"echo '3s          Warning   BackOff   pod/web   back-off'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"}))
+}
+
+func runEventsWatchOutput(t *testing.T) string {
+	t.Helper()
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, runEventsWatch([]string{"-w", "--reorder-window=10ms"}))
+		})
+	})
+	return output
+}
+
+func TestRunEventsWatchAppliesGrep(t *testing.T) {
+	fakeEventsKubectl(t)
+	setGrep(t, []string{"BackOff"}, nil)
+
+	output := runEventsWatchOutput(t)
+	assert.Contains(t, output, "CONTEXT  LAST SEEN")
+	assert.Contains(t, output, "ctx1     3s          Warning   BackOff")
+	assert.Contains(t, output, "ctx2     3s          Warning   BackOff")
+	assert.NotContains(t, output, "Pulled")
+}
+
+func TestRunEventsWatchNoHeaders(t *testing.T) {
+	fakeEventsKubectl(t)
+	noHeaders = true
+	t.Cleanup(func() { noHeaders = false })
+
+	output := runEventsWatchOutput(t)
+	assert.NotContains(t, output, "LAST SEEN")
+	assert.Contains(t, output, "ctx1     5s          Normal    Pulled")
+}
+
+func TestRunEventsWatchHideContext(t *testing.T) {
+	fakeEventsKubectl(t)
+	hideContext = true
+	t.Cleanup(func() { hideContext = false })
+
+	output := runEventsWatchOutput(t)
+	assert.Contains(t, output, "LAST SEEN   TYPE      REASON    OBJECT    MESSAGE\n")
+	assert.NotContains(t, output, "CONTEXT")
+	assert.NotContains(t, output, "ctx1")
+}
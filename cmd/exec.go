@@ -2,27 +2,37 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
-	"syscall"
 	"time"
 
 	"golang.org/x/term"
 )
 
 type contextResult struct {
-	context string
-	output  string
-	err     error
+	context  string
+	output   string
+	err      error
+	duration time.Duration
+	// cells, when set, are the rows of a table read from the API server,
+	// header first, which are merged in place of output.
+	cells [][]string
 }
 
+// progressDisabled suppresses the progress bar for callers that own the
+// terminal themselves, such as the dash TUI.
+var progressDisabled bool
+
 func stderrIsTerminal() bool {
 	return term.IsTerminal(int(os.Stderr.Fd()))
 }
@@ -42,7 +52,9 @@ func lerp(display, target float64) float64 {
 	return display
 }
 
-func renderProgressBar(displayStarted, displayCompleted float64, total int) string {
+// renderProgressBar draws the bar followed by the completed count and, once
+// known, a red failure count and the estimated time remaining.
+func renderProgressBar(displayStarted, displayCompleted float64, total, failed int, eta time.Duration) string {
 	if total == 0 {
 		return ""
 	}
@@ -70,7 +82,25 @@ func renderProgressBar(displayStarted, displayCompleted float64, total int) stri
 	}
 	bar.WriteString(colorReset)
 
-	return fmt.Sprintf("\r\033[K %s %d/%d complete", bar.String(), int(displayCompleted), total)
+	status := fmt.Sprintf("\r\033[K %s %d/%d complete", bar.String(), int(displayCompleted), total)
+	if failed > 0 {
+		status += fmt.Sprintf(", %s%d failed%s", colorRed, failed, colorReset)
+	}
+	if eta > 0 {
+		status += ", ETA " + formatDuration(eta)
+	}
+	return status
+}
+
+// estimateRemaining extrapolates the time left from the average duration of
+// the completed contexts, running parallel contexts at a time.
+func estimateRemaining(elapsed time.Duration, completed, total, parallel int) time.Duration {
+	if completed == 0 || completed >= total || parallel <= 0 {
+		return 0
+	}
+	remaining := total - completed
+	waves := (remaining + parallel - 1) / parallel
+	return elapsed / time.Duration(completed) * time.Duration(waves)
 }
 
 func clearProgress() {
@@ -80,16 +110,22 @@ func clearProgress() {
 type progressBar struct {
 	started   atomic.Int32
 	completed atomic.Int32
+	failed    atomic.Int32
 	total     int
 	stop      chan struct{}
 	done      chan struct{}
+
+	mu      sync.Mutex
+	starts  map[string]time.Time
+	elapsed time.Duration // summed durations of completed contexts
 }
 
 func newProgressBar(total int) *progressBar {
 	p := &progressBar{
-		total: total,
-		stop:  make(chan struct{}),
-		done:  make(chan struct{}),
+		total:  total,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		starts: make(map[string]time.Time),
 	}
 	go p.animate()
 	return p
@@ -115,11 +151,34 @@ func (p *progressBar) animate() {
 			displayStarted = lerp(displayStarted, targetStarted)
 			displayCompleted = lerp(displayCompleted, targetCompleted)
 
-			fmt.Fprint(os.Stderr, renderProgressBar(displayStarted, displayCompleted, p.total))
+			fmt.Fprint(os.Stderr, renderProgressBar(displayStarted, displayCompleted, p.total, int(p.failed.Load()), p.eta()))
 		}
 	}
 }
 
+func (p *progressBar) contextStarted(context string) {
+	p.mu.Lock()
+	p.starts[context] = time.Now()
+	p.mu.Unlock()
+	p.started.Add(1)
+}
+
+func (p *progressBar) contextDone(context string, err error) {
+	p.mu.Lock()
+	p.elapsed += time.Since(p.starts[context])
+	p.mu.Unlock()
+	if err != nil {
+		p.failed.Add(1)
+	}
+	p.completed.Add(1)
+}
+
+func (p *progressBar) eta() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return estimateRemaining(p.elapsed, int(p.completed.Load()), p.total, min(batchSize, p.total))
+}
+
 func (p *progressBar) finish() {
 	close(p.stop)
 	<-p.done
@@ -136,7 +195,14 @@ func hasSortBy(args []string) bool {
 
 func runCommand(subcommand string, extraArgs []string) error {
 	if hasSortBy(extraArgs) {
-		fmt.Fprintf(os.Stderr, "Warning: --sort-by sorts within each context independently and may not produce the expected global ordering. See https://github.com/platformersdev/kubectl-x/issues/29\n")
+		var column string
+		column, extraArgs = clientSideSortBy(extraArgs)
+		if column != "" {
+			mergedSortColumn = column
+			defer func() { mergedSortColumn = "" }()
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: --sort-by sorts within each context independently and may not produce the expected global ordering. See https://github.com/platformersdev/kubectl-x/issues/29\n")
+		}
 	}
 
 	contexts, err := getContexts()
@@ -148,14 +214,149 @@ func runCommand(subcommand string, extraArgs []string) error {
 		return fmt.Errorf("no contexts found in kubeconfig")
 	}
 
-	showStatus := stderrIsTerminal()
-	total := len(contexts)
+	warnNamespaceSkew(contexts, subcommand, extraArgs)
+
+	extraArgs, err = inlineJSONPathFile(extraArgs)
+	if err != nil {
+		return err
+	}
+	text, usesContext, err := goTemplate(extraArgs)
+	if err != nil {
+		return err
+	}
+	if usesContext {
+		return runGoTemplate(contexts, subcommand, extraArgs, text)
+	}
+	if usesContextVariable(extraArgs) {
+		results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+			return runKubectlCommand(context, subcommand, withContextVariable(extraArgs, context))
+		})
+		return formatVerbatimOutput(results)
+	}
+
+	var results []contextResult
+	if request, ok := parseTableRequest(extraArgs); ok && subcommand == "get" {
+		results = runTableGet(contexts, extraArgs, request)
+	} else {
+		results = runAcrossContexts(contexts, subcommand, extraArgs)
+	}
+
+	outputFormat := detectOutputFormat(extraArgs)
+	return formatOutput(results, outputFormat, subcommand)
+}
+
+// runConfirmedCommand is runCommand for mutating subcommands: it lists the
+// target contexts and waits for confirmation (skipped with --yes/-y) before
+// running, then prints a per-context summary instead of merged table output.
+func runConfirmedCommand(subcommand string, extraArgs []string) error {
+	yes, extraArgs := extractBoolFlag(extraArgs, "--yes", "-y")
+	canary, extraArgs := extractCanary(extraArgs)
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	if readsStdin(extraArgs) && !yes {
+		restore, err := promptFromTerminal()
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	warnNamespaceSkew(contexts, subcommand, extraArgs)
+	description := strings.TrimSpace("kubectl " + subcommand + " " + strings.Join(extraArgs, " "))
+	if !confirmContexts(description, contexts, yes) {
+		return fmt.Errorf("aborted")
+	}
+
+	results, canaryErr := runWithCanary(contexts, canary, yes, func(contexts []string) []contextResult {
+		return runAcrossContexts(contexts, subcommand, extraArgs)
+	})
+	if results == nil {
+		return canaryErr
+	}
+
+	var formatErr error
+	switch format := detectOutputFormat(extraArgs); {
+	case format != formatDefault || onlyErrors:
+		formatErr = formatOutput(results, format, subcommand)
+	case subcommand == "apply":
+		formatErr = formatApplyOutput(results)
+	default:
+		formatErr = formatSummaryOutput(results)
+	}
+	if canaryErr != nil {
+		return canaryErr
+	}
+	return formatErr
+}
+
+// runStreamingAcrossContexts runs a long-running subcommand against every
+// context, echoing each output line to stderr with a context prefix as it
+// arrives so progress is visible, and returns the collected results.
+func runStreamingAcrossContexts(contexts []string, subcommand string, extraArgs []string) []contextResult {
+	maxWidth := 0
+	for _, ctx := range contexts {
+		if len(ctx) > maxWidth {
+			maxWidth = len(ctx)
+		}
+	}
+
+	printLegendOnce(os.Stderr, contexts)
+	results := make([]contextResult, len(contexts))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, batchSize)
+
+	for i, ctx := range contexts {
+		wg.Add(1)
+		go func(index int, context string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
 
-	var progress *progressBar
-	if showStatus {
-		progress = newProgressBar(total)
+			coloredCtx := colorizeContext(context)
+			padding := fillWidth(context, maxWidth)
+			start := time.Now()
+			output, err := runKubectlCommandStreaming(context, subcommand, extraArgs, func(line string) {
+				mu.Lock()
+				fmt.Fprintf(os.Stderr, "%s%s\n", contextPrefix(coloredCtx, padding), line)
+				mu.Unlock()
+			})
+			results[index] = contextResult{context: context, output: output, err: err, duration: time.Since(start)}
+		}(i, ctx)
 	}
 
+	wg.Wait()
+	recordResults(results)
+	return results
+}
+
+// runAcrossContexts runs the kubectl subcommand against every context,
+// batchSize at a time, showing a progress bar when stderr is a terminal.
+func runAcrossContexts(contexts []string, subcommand string, extraArgs []string) []contextResult {
+	return runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		return runKubectlCommand(context, subcommand, extraArgs)
+	})
+}
+
+// runAcrossContextsFunc is runAcrossContexts for callers that need to vary
+// the invocation per context.
+func runAcrossContextsFunc(contexts []string, run func(context string) (string, error)) []contextResult {
+	return runAcrossContextsIndexed(contexts, func(_ int, context string) (string, error) {
+		return run(context)
+	})
+}
+
+// runAcrossContextsIndexed is runAcrossContextsFunc that also passes the
+// context's position in contexts, for callers that collect per-context
+// results alongside the output.
+func runAcrossContextsIndexed(contexts []string, run func(index int, context string) (string, error)) []contextResult {
+	printLegendOnce(os.Stderr, contexts)
+	progress := newProgressDisplay(contexts)
+
 	results := make([]contextResult, len(contexts))
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, batchSize)
@@ -168,18 +369,20 @@ func runCommand(subcommand string, extraArgs []string) error {
 			defer func() { <-semaphore }()
 
 			if progress != nil {
-				progress.started.Add(1)
+				progress.contextStarted(context)
 			}
 
-			output, err := runKubectlCommand(context, subcommand, extraArgs)
+			start := time.Now()
+			output, err := run(index, context)
 			results[index] = contextResult{
-				context: context,
-				output:  output,
-				err:     err,
+				context:  context,
+				output:   output,
+				err:      err,
+				duration: time.Since(start),
 			}
 
 			if progress != nil {
-				progress.completed.Add(1)
+				progress.contextDone(context, err)
 			}
 		}(i, ctx)
 	}
@@ -190,19 +393,197 @@ func runCommand(subcommand string, extraArgs []string) error {
 		progress.finish()
 	}
 
-	outputFormat := detectOutputFormat(extraArgs)
-	return formatOutput(results, outputFormat, subcommand)
+	if ignoreMissingTypes {
+		results = emptyMissingTypes(results)
+	}
+	recordResults(results)
+	return results
+}
+
+// recorded keeps a result for every context the command ran against, in the
+// order contexts were first seen. Every fan-out records into it so that
+// reports and the summary footer see the same accounting. Commands run
+// several fan-outs, such as the get after a scale, so a later result only
+// replaces a failure with another failure.
+var recorded struct {
+	sync.Mutex
+	order   []string
+	results map[string]contextResult
+}
+
+func recordResults(results []contextResult) {
+	recorded.Lock()
+	defer recorded.Unlock()
+	if recorded.results == nil {
+		recorded.results = make(map[string]contextResult)
+	}
+	for _, result := range results {
+		previous, seen := recorded.results[result.context]
+		if !seen {
+			recorded.order = append(recorded.order, result.context)
+		}
+		if seen && previous.err != nil && result.err == nil {
+			continue
+		}
+		recorded.results[result.context] = result
+	}
 }
 
-func runKubectlCommand(context, subcommand string, extraArgs []string) (string, error) {
-	args := []string{"--context", context, subcommand}
-	args = append(args, extraArgs...)
+// recordSucceeded overrides the recorded failure of contexts whose kubectl
+// exited non-zero for an outcome the command does not count as a failure,
+// such as objects that were already gone or drift found by diff, so that
+// the summary footer and reports agree with the command's own table.
+func recordSucceeded(results []contextResult) {
+	recorded.Lock()
+	defer recorded.Unlock()
+	for _, result := range results {
+		if _, seen := recorded.results[result.context]; seen {
+			result.err = nil
+			recorded.results[result.context] = result
+		}
+	}
+}
 
+func recordedResults() []contextResult {
+	recorded.Lock()
+	defer recorded.Unlock()
+	results := make([]contextResult, 0, len(recorded.order))
+	for _, context := range recorded.order {
+		results = append(results, recorded.results[context])
+	}
+	return results
+}
+
+func resetRecordedResults() {
+	recorded.Lock()
+	defer recorded.Unlock()
+	recorded.order = nil
+	recorded.results = nil
+}
+
+// stdinSource is read by bufferedStdin; tests replace it.
+var stdinSource io.Reader = os.Stdin
+
+var (
+	stdinOnce sync.Once
+	stdinData []byte
+	stdinErr  error
+)
+
+// bufferedStdin reads stdin once so that every per-context kubectl process
+// can be given its own copy, e.g. for "apply -f -".
+func bufferedStdin() ([]byte, error) {
+	stdinOnce.Do(func() {
+		stdinData, stdinErr = io.ReadAll(stdinSource)
+		if stdinErr != nil {
+			stdinErr = fmt.Errorf("failed to read stdin: %w", stdinErr)
+		}
+	})
+	return stdinData, stdinErr
+}
+
+// readsStdin reports whether kubectl is asked to read a file from stdin.
+func readsStdin(args []string) bool {
+	for i, arg := range args {
+		switch arg {
+		case "-f=-", "--filename=-", "-f-":
+			return true
+		case "-f", "--filename":
+			if i+1 < len(args) && args[i+1] == "-" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// kubectlCommand builds a kubectl invocation that sees the same kubeconfig
+// files the contexts were loaded from.
+func kubectlCommand(args ...string) *exec.Cmd {
 	cmd := exec.Command("kubectl", args...)
+	if len(kubeconfigPaths) > 0 {
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+strings.Join(kubeconfigPaths, string(filepath.ListSeparator)))
+	}
+	return cmd
+}
+
+// kubectlArgs builds the argument list for one context, adding the root
+// --namespace when one is forced.
+func kubectlArgs(context, subcommand string, extraArgs []string) []string {
+	args := []string{"--context", context}
+	if forcedNamespace != "" {
+		args = append(args, "--namespace", forcedNamespace)
+	}
+	args = append(args, subcommand)
+	return append(args, extraArgs...)
+}
+
+// runKubectlCommand is a variable so tests can substitute a fake kubectl.
+var runKubectlCommand = func(context, subcommand string, extraArgs []string) (string, error) {
+	args := kubectlArgs(context, subcommand, extraArgs)
+
+	cmd := kubectlCommand(args...)
+	if readsStdin(extraArgs) {
+		data, err := bufferedStdin()
+		if err != nil {
+			return "", err
+		}
+		cmd.Stdin = bytes.NewReader(data)
+	}
 	output, err := cmd.CombinedOutput()
 	return string(output), err
 }
 
+// runKubectlInteractive runs kubectl attached to this process's terminal so
+// that TTY sessions (exec -it) work. It is a variable so tests can replace it.
+var runKubectlInteractive = func(context, subcommand string, extraArgs []string) error {
+	args := kubectlArgs(context, subcommand, extraArgs)
+
+	cmd := kubectlCommand(args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// runKubectlCommandStreaming behaves like runKubectlCommand but hands each line
+// of combined output to onLine as it arrives, for commands that block until
+// some condition is met. It is a variable so tests can replace it.
+var runKubectlCommandStreaming = func(context, subcommand string, extraArgs []string, onLine func(string)) (string, error) {
+	args := kubectlArgs(context, subcommand, extraArgs)
+
+	pr, pw := io.Pipe()
+	cmd := kubectlCommand(args...)
+	if readsStdin(extraArgs) {
+		data, err := bufferedStdin()
+		if err != nil {
+			return "", err
+		}
+		cmd.Stdin = bytes.NewReader(data)
+	}
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var output strings.Builder
+	scanDone := make(chan struct{})
+	go func() {
+		defer close(scanDone)
+		scanner := newLineScanner(pr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			output.WriteString(line + "\n")
+			onLine(line)
+		}
+		finishScan(scanner, pr, colorizeContext(context))
+		io.Copy(io.Discard, pr)
+	}()
+
+	err := cmd.Run()
+	pw.Close()
+	<-scanDone
+	return output.String(), err
+}
+
 func runStreamingCommand(subcommand string, extraArgs []string, filterHeaders bool) error {
 	contexts, err := getContexts()
 	if err != nil {
@@ -213,120 +594,457 @@ func runStreamingCommand(subcommand string, extraArgs []string, filterHeaders bo
 		return fmt.Errorf("no contexts found in kubeconfig")
 	}
 
+	warnNamespaceSkew(contexts, subcommand, extraArgs)
+	return streamAcrossContexts(contexts, subcommand, func(int, string) []string { return extraArgs }, streamOptions{filterHeaders: filterHeaders})
+}
+
+type streamOptions struct {
+	// filterHeaders prints the first stdout line once, under a CONTEXT column.
+	filterHeaders bool
+	// handleStdout, when set, consumes each context's stdout in place of the
+	// default line prefixing.
+	handleStdout func(reader io.Reader, coloredCtx, padding string)
+	// reconnect, when set, restarts a context's kubectl after a backoff if it
+	// fails before the user interrupts, having printed something. A process
+	// that exits cleanly, such as logs -f of a completed pod, is done. Given
+	// the arguments of the process that ended and when its last output
+	// arrived, it returns the arguments for the new process, or nil to give
+	// up.
+	reconnect func(context string, args []string, lastOutput time.Time) []string
+	// raw copies output through as it arrives, without splitting it into
+	// lines or prefixing them, for binary or very high-volume output.
+	raw bool
+	// throttle, when set, drops stdout lines beyond its rates.
+	throttle *lineThrottle
+	// idle, when set, is told about every context's stdout.
+	idle *idleWatcher
+	// stop, when closed, stops every stream as an interrupt does.
+	stop <-chan struct{}
+}
+
+const (
+	reconnectInitialBackoff = time.Second
+	reconnectMaxBackoff     = 30 * time.Second
+	// reconnectHealthyRun is how long a stream must have run for the backoff
+	// to start over.
+	reconnectHealthyRun = time.Minute
+)
+
+// nextReconnectBackoff doubles the backoff up to reconnectMaxBackoff. It
+// starts over for the first reconnect and when the stream that just ended
+// had been running fine.
+func nextReconnectBackoff(backoff, ran time.Duration) time.Duration {
+	if backoff == 0 || ran >= reconnectHealthyRun {
+		return reconnectInitialBackoff
+	}
+	return min(backoff*2, reconnectMaxBackoff)
+}
+
+// lastReadReader records when data last came through it.
+type lastReadReader struct {
+	reader io.Reader
+	last   *atomic.Int64
+}
+
+func (r lastReadReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.last.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// streamAcrossContexts runs a long-lived kubectl process per context with
+// arguments from argsFor, prefixing output lines with the context until all
+// processes exit or the user interrupts. It fails when any process did.
+func streamAcrossContexts(contexts []string, subcommand string, argsFor func(index int, context string) []string, opts streamOptions) error {
+	if err := rejectOnlyErrors(subcommand); err != nil {
+		return err
+	}
+	s := newStreamer(contexts, subcommand, opts)
+	for i, ctx := range contexts {
+		s.start(ctx, argsFor(i, ctx))
+	}
+	return s.wait()
+}
+
+// streamer runs the long-lived kubectl processes of streamAcrossContexts.
+// Processes can be added while it runs.
+type streamer struct {
+	subcommand string
+	maxWidth   int
+	opts       streamOptions
+	sigChan    chan os.Signal
+
+	mu         sync.Mutex // serializes output lines
+	headerOnce sync.Once
+	wg         sync.WaitGroup
+
+	procMu      sync.Mutex
+	interrupted chan struct{}
+	running     map[*exec.Cmd]bool
+	results     []contextResult
+}
+
+// newStreamer starts listening for interrupts; contexts are only used to
+// align the prefixes and for the --legend.
+func newStreamer(contexts []string, subcommand string, opts streamOptions) *streamer {
+	printLegendOnce(os.Stderr, contexts)
 	maxWidth := 0
 	for _, ctx := range contexts {
 		if len(ctx) > maxWidth {
 			maxWidth = len(ctx)
 		}
 	}
-	if filterHeaders && maxWidth < len("CONTEXT") {
+	if opts.filterHeaders && maxWidth < len("CONTEXT") {
 		maxWidth = len("CONTEXT")
 	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	defer signal.Stop(sigChan)
+	s := &streamer{
+		subcommand:  subcommand,
+		maxWidth:    maxWidth,
+		opts:        opts,
+		sigChan:     make(chan os.Signal, 1),
+		interrupted: make(chan struct{}),
+		running:     make(map[*exec.Cmd]bool),
+	}
+	signal.Notify(s.sigChan, shutdownSignals...)
+	return s
+}
 
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	var cmds []*exec.Cmd
-	var headerOnce sync.Once
+// start runs kubectl with args against context, restarting it per
+// opts.reconnect. How the last process exited is recorded for wait; one
+// stopped by an interrupt has not failed.
+func (s *streamer) start(context string, args []string) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		coloredCtx := colorizeContext(context)
+		padding := fillWidth(context, s.maxWidth)
+		var backoff time.Duration
+		var lastOutput atomic.Int64
+		began := time.Now()
+		var err error
+		defer func() {
+			s.procMu.Lock()
+			s.results = append(s.results, contextResult{context: context, err: err, duration: time.Since(began)})
+			s.procMu.Unlock()
+		}()
 
-	for _, ctx := range contexts {
-		kubectlArgs := []string{"--context", ctx, subcommand}
-		kubectlArgs = append(kubectlArgs, extraArgs...)
+		for {
+			lastOutput.Store(0)
+			s.procMu.Lock()
+			if s.isInterrupted() {
+				s.procMu.Unlock()
+				return
+			}
+			cmd := kubectlCommand(kubectlArgs(context, s.subcommand, args)...)
+			started := time.Now()
+			var wait func() error
+			wait, err = startStream(cmd, coloredCtx, padding, s.maxWidth, &s.mu, &s.headerOnce, &lastOutput, s.opts)
+			if err == nil {
+				s.running[cmd] = true
+			}
+			s.procMu.Unlock()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Context %s: %v\n", context, err)
+				return
+			}
 
-		cmd := exec.Command("kubectl", kubectlArgs...)
-		cmds = append(cmds, cmd)
+			err = wait()
+			s.procMu.Lock()
+			delete(s.running, cmd)
+			s.procMu.Unlock()
 
-		stdout, err := cmd.StdoutPipe()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: failed to create stdout pipe: %v\n", ctx, err)
-			continue
-		}
+			if s.isInterrupted() {
+				err = nil
+				return
+			}
+			// A stream that printed nothing this time, e.g. for a pod
+			// that is gone, is not worth retrying.
+			if s.opts.reconnect == nil || err == nil || lastOutput.Load() == 0 {
+				return
+			}
+			next := s.opts.reconnect(context, args, time.Unix(0, lastOutput.Load()))
+			if next == nil {
+				return
+			}
+			args = next
 
-		stderr, err := cmd.StderrPipe()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: failed to create stderr pipe: %v\n", ctx, err)
-			continue
-		}
+			backoff = nextReconnectBackoff(backoff, time.Since(started))
+			s.notice(context, fmt.Sprintf("stream ended (%v), reconnecting in %s", err, backoff))
 
-		if err := cmd.Start(); err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: failed to start: %v\n", ctx, err)
-			continue
+			select {
+			case <-s.interrupted:
+				err = nil
+				return
+			case <-time.After(backoff):
+			}
 		}
+	}()
+}
 
-		coloredCtx := colorizeContext(ctx)
-		padding := strings.Repeat(" ", maxWidth-len(ctx))
-
-		wg.Add(1)
-		if filterHeaders {
-			contextHeader := "CONTEXT" + strings.Repeat(" ", maxWidth-len("CONTEXT"))
-			go streamLinesFilterHeader(&wg, &mu, stdout, coloredCtx, padding, contextHeader, os.Stdout, &headerOnce)
-		} else {
-			go streamLines(&wg, &mu, stdout, coloredCtx, padding, os.Stdout)
+// every calls fn every interval until the user interrupts. Since it counts
+// as a running stream, wait only returns on an interrupt.
+func (s *streamer) every(interval time.Duration, fn func()) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.interrupted:
+				return
+			case <-ticker.C:
+				fn()
+			}
 		}
+	}()
+}
 
-		wg.Add(1)
-		go streamLines(&wg, &mu, stderr, coloredCtx, padding, os.Stderr)
+// notice prints a line about a context's stream on stderr.
+func (s *streamer) notice(context, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(os.Stderr, "%s%s\n", contextPrefix(colorizeContext(context), fillWidth(context, s.maxWidth)), text)
+}
+
+func (s *streamer) isInterrupted() bool {
+	select {
+	case <-s.interrupted:
+		return true
+	default:
+		return false
 	}
+}
 
+// wait returns once every stream has ended, or stops them all when the user
+// interrupts or opts.stop is closed. Each stream's exit status is recorded
+// for the summary and report, and wait fails when any stream failed.
+func (s *streamer) wait() error {
+	defer signal.Stop(s.sigChan)
 	done := make(chan struct{})
 	go func() {
-		wg.Wait()
+		s.wg.Wait()
 		close(done)
 	}()
 
 	select {
-	case <-sigChan:
-		for _, cmd := range cmds {
-			if cmd.Process != nil {
-				cmd.Process.Signal(syscall.SIGTERM)
-			}
-		}
-		for _, cmd := range cmds {
-			cmd.Wait()
-		}
+	case <-s.sigChan:
+	case <-s.opts.stop:
 	case <-done:
-		for _, cmd := range cmds {
-			cmd.Wait()
+		return s.failure()
+	}
+	s.procMu.Lock()
+	close(s.interrupted)
+	for cmd := range s.running {
+		stopProcess(cmd.Process)
+	}
+	s.procMu.Unlock()
+	<-done
+	return s.failure()
+}
+
+// failure records the streams' results and reports how many contexts had a
+// stream that failed.
+func (s *streamer) failure() error {
+	s.procMu.Lock()
+	results := s.results
+	s.procMu.Unlock()
+	if len(results) == 0 {
+		return nil
+	}
+	recordResults(results)
+
+	failed := make(map[string]bool)
+	contexts := make(map[string]bool)
+	for _, result := range results {
+		contexts[result.context] = true
+		if result.err != nil {
+			failed[result.context] = true
 		}
 	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s failed in %d of %d contexts", s.subcommand, len(failed), len(contexts))
+	}
+	return nil
+}
+
+// startStream starts one streaming kubectl process and the goroutines that
+// copy its output. The returned function waits for both to finish.
+func startStream(cmd *exec.Cmd, coloredCtx, padding string, maxWidth int, mu *sync.Mutex, headerOnce *sync.Once, lastOutput *atomic.Int64, opts streamOptions) (func() error, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start: %w", err)
+	}
+
+	var reader io.Reader = lastReadReader{reader: stdout, last: lastOutput}
+	if opts.idle != nil {
+		context := stripColor(coloredCtx)
+		opts.idle.seen(context, time.Now())
+		reader = idleReader{reader: reader, context: context, watcher: opts.idle}
+	}
+	if opts.throttle != nil {
+		reader = opts.throttle.filter(stripColor(coloredCtx), reader)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	switch {
+	case opts.raw:
+		go copyRaw(&wg, os.Stdout, reader)
+	case opts.handleStdout != nil:
+		go func() {
+			defer wg.Done()
+			opts.handleStdout(reader, coloredCtx, padding)
+		}()
+	case opts.filterHeaders:
+		contextHeader := padRight("CONTEXT", maxWidth)
+		go streamLinesFilterHeader(&wg, mu, reader, coloredCtx, padding, contextHeader, os.Stdout, headerOnce)
+	default:
+		go streamLines(&wg, mu, reader, coloredCtx, padding, os.Stdout)
+	}
+	if opts.raw {
+		go copyRaw(&wg, os.Stderr, stderr)
+	} else {
+		go streamLines(&wg, mu, stderr, coloredCtx, padding, os.Stderr)
+	}
+	return func() error {
+		wg.Wait()
+		return cmd.Wait()
+	}, nil
+}
 
+// copyRaw copies output through unchanged. Writes from different contexts
+// are not serialized, so their output may interleave mid-line.
+func copyRaw(wg *sync.WaitGroup, dest io.Writer, reader io.Reader) {
+	defer wg.Done()
+	io.Copy(dest, reader)
+}
+
+// maxLineBytes is the --max-line-bytes setting: the longest streamed output
+// line that is read before that context's output stops being read.
+var maxLineBytes = 1024 * 1024
+
+func validateMaxLineBytes() error {
+	if maxLineBytes <= 0 {
+		return fmt.Errorf("invalid --max-line-bytes %d: must be positive", maxLineBytes)
+	}
 	return nil
 }
 
+// newLineScanner splits streamed output into lines of up to maxLineBytes.
+func newLineScanner(reader io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(bufio.MaxScanTokenSize, maxLineBytes)), maxLineBytes)
+	return scanner
+}
+
+// finishScan reports, on stderr, why a context's output stopped being read
+// early, and drains the rest so that kubectl does not block writing it.
+func finishScan(scanner *bufio.Scanner, reader io.Reader, coloredCtx string) {
+	err := scanner.Err()
+	if err == nil {
+		return
+	}
+	if errors.Is(err, bufio.ErrTooLong) {
+		err = fmt.Errorf("a line is longer than %d bytes; raise --max-line-bytes", maxLineBytes)
+	}
+	fmt.Fprintf(os.Stderr, "Context %s: stopped reading output: %v\n", coloredCtx, err)
+	io.Copy(io.Discard, reader)
+}
+
 func streamLines(wg *sync.WaitGroup, mu *sync.Mutex, reader io.Reader, coloredCtx, padding string, dest *os.File) {
 	defer wg.Done()
-	scanner := bufio.NewScanner(reader)
+	scanner := newLineScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
+		if dest == os.Stdout && !keepRow(stripColor(coloredCtx)+padding+"  "+line) {
+			continue
+		}
 		mu.Lock()
-		fmt.Fprintf(dest, "%s%s  %s\n", coloredCtx, padding, line)
+		fmt.Fprintf(dest, "%s%s\n", contextPrefix(coloredCtx, padding), line)
 		mu.Unlock()
 	}
+	finishScan(scanner, reader, coloredCtx)
 }
 
 // streamLinesFilterHeader prints the first line (header) exactly once across
 // all goroutines sharing the same headerOnce, then streams remaining lines
-// with the context prefix.
+// with the context prefix. The EVENT column of --output-watch-events is
+// aligned and colored.
 func streamLinesFilterHeader(wg *sync.WaitGroup, mu *sync.Mutex, reader io.Reader, coloredCtx, padding, contextHeader string, dest *os.File, headerOnce *sync.Once) {
 	defer wg.Done()
-	scanner := bufio.NewScanner(reader)
+	scanner := newLineScanner(reader)
 	firstLine := true
+	events := false
 	for scanner.Scan() {
 		line := scanner.Text()
+		if firstLine {
+			events = isWatchEventHeader(line)
+		}
+		if events {
+			line = formatWatchEvent(line)
+		}
 		if firstLine {
 			firstLine = false
 			headerOnce.Do(func() {
+				if noHeaders {
+					return
+				}
 				mu.Lock()
-				fmt.Fprintf(dest, "%s  %s\n", contextHeader, line)
+				fmt.Fprintf(dest, "%s%s\n", contextPrefix(contextHeader, ""), line)
 				mu.Unlock()
 			})
 			continue
 		}
+		if !keepRow(stripColor(coloredCtx) + padding + "  " + stripColor(line)) {
+			continue
+		}
 		mu.Lock()
-		fmt.Fprintf(dest, "%s%s  %s\n", coloredCtx, padding, line)
+		fmt.Fprintf(dest, "%s%s\n", contextPrefix(coloredCtx, padding), line)
 		mu.Unlock()
 	}
+	finishScan(scanner, reader, coloredCtx)
+}
+
+// watchEventColors colors the event types kubectl prints with
+// --output-watch-events by what they mean for the object.
+var watchEventColors = map[string]string{
+	"ADDED":    colorGreen,
+	"MODIFIED": colorYellow,
+	"DELETED":  colorRed,
+	"ERROR":    colorRed,
+}
+
+// watchEventWidth fits the widest event type, MODIFIED or BOOKMARK.
+const watchEventWidth = len("MODIFIED")
+
+func isWatchEventHeader(line string) bool {
+	event, _ := splitWatchEvent(line)
+	return event == "EVENT"
+}
+
+// splitWatchEvent splits the event type off the front of a line.
+func splitWatchEvent(line string) (string, string) {
+	event, rest, _ := strings.Cut(strings.TrimLeft(line, " "), " ")
+	return event, strings.TrimLeft(rest, " ")
+}
+
+// formatWatchEvent pads the event type in front of a line, which kubectl
+// aligns per line only, and colors it.
+func formatWatchEvent(line string) string {
+	event, rest := splitWatchEvent(line)
+	colored := event
+	if color := watchEventColors[event]; color != "" && colorEnabled() {
+		colored = color + event + colorReset
+	}
+	return colored + fillWidth(event, watchEventWidth) + "   " + rest
 }
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var execCmd = &cobra.Command{
+	Use:                "exec",
+	Short:              "Run a command in a pod in every context",
+	Long:               `Run a non-interactive command inside a pod in every context in parallel and print its output prefixed by context. The pod is given by name (or type/name) as with kubectl exec, or chosen per context with -l/--selector. With --raw, output is copied through unprefixed as it arrives instead. With -i/-t, pick a single context (and pod) interactively and attach to it instead.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExecBroadcast(args)
+	},
+}
+
+// splitAtDash separates kubectl flags from the command after "--".
+func splitAtDash(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
+}
+
+func isInteractiveExec(flags []string) bool {
+	for _, arg := range flags {
+		switch arg {
+		case "-i", "-t", "-it", "-ti", "--stdin", "--tty", "--stdin=true", "--tty=true":
+			return true
+		}
+	}
+	return false
+}
+
+func namespaceArgs(flags []string) []string {
+	namespaces, _ := extractStringFlag(flags, "-n", "--namespace")
+	if len(namespaces) == 0 {
+		return nil
+	}
+	return []string{"--namespace", namespaces[len(namespaces)-1]}
+}
+
+func runExecBroadcast(args []string) error {
+	flags, command := splitAtDash(args)
+	if len(command) < 2 {
+		return fmt.Errorf("exec requires a command after --, e.g. kubectl x exec deploy/app -- cat /etc/resolv.conf")
+	}
+	selectors, flags := extractStringFlag(flags, "-l", "--selector")
+	raw, flags := extractBoolFlag(flags, "--raw")
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	if isInteractiveExec(flags) {
+		if err := rejectOnlyErrors("exec -i/-t"); err != nil {
+			return err
+		}
+		return runInteractiveExec(contexts, flags, command, selectors)
+	}
+	if raw {
+		return streamExecRaw(contexts, flags, command, selectors)
+	}
+
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		execArgs := append([]string{}, flags...)
+		if len(selectors) > 0 {
+			pod, err := findPodBySelector(context, selectors[len(selectors)-1], namespaceArgs(flags))
+			if err != nil {
+				return "", err
+			}
+			execArgs = append(execArgs, pod)
+		}
+		return runKubectlCommand(context, "exec", append(execArgs, command...))
+	})
+
+	if onlyErrors {
+		return formatOnlyErrors(results)
+	}
+	return formatRawOutput(results)
+}
+
+// findPodBySelector returns the first running pod (as "pod/name") matching
+// the label selector in the given context.
+func findPodBySelector(context, selector string, nsArgs []string) (string, error) {
+	pods, err := listPodsBySelector(context, selector, nsArgs)
+	if err != nil {
+		return "", err
+	}
+	if len(pods) == 0 {
+		return "", fmt.Errorf("no running pods match selector %q", selector)
+	}
+	return pods[0], nil
+}
+
+func listPodsBySelector(context, selector string, nsArgs []string) ([]string, error) {
+	getArgs := append([]string{"pods", "-l", selector, "--field-selector=status.phase=Running", "-o", "name"}, nsArgs...)
+	output, err := runKubectlCommand(context, "get", getArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %s", strings.TrimSpace(output))
+	}
+	var pods []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			pods = append(pods, line)
+		}
+	}
+	return pods, nil
+}
+
+// streamExecRaw runs the command in every context at once and copies its
+// output through unchanged as it arrives, for binary or very high-volume
+// output that would be slowed down by splitting it into prefixed lines.
+func streamExecRaw(contexts, flags, command, selectors []string) error {
+	targets := make([][]string, len(contexts))
+	for i := range contexts {
+		targets[i] = append(append([]string{}, flags...), command...)
+	}
+	if len(selectors) > 0 {
+		results := runAcrossContextsIndexed(contexts, func(i int, context string) (string, error) {
+			pod, err := findPodBySelector(context, selectors[len(selectors)-1], namespaceArgs(flags))
+			if err == nil {
+				targets[i] = append(append(append([]string{}, flags...), pod), command...)
+			}
+			return "", err
+		})
+		var found []string
+		var foundTargets [][]string
+		for i, result := range results {
+			if result.err != nil {
+				printContextError(result)
+				continue
+			}
+			found = append(found, result.context)
+			foundTargets = append(foundTargets, targets[i])
+		}
+		contexts, targets = found, foundTargets
+	}
+	return streamAcrossContexts(contexts, "exec", func(index int, _ string) []string { return targets[index] }, streamOptions{raw: true})
+}
+
+// hasExecPod reports whether exec flags name the pod to run in, by name,
+// type/name, or -f/--filename.
+func hasExecPod(flags []string) bool {
+	_, flags = extractStringFlag(flags, "-n", "--namespace")
+	_, flags = extractStringFlag(flags, "-c", "--container")
+	_, flags = extractStringFlag(flags, "--pod-running-timeout")
+	files, flags := extractStringFlag(flags, "-f", "--filename")
+	if len(files) > 0 {
+		return true
+	}
+	for _, arg := range flags {
+		if !strings.HasPrefix(arg, "-") {
+			return true
+		}
+	}
+	return false
+}
+
+type execTarget struct {
+	context string
+	pod     string
+}
+
+// runInteractiveExec attaches to one context because a single terminal
+// cannot be fanned out. With a selector, every matching pod in every context
+// is offered; otherwise each context is offered with the given pod target.
+func runInteractiveExec(contexts, flags, command, selectors []string) error {
+	if len(selectors) == 0 && !hasExecPod(flags) {
+		return fmt.Errorf("exec -i/-t needs a pod or -l/--selector, e.g. kubectl x exec -it deploy/app -- sh")
+	}
+	var targets []execTarget
+	if len(selectors) > 0 {
+		nsArgs := namespaceArgs(flags)
+		pods := make([][]string, len(contexts))
+		results := runAcrossContextsIndexed(contexts, func(i int, context string) (string, error) {
+			found, err := listPodsBySelector(context, selectors[len(selectors)-1], nsArgs)
+			pods[i] = found
+			return "", err
+		})
+		for i, result := range results {
+			if result.err != nil {
+				printContextError(result)
+				continue
+			}
+			for _, pod := range pods[i] {
+				targets = append(targets, execTarget{context: result.context, pod: pod})
+			}
+		}
+	} else {
+		for _, ctx := range contexts {
+			targets = append(targets, execTarget{context: ctx})
+		}
+	}
+
+	if len(targets) == 0 {
+		if len(selectors) == 0 {
+			return fmt.Errorf("no contexts to attach to")
+		}
+		return fmt.Errorf("no pods match selector %q in any context", selectors[len(selectors)-1])
+	}
+
+	choice := 0
+	if len(targets) > 1 {
+		options := make([]string, len(targets))
+		for i, target := range targets {
+			options[i] = strings.TrimSpace(target.context + "  " + target.pod)
+		}
+		var err error
+		choice, err = pickOption("Attach to which target?", options)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := targets[choice]
+	execArgs := append([]string{}, flags...)
+	if target.pod != "" {
+		execArgs = append(execArgs, target.pod)
+	}
+	return runKubectlInteractive(target.context, "exec", append(execArgs, command...))
+}
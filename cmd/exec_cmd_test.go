@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecCmd(t *testing.T) {
+	require.NotNil(t, execCmd)
+	assert.Equal(t, "exec", execCmd.Use)
+	assert.True(t, execCmd.DisableFlagParsing)
+}
+
+func TestSplitAtDash(t *testing.T) {
+	flags, command := splitAtDash([]string{"deploy/app", "-n", "web", "--", "ls", "-l"})
+	assert.Equal(t, []string{"deploy/app", "-n", "web"}, flags)
+	assert.Equal(t, []string{"--", "ls", "-l"}, command)
+
+	flags, command = splitAtDash([]string{"deploy/app"})
+	assert.Equal(t, []string{"deploy/app"}, flags)
+	assert.Nil(t, command)
+}
+
+func TestIsInteractiveExec(t *testing.T) {
+	assert.False(t, isInteractiveExec([]string{"deploy/app", "-c", "main"}))
+	assert.True(t, isInteractiveExec([]string{"-it", "deploy/app"}))
+	assert.True(t, isInteractiveExec([]string{"deploy/app", "--tty"}))
+}
+
+func TestHasExecPod(t *testing.T) {
+	assert.True(t, hasExecPod([]string{"-it", "deploy/app"}))
+	assert.True(t, hasExecPod([]string{"-n", "web", "web-1", "-c", "main"}))
+	assert.True(t, hasExecPod([]string{"-it", "-f", "pod.yaml"}))
+	assert.False(t, hasExecPod([]string{"-it", "-n", "web", "-c", "main"}))
+	assert.False(t, hasExecPod(nil))
+}
+
+func TestRunInteractiveExecWithoutPod(t *testing.T) {
+	fakeKubectlInteractive(t, func(context, subcommand string, extraArgs []string) error {
+		t.Fatalf("unexpected attach to %s", context)
+		return nil
+	})
+
+	err := runInteractiveExec([]string{"ctx1", "ctx2"}, []string{"-it", "-n", "web"}, []string{"--", "sh"}, nil)
+	assert.EqualError(t, err, "exec -i/-t needs a pod or -l/--selector, e.g. kubectl x exec -it deploy/app -- sh")
+
+	err = runInteractiveExec(nil, []string{"-it", "deploy/app"}, []string{"--", "sh"}, nil)
+	assert.EqualError(t, err, "no contexts to attach to")
+}
+
+func TestNamespaceArgs(t *testing.T) {
+	assert.Nil(t, namespaceArgs([]string{"deploy/app"}))
+	assert.Equal(t, []string{"--namespace", "web"}, namespaceArgs([]string{"-n", "web", "deploy/app"}))
+	assert.Equal(t, []string{"--namespace", "web"}, namespaceArgs([]string{"--namespace=web"}))
+}
+
+func TestFindPodBySelector(t *testing.T) {
+	t.Run("returns first pod", func(t *testing.T) {
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			assert.Equal(t, "get", subcommand)
+			assert.Equal(t, []string{"pods", "-l", "app=web", "--field-selector=status.phase=Running", "-o", "name", "--namespace", "web"}, extraArgs)
+			return "pod/web-1\npod/web-2\n", nil
+		})
+		pod, err := findPodBySelector("ctx1", "app=web", []string{"--namespace", "web"})
+		require.NoError(t, err)
+		assert.Equal(t, "pod/web-1", pod)
+	})
+
+	t.Run("no matching pods", func(t *testing.T) {
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			return "", nil
+		})
+		_, err := findPodBySelector("ctx1", "app=web", nil)
+		assert.ErrorContains(t, err, "no running pods")
+	})
+}
+
+func TestRunExecBroadcast(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	t.Run("requires a command", func(t *testing.T) {
+		assert.Error(t, runExecBroadcast([]string{"deploy/app"}))
+	})
+
+	t.Run("interactive exec attaches to the picked context", func(t *testing.T) {
+		old := confirmInput
+		confirmInput = strings.NewReader("2\n")
+		t.Cleanup(func() { confirmInput = old })
+
+		var attached string
+		fakeKubectlInteractive(t, func(context, subcommand string, extraArgs []string) error {
+			attached = context + " " + subcommand + " " + strings.Join(extraArgs, " ")
+			return nil
+		})
+
+		var err error
+		captureStderr(func() {
+			err = runExecBroadcast([]string{"-it", "deploy/app", "--", "sh"})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ctx2 exec -it deploy/app -- sh", attached)
+	})
+
+	t.Run("resolves pods by selector per context", func(t *testing.T) {
+		var mu sync.Mutex
+		execCalls := make(map[string]string)
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			if subcommand == "get" {
+				return "pod/web-" + context + "\n", nil
+			}
+			mu.Lock()
+			execCalls[context] = strings.Join(extraArgs, " ")
+			mu.Unlock()
+			if context == "ctx2" {
+				return "error: container not found", fmt.Errorf("exit status 1")
+			}
+			return "nameserver 10.0.0.10\n", nil
+		})
+
+		var err error
+		output := captureStdout(func() {
+			captureStderr(func() {
+				err = runExecBroadcast([]string{"-l", "app=web", "--", "cat", "/etc/resolv.conf"})
+			})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"ctx1": "pod/web-ctx1 -- cat /etc/resolv.conf",
+			"ctx2": "pod/web-ctx2 -- cat /etc/resolv.conf",
+		}, execCalls)
+		assert.Equal(t, "ctx1  nameserver 10.0.0.10\n", output)
+	})
+}
+
+func fakeKubectlInteractive(t *testing.T, fn func(context, subcommand string, extraArgs []string) error) {
+	t.Helper()
+	old := runKubectlInteractive
+	runKubectlInteractive = fn
+	t.Cleanup(func() { runKubectlInteractive = old })
+}
+
+func TestRunInteractiveExecWithSelector(t *testing.T) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if context == "ctx1" {
+			return "pod/web-a\npod/web-b\n", nil
+		}
+		return "", nil
+	})
+
+	old := confirmInput
+	confirmInput = strings.NewReader("2\n")
+	t.Cleanup(func() { confirmInput = old })
+
+	var attached string
+	fakeKubectlInteractive(t, func(context, subcommand string, extraArgs []string) error {
+		attached = context + " " + strings.Join(extraArgs, " ")
+		return nil
+	})
+
+	var err error
+	stderr := captureStderr(func() {
+		err = runInteractiveExec([]string{"ctx1", "ctx2"}, []string{"-it"}, []string{"--", "sh"}, []string{"app=web"})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "1) ctx1  pod/web-a")
+	assert.Equal(t, "ctx1 -it pod/web-b -- sh", attached)
+
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		return "", nil
+	})
+	captureStderr(func() {
+		err = runInteractiveExec([]string{"ctx1"}, []string{"-it"}, []string{"--", "sh"}, []string{"app=web"})
+	})
+	assert.ErrorContains(t, err, "no pods match selector")
+}
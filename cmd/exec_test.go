@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -212,6 +215,40 @@ func TestStreamLinesFilterHeaderDeduplicatesAcrossContexts(t *testing.T) {
 	assert.Len(t, lines, 3, "expected 1 header + 2 data lines")
 }
 
+func TestStreamLinesFilterHeaderWatchEvents(t *testing.T) {
+	input := "EVENT    NAME    STATUS\nADDED   pod1    Running\nMODIFIED   pod1    Terminating\n"
+
+	r, w, _ := os.Pipe()
+	var buf bytes.Buffer
+	done := make(chan bool)
+	go func() {
+		io.Copy(&buf, r)
+		done <- true
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var headerOnce sync.Once
+	wg.Add(1)
+	streamLinesFilterHeader(&wg, &mu, strings.NewReader(input), "ctx1", "   ", "CONTEXT", w, &headerOnce)
+	wg.Wait()
+	w.Close()
+	<-done
+
+	assert.Equal(t, "CONTEXT  EVENT      NAME    STATUS\nctx1     ADDED      pod1    Running\nctx1     MODIFIED   pod1    Terminating\n", buf.String())
+}
+
+func TestFormatWatchEvent(t *testing.T) {
+	assert.True(t, isWatchEventHeader("EVENT   NAME   READY"))
+	assert.False(t, isWatchEventHeader("NAME   READY"))
+	assert.Equal(t, "DELETED    web-1   0/1", formatWatchEvent("DELETED   web-1   0/1"))
+
+	colorMode = "always"
+	t.Cleanup(func() { colorMode = "auto" })
+	assert.Equal(t, colorRed+"DELETED"+colorReset+"    web-1   0/1", formatWatchEvent("DELETED   web-1   0/1"))
+	assert.Equal(t, "BOOKMARK   web-1", formatWatchEvent("BOOKMARK web-1"))
+}
+
 func captureStderr(fn func()) string {
 	r, w, _ := os.Pipe()
 	oldStderr := os.Stderr
@@ -290,7 +327,7 @@ func TestRenderProgressBar(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderProgressBar(tt.started, tt.completed, tt.total)
+			result := renderProgressBar(tt.started, tt.completed, tt.total, 0, 0)
 			if tt.total == 0 {
 				assert.Empty(t, result)
 				return
@@ -307,13 +344,13 @@ func TestRenderProgressBar(t *testing.T) {
 }
 
 func TestRenderProgressBarWidth(t *testing.T) {
-	result := renderProgressBar(30, 30, 30)
+	result := renderProgressBar(30, 30, 30, 0, 0)
 	assert.Equal(t, strings.Count(result, "█"), progressBarWidth, "fully completed bar should have exactly progressBarWidth full blocks")
 	assert.NotContains(t, result, "░")
 }
 
 func TestRenderProgressBarPartialBlocks(t *testing.T) {
-	result := renderProgressBar(1, 0, 100)
+	result := renderProgressBar(1, 0, 100, 0, 0)
 	hasPartial := false
 	for _, p := range partialBlocks[1:] {
 		if strings.Contains(result, p) {
@@ -340,3 +377,271 @@ func TestClearProgress(t *testing.T) {
 
 	assert.Contains(t, output, "\r\033[K")
 }
+
+// fakeKubectl replaces runKubectlCommand for the duration of the test. Gets
+// are not served from API server tables, so they reach fn as well.
+func fakeKubectl(t *testing.T, fn func(context, subcommand string, extraArgs []string) (string, error)) {
+	t.Helper()
+	old, oldFetch := runKubectlCommand, fetchTable
+	runKubectlCommand = fn
+	fetchTable = func(string, tableRequest) ([][]string, error) {
+		return nil, &unsupportedTableError{msg: "no API server"}
+	}
+	t.Cleanup(func() { runKubectlCommand, fetchTable = old, oldFetch })
+}
+
+func TestRunConfirmedCommand(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, context+" "+subcommand+" "+strings.Join(extraArgs, " "))
+		return "deployment.apps/app restarted\n", nil
+	})
+
+	t.Run("declined confirmation runs nothing", func(t *testing.T) {
+		calls = nil
+		old := confirmInput
+		confirmInput = strings.NewReader("n\n")
+		t.Cleanup(func() { confirmInput = old })
+
+		var err error
+		captureStderr(func() {
+			err = runConfirmedCommand("rollout", []string{"restart", "deploy/app"})
+		})
+		require.Error(t, err)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("--yes skips the prompt and is not forwarded", func(t *testing.T) {
+		calls = nil
+		var err error
+		output := captureStdout(func() {
+			captureStderr(func() {
+				err = runConfirmedCommand("rollout", []string{"restart", "deploy/app", "--yes"})
+			})
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"ctx1 rollout restart deploy/app", "ctx2 rollout restart deploy/app"}, calls)
+		assert.Contains(t, output, "ctx1     OK")
+		assert.Contains(t, output, "ctx2     OK")
+	})
+}
+
+func TestReadsStdin(t *testing.T) {
+	assert.True(t, readsStdin([]string{"-f", "-"}))
+	assert.True(t, readsStdin([]string{"--filename", "-", "-n", "web"}))
+	assert.True(t, readsStdin([]string{"--filename=-"}))
+	assert.False(t, readsStdin([]string{"-f", "manifest.yaml"}))
+	assert.False(t, readsStdin([]string{"pods", "-"}))
+}
+
+func TestBufferedStdinReadsOnce(t *testing.T) {
+	oldSource := stdinSource
+	stdinSource = strings.NewReader("kind: ConfigMap\n")
+	stdinOnce = sync.Once{}
+	t.Cleanup(func() {
+		stdinSource = oldSource
+		stdinOnce = sync.Once{}
+		stdinData, stdinErr = nil, nil
+	})
+
+	first, err := bufferedStdin()
+	require.NoError(t, err)
+	second, err := bufferedStdin()
+	require.NoError(t, err)
+	assert.Equal(t, "kind: ConfigMap\n", string(first))
+	assert.Equal(t, first, second, "every caller should get the same buffered copy")
+}
+
+func TestKubectlCommandKubeconfigEnv(t *testing.T) {
+	cmd := kubectlCommand("--context", "ctx1", "get", "pods")
+	assert.Equal(t, []string{"kubectl", "--context", "ctx1", "get", "pods"}, cmd.Args)
+	assert.Nil(t, cmd.Env)
+
+	kubeconfigPaths = []string{"/a/config", "/b/config"}
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+	cmd = kubectlCommand("get", "pods")
+	assert.Contains(t, cmd.Env, "KUBECONFIG=/a/config"+string(filepath.ListSeparator)+"/b/config")
+}
+
+func TestKubectlArgs(t *testing.T) {
+	assert.Equal(t, []string{"--context", "ctx1", "get", "pods"}, kubectlArgs("ctx1", "get", []string{"pods"}))
+
+	forcedNamespace = "web"
+	t.Cleanup(func() { forcedNamespace = "" })
+	assert.Equal(t, []string{"--context", "ctx1", "--namespace", "web", "get", "pods"}, kubectlArgs("ctx1", "get", []string{"pods"}))
+}
+
+func TestRecordResultsKeepsFailures(t *testing.T) {
+	resetRecordedResults()
+	t.Cleanup(resetRecordedResults)
+
+	recordResults([]contextResult{{context: "prod", err: fmt.Errorf("scale failed")}, {context: "dev"}})
+	recordResults([]contextResult{{context: "prod", output: "3"}, {context: "dev", err: fmt.Errorf("get failed")}})
+	results := recordedResults()
+	require.Len(t, results, 2)
+	assert.Equal(t, "prod", results[0].context)
+	assert.EqualError(t, results[0].err, "scale failed")
+	assert.Equal(t, "dev", results[1].context)
+	assert.EqualError(t, results[1].err, "get failed")
+}
+
+func TestRunAcrossContextsRecordsResults(t *testing.T) {
+	resetRecordedResults()
+	t.Cleanup(resetRecordedResults)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		return "ok", nil
+	})
+
+	runAcrossContexts([]string{"ctx1", "ctx2"}, "get", []string{"pods"})
+	assert.Len(t, recordedResults(), 2)
+}
+
+func TestRenderProgressBarFailuresAndETA(t *testing.T) {
+	result := renderProgressBar(5, 4, 10, 2, 90*time.Second)
+	assert.Contains(t, result, "4/10 complete, "+colorRed+"2 failed"+colorReset+", ETA 1m30s")
+
+	result = renderProgressBar(5, 4, 10, 0, 0)
+	assert.True(t, strings.HasSuffix(result, "4/10 complete"))
+}
+
+func TestEstimateRemaining(t *testing.T) {
+	assert.Zero(t, estimateRemaining(0, 0, 10, 5), "unknown before any context completes")
+	assert.Zero(t, estimateRemaining(10*time.Second, 10, 10, 5))
+	assert.Equal(t, 4*time.Second, estimateRemaining(4*time.Second, 2, 10, 5), "8 remaining in 2 waves of 2s")
+	assert.Equal(t, 8*time.Second, estimateRemaining(2*time.Second, 2, 10, 1))
+}
+
+func TestProgressBarCountsFailures(t *testing.T) {
+	p := &progressBar{total: 3, starts: make(map[string]time.Time)}
+	p.contextStarted("ctx1")
+	p.contextStarted("ctx2")
+	p.contextDone("ctx1", nil)
+	p.contextDone("ctx2", fmt.Errorf("timeout"))
+
+	assert.Equal(t, int32(2), p.completed.Load())
+	assert.Equal(t, int32(1), p.failed.Load())
+	assert.Greater(t, p.eta(), time.Duration(0))
+}
+
+func TestNextReconnectBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, nextReconnectBackoff(time.Second, time.Second))
+	assert.Equal(t, reconnectMaxBackoff, nextReconnectBackoff(20*time.Second, time.Second))
+	assert.Equal(t, reconnectInitialBackoff, nextReconnectBackoff(20*time.Second, 5*time.Minute))
+	assert.Equal(t, reconnectInitialBackoff, nextReconnectBackoff(0, time.Second))
+}
+
+func TestStreamLinesReportsLongLines(t *testing.T) {
+	old := maxLineBytes
+	maxLineBytes = 16
+	t.Cleanup(func() { maxLineBytes = old })
+
+	r, w, _ := os.Pipe()
+	var buf bytes.Buffer
+	done := make(chan bool)
+	go func() {
+		io.Copy(&buf, r)
+		done <- true
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wg.Add(1)
+	stderr := captureStderr(func() {
+		streamLines(&wg, &mu, strings.NewReader("short\n"+strings.Repeat("x", 32)+"\nafter\n"), "ctx1", "", w)
+	})
+	w.Close()
+	<-done
+
+	assert.Equal(t, "ctx1  short\n", buf.String())
+	assert.Contains(t, stderr, "Context ctx1: stopped reading output: a line is longer than 16 bytes; raise --max-line-bytes")
+}
+
+func TestNewLineScannerReadsLongLines(t *testing.T) {
+	scanner := newLineScanner(strings.NewReader(strings.Repeat("x", 100*1024) + "\n"))
+	require.True(t, scanner.Scan())
+	assert.Len(t, scanner.Text(), 100*1024)
+	assert.NoError(t, scanner.Err())
+}
+
+func TestStreamAcrossContextsRaw(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as kubectl")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nprintf 'no newline'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	output := captureStdout(func() {
+		err := streamAcrossContexts([]string{"ctx1"}, "exec", func(int, string) []string { return nil }, streamOptions{raw: true})
+		require.NoError(t, err)
+	})
+	assert.Equal(t, "no newline", output)
+}
+
+func TestStreamAcrossContextsRecordsFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as kubectl")
+	}
+	resetRecordedResults()
+	t.Cleanup(resetRecordedResults)
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho line\ncase \"$*\" in *ctx2*) exit 1;; esac\nexit 0\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	var err error
+	captureStderr(func() {
+		captureStdout(func() {
+			err = streamAcrossContexts([]string{"ctx1", "ctx2"}, "logs", func(int, string) []string { return []string{"web"} }, streamOptions{})
+		})
+	})
+	assert.EqualError(t, err, "logs failed in 1 of 2 contexts")
+
+	results := recordedResults()
+	require.Len(t, results, 2)
+	for _, result := range results {
+		if result.context == "ctx2" {
+			assert.Error(t, result.err)
+		} else {
+			assert.NoError(t, result.err)
+		}
+	}
+}
+
+func TestStreamerReconnectsOnlyFailedStreams(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as kubectl")
+	}
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	script := "#!/bin/sh\necho run >> " + calls + "\necho line\ncase \"$*\" in *fail*) [ $(wc -l < " + calls + ") -lt 3 ] && exit 1;; esac\nexit 0\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+	resumed := 0
+	opts := streamOptions{reconnect: func(_ string, args []string, _ time.Time) []string {
+		resumed++
+		return args
+	}}
+
+	captureStderr(func() {
+		captureStdout(func() {
+			require.NoError(t, streamAcrossContexts([]string{"ctx1"}, "logs", func(int, string) []string { return []string{"ok"} }, opts))
+		})
+	})
+	assert.Zero(t, resumed, "a stream that ends cleanly is not reconnected")
+
+	require.NoError(t, os.Remove(calls))
+	captureStderr(func() {
+		captureStdout(func() {
+			streamAcrossContexts([]string{"ctx1"}, "logs", func(int, string) []string { return []string{"fail"} }, opts)
+		})
+	})
+	assert.Equal(t, 2, resumed)
+}
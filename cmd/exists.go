@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var existsCmd = &cobra.Command{
+	Use:                "exists",
+	Short:              "Show which contexts have the given resources",
+	Long:               `Check every context for one or more resources given as kind/name, e.g. kubectl x exists configmap/feature-flags -n payments, and print a matrix of FOUND, NOT FOUND, or ERROR per context. Other arguments such as -n are passed through to kubectl get.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resources, extraArgs := splitExistsArgs(args)
+		if len(resources) == 0 {
+			return fmt.Errorf("exists requires at least one kind/name, e.g. kubectl x exists configmap/feature-flags")
+		}
+		return runExists(resources, extraArgs)
+	},
+}
+
+const (
+	existsFound    = "FOUND"
+	existsNotFound = "NOT FOUND"
+	existsError    = "ERROR"
+)
+
+var existsColors = map[string]string{
+	existsFound:    colorGreen,
+	existsNotFound: colorYellow,
+	existsError:    colorRed,
+}
+
+// splitExistsArgs separates the kind/name arguments from the flags passed
+// through to kubectl, which keep their order.
+func splitExistsArgs(args []string) ([]string, []string) {
+	var resources, extraArgs []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") && strings.Contains(arg, "/") {
+			resources = append(resources, arg)
+		} else {
+			extraArgs = append(extraArgs, arg)
+		}
+	}
+	return resources, extraArgs
+}
+
+// checkExists looks up each resource in context. A context fails if any
+// lookup does, with the reason kept per resource for stderr.
+func checkExists(context string, resources, extraArgs []string) ([]string, []string, error) {
+	statuses := make([]string, len(resources))
+	reasons := make([]string, len(resources))
+	var failed error
+	for i, resource := range resources {
+		args := append([]string{resource, "-o", "name", "--ignore-not-found"}, extraArgs...)
+		output, err := runKubectlCommand(context, "get", args)
+		switch {
+		case err != nil:
+			statuses[i] = existsError
+			reasons[i] = lastLine(output)
+			if failed == nil {
+				failed = err
+			}
+		case strings.TrimSpace(output) != "":
+			statuses[i] = existsFound
+		default:
+			statuses[i] = existsNotFound
+		}
+	}
+	return statuses, reasons, failed
+}
+
+func runExists(resources, extraArgs []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+	warnNamespaceSkew(contexts, "get", extraArgs)
+
+	var mu sync.Mutex
+	statuses := make(map[string][]string, len(contexts))
+	reasons := make(map[string][]string, len(contexts))
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		contextStatuses, contextReasons, err := checkExists(context, resources, extraArgs)
+		mu.Lock()
+		statuses[context] = contextStatuses
+		reasons[context] = contextReasons
+		mu.Unlock()
+		return "", err
+	})
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+	}
+	var failure error
+	if failed > 0 {
+		failure = &ExitError{Code: 2, Err: fmt.Errorf("exists failed in %d of %d contexts", failed, len(contexts))}
+	}
+	if onlyErrors {
+		if err := formatOnlyErrors(results); err != nil {
+			return err
+		}
+		return failure
+	}
+
+	var rows [][]string
+	for _, context := range contexts {
+		row := []string{context}
+		for i, status := range statuses[context] {
+			if reason := reasons[context][i]; reason != "" {
+				fmt.Fprintf(os.Stderr, "Context %s: %s: %s\n", colorizeContext(context), resources[i], reason)
+			}
+			if color := existsColors[status]; color != "" && colorEnabled() {
+				status = color + status + colorReset
+			}
+			row = append(row, status)
+		}
+		rows = append(rows, row)
+	}
+	header := []string{"CONTEXT"}
+	for _, resource := range resources {
+		header = append(header, strings.ToUpper(resource))
+	}
+	printContextTable(os.Stdout, header, rows)
+	return failure
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistsCmd(t *testing.T) {
+	require.NotNil(t, existsCmd)
+	assert.Equal(t, "exists", existsCmd.Use)
+	assert.True(t, existsCmd.DisableFlagParsing)
+	assert.ErrorContains(t, existsCmd.RunE(existsCmd, []string{"-n", "payments"}), "requires at least one kind/name")
+}
+
+func TestSplitExistsArgs(t *testing.T) {
+	resources, extraArgs := splitExistsArgs([]string{"configmap/flags", "-n", "payments", "secret/tls", "--kubeconfig=/tmp/kc"})
+	assert.Equal(t, []string{"configmap/flags", "secret/tls"}, resources)
+	assert.Equal(t, []string{"-n", "payments", "--kubeconfig=/tmp/kc"}, extraArgs)
+}
+
+func TestRunExists(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2", "ctx3"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"-o", "name", "--ignore-not-found", "-n", "payments"}, extraArgs[1:])
+		switch {
+		case context == "ctx3":
+			return "error: You must be logged in to the server (Unauthorized)\n", fmt.Errorf("exit status 1")
+		case context == "ctx1" || extraArgs[0] == "configmap/flags":
+			return extraArgs[0] + "\n", nil
+		}
+		return "", nil
+	})
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			err := runExists([]string{"configmap/flags", "secret/tls"}, []string{"-n", "payments"})
+			var exitErr *ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, 2, exitErr.Code)
+			assert.EqualError(t, err, "exists failed in 1 of 3 contexts")
+		})
+	})
+	assert.Equal(t, "CONTEXT  CONFIGMAP/FLAGS    SECRET/TLS\n"+
+		"ctx1     FOUND              FOUND\n"+
+		"ctx2     FOUND              NOT FOUND\n"+
+		"ctx3     ERROR              ERROR\n", output)
+	assert.Contains(t, stderr, "Context ctx3: configmap/flags: error: You must be logged in to the server (Unauthorized)")
+}
+
+func TestRunExistsIgnoresGrep(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"ctx1"}))
+	grepPatterns = []string{"nomatch"}
+	require.NoError(t, compileGrepPatterns())
+	t.Cleanup(func() {
+		grepPatterns = []string{}
+		require.NoError(t, compileGrepPatterns())
+	})
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		return "configmap/flags\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runExists([]string{"configmap/flags"}, nil))
+	})
+	assert.Equal(t, "CONTEXT  CONFIGMAP/FLAGS\nctx1     FOUND\n", output)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:                "explain",
+	Short:              "Run kubectl explain against one reachable context",
+	Long:               `Run kubectl explain against the first context that answers, since the output only depends on the API version. Pass --all to run it everywhere and compare the distinct schemas, e.g. for CRDs that differ between clusters.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExplain(args)
+	},
+}
+
+func runExplain(args []string) error {
+	all, args := extractBoolFlag(args, "--all")
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	if onlyErrors {
+		return formatOnlyErrors(runAcrossContexts(contexts, "explain", args))
+	}
+	if all {
+		return formatExplainComparison(runAcrossContexts(contexts, "explain", args))
+	}
+
+	var lastOutput string
+	for _, ctx := range contexts {
+		output, err := runKubectlCommand(ctx, "explain", args)
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "Showing output from context %s\n", colorizeContext(ctx))
+			fmt.Print(output)
+			return nil
+		}
+		lastOutput = strings.TrimSpace(output)
+	}
+	return fmt.Errorf("kubectl explain failed in every context: %s", lastOutput)
+}
+
+// formatExplainComparison prints each distinct explain output once, headed
+// by the contexts that returned it.
+func formatExplainComparison(results []contextResult) error {
+	var outputs []string
+	contextsByOutput := make(map[string][]string)
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		if _, seen := contextsByOutput[result.output]; !seen {
+			outputs = append(outputs, result.output)
+		}
+		contextsByOutput[result.output] = append(contextsByOutput[result.output], result.context)
+	}
+
+	if len(outputs) == 1 {
+		fmt.Fprintf(os.Stderr, "All %d contexts returned identical output\n", len(contextsByOutput[outputs[0]]))
+		fmt.Print(outputs[0])
+		return nil
+	}
+
+	for i, output := range outputs {
+		if i > 0 {
+			fmt.Println()
+		}
+		var colored []string
+		for _, ctx := range contextsByOutput[output] {
+			colored = append(colored, colorizeContext(ctx))
+		}
+		fmt.Printf("=== %s ===\n", strings.Join(colored, ", "))
+		fmt.Print(output)
+	}
+	return nil
+}
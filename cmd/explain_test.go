@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCmd(t *testing.T) {
+	require.NotNil(t, explainCmd)
+	assert.Equal(t, "explain", explainCmd.Use)
+	assert.True(t, explainCmd.DisableFlagParsing)
+}
+
+func TestRunExplainUsesFirstReachableContext(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2", "ctx3"})
+	t.Setenv("KUBECONFIG", path)
+
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		calls = append(calls, context)
+		if context == "ctx1" {
+			return "error: connection refused", fmt.Errorf("exit status 1")
+		}
+		return "KIND:       Pod\n", nil
+	})
+
+	var err error
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			err = runExplain([]string{"pods"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctx1", "ctx2"}, calls)
+	assert.Equal(t, "KIND:       Pod\n", output)
+	assert.Contains(t, stderr, "ctx2")
+}
+
+func TestFormatExplainComparison(t *testing.T) {
+	t.Run("identical output printed once", func(t *testing.T) {
+		var output string
+		stderr := captureStderr(func() {
+			output = captureStdout(func() {
+				require.NoError(t, formatExplainComparison([]contextResult{
+					{context: "ctx1", output: "KIND: Widget\n"},
+					{context: "ctx2", output: "KIND: Widget\n"},
+				}))
+			})
+		})
+		assert.Equal(t, "KIND: Widget\n", output)
+		assert.Contains(t, stderr, "All 2 contexts returned identical output")
+	})
+
+	t.Run("distinct outputs grouped by context", func(t *testing.T) {
+		output := captureStdout(func() {
+			require.NoError(t, formatExplainComparison([]contextResult{
+				{context: "ctx1", output: "VERSION: v1\n"},
+				{context: "ctx2", output: "VERSION: v2\n"},
+				{context: "ctx3", output: "VERSION: v1\n"},
+			}))
+		})
+		assert.Equal(t, "=== ctx1, ctx3 ===\nVERSION: v1\n\n=== ctx2 ===\nVERSION: v2\n", output)
+	})
+}
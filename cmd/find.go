@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const findColumns = "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,CREATED:.metadata.creationTimestamp"
+
+var findCmd = &cobra.Command{
+	Use:                "find",
+	Short:              "Search every namespace of every context for resources by name",
+	Long:               `Find resources of the given kind whose name matches a regular expression, across all namespaces in all contexts, e.g. kubectl x find deployments '^payments-'. Extra arguments such as -l are passed through to kubectl get.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("find requires a kind and a name pattern, e.g. kubectl x find pods '^api-'")
+		}
+		pattern, err := regexp.Compile(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", args[1], err)
+		}
+		return runFind(args[0], pattern, args[2:], time.Now())
+	},
+}
+
+type foundResource struct {
+	namespace string
+	name      string
+	created   time.Time
+}
+
+// parseFindOutput reads the NAMESPACE/NAME/CREATED custom columns and keeps
+// the rows whose name matches pattern.
+func parseFindOutput(output string, pattern *regexp.Regexp) []foundResource {
+	var found []foundResource
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !pattern.MatchString(fields[1]) {
+			continue
+		}
+		namespace := fields[0]
+		if namespace == "<none>" {
+			namespace = ""
+		}
+		created, _ := time.Parse(time.RFC3339, fields[2])
+		found = append(found, foundResource{namespace: namespace, name: fields[1], created: created})
+	}
+	return found
+}
+
+// formatAge renders a duration in kubectl's short style, e.g. "45s", "12m",
+// "5h", "3d".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
+
+func runFind(kind string, pattern *regexp.Regexp, extraArgs []string, now time.Time) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	getArgs := append([]string{kind, "--all-namespaces", "--no-headers", "-o", findColumns}, extraArgs...)
+	results := runAcrossContexts(contexts, "get", getArgs)
+
+	var rows [][]string
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		for _, resource := range parseFindOutput(result.output, pattern) {
+			age := "<unknown>"
+			if !resource.created.IsZero() {
+				age = formatAge(now.Sub(resource.created))
+			}
+			rows = append(rows, []string{result.context, resource.namespace, resource.name, age})
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintf(os.Stderr, "No %s matching %q found.\n", kind, pattern.String())
+		return nil
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "NAMESPACE", "NAME", "AGE"}, rows)
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCmd(t *testing.T) {
+	require.NotNil(t, findCmd)
+	assert.Equal(t, "find", findCmd.Use)
+	assert.True(t, findCmd.DisableFlagParsing)
+	assert.Error(t, findCmd.RunE(findCmd, []string{"pods"}))
+	assert.Error(t, findCmd.RunE(findCmd, []string{"pods", "("}))
+}
+
+func TestParseFindOutput(t *testing.T) {
+	output := "default   api-1     2024-01-01T00:00:00Z\n" +
+		"default   worker-1  2024-01-01T00:00:00Z\n" +
+		"<none>    api-node  2024-01-02T00:00:00Z\n"
+
+	found := parseFindOutput(output, regexp.MustCompile("^api"))
+	require.Len(t, found, 2)
+	assert.Equal(t, "default", found[0].namespace)
+	assert.Equal(t, "api-1", found[0].name)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), found[0].created)
+	assert.Equal(t, "", found[1].namespace)
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "45s", formatAge(45*time.Second))
+	assert.Equal(t, "12m", formatAge(12*time.Minute+30*time.Second))
+	assert.Equal(t, "30h", formatAge(30*time.Hour))
+	assert.Equal(t, "3d", formatAge(3*24*time.Hour+time.Hour))
+	assert.Equal(t, "2y", formatAge(2*365*24*time.Hour))
+}
+
+func TestRunFind(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	var gotArgs []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		gotArgs = extraArgs
+		if context == "ctx2" {
+			return "error: the server doesn't have a resource type \"widgets\"", fmt.Errorf("exit status 1")
+		}
+		return "default   api-1     2024-01-01T00:00:00Z\nkube-system   dns   2024-01-01T00:00:00Z\n", nil
+	})
+
+	now := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+	var stdout string
+	stderr := captureStderr(func() {
+		stdout = captureStdout(func() {
+			require.NoError(t, runFind("pods", regexp.MustCompile("api"), []string{"-l", "app=api"}, now))
+		})
+	})
+
+	assert.Equal(t, []string{"pods", "--all-namespaces", "--no-headers", "-o", findColumns, "-l", "app=api"}, gotArgs)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "CONTEXT  NAMESPACE    NAME     AGE", lines[0])
+	assert.Regexp(t, `^ctx1\s+default\s+api-1\s+3d$`, lines[1])
+	assert.Contains(t, stderr, "ctx2")
+}
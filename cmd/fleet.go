@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Fleet-manager backends read cluster lists and credentials from a hub: a
+// management cluster reachable through an existing context, or a Rancher
+// server.
+
+// mergeKubeconfig adds the clusters, users, and contexts of fragment to the
+// kubeconfig at path.
+func mergeKubeconfig(path string, fragment *clientcmdapi.Config) error {
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load discovered kubeconfig: %w", err)
+	}
+	for name, cluster := range fragment.Clusters {
+		config.Clusters[name] = cluster
+	}
+	for name, user := range fragment.AuthInfos {
+		config.AuthInfos[name] = user
+	}
+	for name, context := range fragment.Contexts {
+		config.Contexts[name] = context
+	}
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		return fmt.Errorf("failed to write discovered kubeconfig: %w", err)
+	}
+	return nil
+}
+
+// renamedContext extracts the current (or only) context of a kubeconfig and
+// returns it, with its cluster and user, under a single new name.
+func renamedContext(kubeconfig []byte, name string) (*clientcmdapi.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig for %s: %w", name, err)
+	}
+	current := config.CurrentContext
+	if current == "" {
+		for contextName := range config.Contexts {
+			current = contextName
+			break
+		}
+	}
+	context, ok := config.Contexts[current]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig for %s has no contexts", name)
+	}
+	cluster, ok := config.Clusters[context.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig for %s references missing cluster %q", name, context.Cluster)
+	}
+
+	fragment := clientcmdapi.NewConfig()
+	fragment.Clusters[name] = cluster
+	if user, ok := config.AuthInfos[context.AuthInfo]; ok {
+		fragment.AuthInfos[name] = user
+	}
+	fragment.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: context.Namespace}
+	return fragment, nil
+}
+
+// capiSource discovers Cluster API workload clusters from their
+// <name>-kubeconfig secrets on the management cluster.
+type capiSource struct {
+	hub       string
+	namespace string
+}
+
+func (s capiSource) discover(path string) (int, error) {
+	scope := []string{"--all-namespaces"}
+	if s.namespace != "" {
+		scope = []string{"--namespace", s.namespace}
+	}
+	output, err := runKubectlCommand(s.hub, "get", append([]string{"clusters.cluster.x-k8s.io", "-o", "jsonpath={range .items[*]}{.metadata.namespace}{\" \"}{.metadata.name}{\"\\n\"}{end}"}, scope...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list Cluster API clusters on %s: %s", s.hub, lastLine(output))
+	}
+
+	count := 0
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		namespace, name := fields[0], fields[1]
+		secret, err := runKubectlCommand(s.hub, "get", []string{"secret", name + "-kubeconfig", "--namespace", namespace, "-o", "jsonpath={.data.value}"})
+		if err != nil {
+			return 0, fmt.Errorf("failed to read kubeconfig secret for cluster %s/%s: %s", namespace, name, lastLine(secret))
+		}
+		kubeconfig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(secret))
+		if err != nil {
+			return 0, fmt.Errorf("invalid kubeconfig secret for cluster %s/%s: %w", namespace, name, err)
+		}
+		fragment, err := renamedContext(kubeconfig, fmt.Sprintf("capi_%s_%s", namespace, name))
+		if err != nil {
+			return 0, err
+		}
+		if err := mergeKubeconfig(path, fragment); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// argoCDSource discovers clusters registered with Argo CD through its
+// cluster secrets.
+type argoCDSource struct {
+	hub       string
+	namespace string
+}
+
+type argoCDClusterConfig struct {
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	BearerToken     string `json:"bearerToken"`
+	TLSClientConfig struct {
+		Insecure   bool   `json:"insecure"`
+		ServerName string `json:"serverName"`
+		CAData     []byte `json:"caData"`
+		CertData   []byte `json:"certData"`
+		KeyData    []byte `json:"keyData"`
+	} `json:"tlsClientConfig"`
+	ExecProviderConfig *struct {
+		Command    string            `json:"command"`
+		Args       []string          `json:"args"`
+		Env        map[string]string `json:"env"`
+		APIVersion string            `json:"apiVersion"`
+	} `json:"execProviderConfig"`
+	AWSAuthConfig *struct {
+		ClusterName string `json:"clusterName"`
+		RoleARN     string `json:"roleARN"`
+	} `json:"awsAuthConfig"`
+}
+
+// argoCDFragment turns one decoded Argo CD cluster secret into a kubeconfig
+// context named argocd_<name>.
+func argoCDFragment(name, server string, config argoCDClusterConfig) *clientcmdapi.Config {
+	contextName := "argocd_" + name
+	fragment := clientcmdapi.NewConfig()
+	fragment.Clusters[contextName] = &clientcmdapi.Cluster{
+		Server:                   server,
+		InsecureSkipTLSVerify:    config.TLSClientConfig.Insecure,
+		TLSServerName:            config.TLSClientConfig.ServerName,
+		CertificateAuthorityData: config.TLSClientConfig.CAData,
+	}
+
+	user := &clientcmdapi.AuthInfo{
+		Token:                 config.BearerToken,
+		Username:              config.Username,
+		Password:              config.Password,
+		ClientCertificateData: config.TLSClientConfig.CertData,
+		ClientKeyData:         config.TLSClientConfig.KeyData,
+	}
+	switch {
+	case config.ExecProviderConfig != nil:
+		exec := &clientcmdapi.ExecConfig{
+			Command:         config.ExecProviderConfig.Command,
+			Args:            config.ExecProviderConfig.Args,
+			APIVersion:      config.ExecProviderConfig.APIVersion,
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		}
+		for key, value := range config.ExecProviderConfig.Env {
+			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: key, Value: value})
+		}
+		user.Exec = exec
+	case config.AWSAuthConfig != nil:
+		args := []string{"eks", "get-token", "--cluster-name", config.AWSAuthConfig.ClusterName}
+		if config.AWSAuthConfig.RoleARN != "" {
+			args = append(args, "--role-arn", config.AWSAuthConfig.RoleARN)
+		}
+		user.Exec = &clientcmdapi.ExecConfig{
+			Command:         "aws",
+			Args:            args,
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		}
+	}
+	fragment.AuthInfos[contextName] = user
+	fragment.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: contextName}
+	return fragment
+}
+
+func (s argoCDSource) discover(path string) (int, error) {
+	output, err := runKubectlCommand(s.hub, "get", []string{"secrets", "--namespace", s.namespace, "-l", "argocd.argoproj.io/secret-type=cluster", "-o", "json"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list Argo CD cluster secrets on %s: %s", s.hub, lastLine(output))
+	}
+	var secrets struct {
+		Items []struct {
+			Data struct {
+				Name   []byte `json:"name"`
+				Server []byte `json:"server"`
+				Config []byte `json:"config"`
+			} `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &secrets); err != nil {
+		return 0, fmt.Errorf("failed to parse Argo CD cluster secrets: %w", err)
+	}
+
+	for _, item := range secrets.Items {
+		var config argoCDClusterConfig
+		if err := json.Unmarshal(item.Data.Config, &config); err != nil {
+			return 0, fmt.Errorf("invalid config in Argo CD cluster secret %s: %w", item.Data.Name, err)
+		}
+		if err := mergeKubeconfig(path, argoCDFragment(string(item.Data.Name), string(item.Data.Server), config)); err != nil {
+			return 0, err
+		}
+	}
+	return len(secrets.Items), nil
+}
+
+// rancherHTTPClient is used for Rancher API calls; tests replace it.
+var rancherHTTPClient = http.DefaultClient
+
+// rancherSource discovers clusters managed by a Rancher server, generating a
+// kubeconfig for each through the Rancher API.
+type rancherSource struct {
+	url   string
+	token string
+}
+
+func (s rancherSource) call(method, path string, into interface{}) error {
+	request, err := http.NewRequest(method, s.url+path, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+s.token)
+	response, err := rancherHTTPClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("rancher request %s failed: %w", path, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("rancher request %s failed: %w", path, err)
+	}
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("rancher request %s failed: %s", path, response.Status)
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		return fmt.Errorf("failed to parse rancher response for %s: %w", path, err)
+	}
+	return nil
+}
+
+func (s rancherSource) discover(path string) (int, error) {
+	var clusters struct {
+		Data []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := s.call(http.MethodGet, "/v3/clusters", &clusters); err != nil {
+		return 0, err
+	}
+
+	for _, cluster := range clusters.Data {
+		var generated struct {
+			Config string `json:"config"`
+		}
+		if err := s.call(http.MethodPost, "/v3/clusters/"+url.PathEscape(cluster.ID)+"?action=generateKubeconfig", &generated); err != nil {
+			return 0, err
+		}
+		fragment, err := renamedContext([]byte(generated.Config), "rancher_"+cluster.Name)
+		if err != nil {
+			return 0, err
+		}
+		if err := mergeKubeconfig(path, fragment); err != nil {
+			return 0, err
+		}
+	}
+	return len(clusters.Data), nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const workloadKubeconfig = `apiVersion: v1
+kind: Config
+current-context: admin@%[1]s
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://%[1]s.example.com
+contexts:
+- name: admin@%[1]s
+  context:
+    cluster: %[1]s
+    user: admin
+users:
+- name: admin
+  user:
+    token: secret-%[1]s
+`
+
+func emptyDiscoveredKubeconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "discovered")
+	require.NoError(t, os.WriteFile(path, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+	return path
+}
+
+func TestNewClusterSource(t *testing.T) {
+	source, err := newClusterSource(discoverySource{provider: "argocd", params: map[string]string{"hub": "mgmt"}})
+	require.NoError(t, err)
+	assert.Equal(t, argoCDSource{hub: "mgmt", namespace: "argocd"}, source)
+
+	_, err = newClusterSource(discoverySource{provider: "capi", params: map[string]string{}})
+	assert.ErrorContains(t, err, "requires hub")
+
+	t.Setenv("RANCHER_TOKEN", "")
+	_, err = newClusterSource(discoverySource{provider: "rancher", params: map[string]string{"url": "https://rancher"}})
+	assert.ErrorContains(t, err, "RANCHER_TOKEN")
+
+	source, err = newClusterSource(discoverySource{provider: "gke", params: map[string]string{}})
+	require.NoError(t, err)
+	assert.IsType(t, discoverySource{}, source)
+}
+
+func TestCAPISourceDiscover(t *testing.T) {
+	path := emptyDiscoveredKubeconfig(t)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, "mgmt", context)
+		if extraArgs[0] == "clusters.cluster.x-k8s.io" {
+			assert.Contains(t, extraArgs, "--all-namespaces")
+			return "fleet alpha\nfleet beta\n", nil
+		}
+		name := strings.TrimSuffix(extraArgs[1], "-kubeconfig")
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(workloadKubeconfig, name))), nil
+	})
+
+	count, err := capiSource{hub: "mgmt"}.discover(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	config, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	require.Contains(t, config.Contexts, "capi_fleet_alpha")
+	assert.Equal(t, "https://beta.example.com", config.Clusters["capi_fleet_beta"].Server)
+	assert.Equal(t, "secret-alpha", config.AuthInfos["capi_fleet_alpha"].Token)
+}
+
+func TestCAPISourceDiscoverListError(t *testing.T) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		return "error: the server doesn't have a resource type \"clusters\"", fmt.Errorf("exit status 1")
+	})
+	_, err := capiSource{hub: "mgmt", namespace: "fleet"}.discover(emptyDiscoveredKubeconfig(t))
+	assert.ErrorContains(t, err, "failed to list Cluster API clusters on mgmt")
+}
+
+func TestArgoCDSourceDiscover(t *testing.T) {
+	path := emptyDiscoveredKubeconfig(t)
+	secret := func(name, server, config string) map[string]interface{} {
+		return map[string]interface{}{"data": map[string]string{
+			"name":   base64.StdEncoding.EncodeToString([]byte(name)),
+			"server": base64.StdEncoding.EncodeToString([]byte(server)),
+			"config": base64.StdEncoding.EncodeToString([]byte(config)),
+		}}
+	}
+	list, err := json.Marshal(map[string]interface{}{"items": []interface{}{
+		secret("prod", "https://prod.example.com", `{"bearerToken":"abc","tlsClientConfig":{"insecure":true}}`),
+		secret("eks-prod", "https://eks.example.com", `{"awsAuthConfig":{"clusterName":"prod","roleARN":"arn:aws:iam::1:role/argo"}}`),
+	}})
+	require.NoError(t, err)
+
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, "hub", context)
+		assert.Equal(t, "argo", argValue(extraArgs, "--namespace"))
+		return string(list), nil
+	})
+
+	count, err := argoCDSource{hub: "hub", namespace: "argo"}.discover(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	config, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", config.AuthInfos["argocd_prod"].Token)
+	assert.True(t, config.Clusters["argocd_prod"].InsecureSkipTLSVerify)
+	exec := config.AuthInfos["argocd_eks-prod"].Exec
+	require.NotNil(t, exec)
+	assert.Equal(t, "aws", exec.Command)
+	assert.Equal(t, "arn:aws:iam::1:role/argo", argValue(exec.Args, "--role-arn"))
+}
+
+func TestRancherSourceDiscover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-xyz", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/clusters":
+			fmt.Fprint(w, `{"data":[{"id":"c-abc","name":"edge"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/clusters/c-abc" && r.URL.Query().Has("action"):
+			body, _ := json.Marshal(map[string]string{"config": fmt.Sprintf(workloadKubeconfig, "edge")})
+			w.Write(body)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := emptyDiscoveredKubeconfig(t)
+	count, err := rancherSource{url: server.URL, token: "token-xyz"}.discover(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	config, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://edge.example.com", config.Clusters["rancher_edge"].Server)
+}
+
+func TestRancherSourceDiscoverUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := rancherSource{url: server.URL, token: "bad"}.discover(emptyDiscoveredKubeconfig(t))
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestRenamedContextWithoutContexts(t *testing.T) {
+	_, err := renamedContext([]byte("apiVersion: v1\nkind: Config\n"), "capi_x_y")
+	assert.ErrorContains(t, err, "has no contexts")
+}
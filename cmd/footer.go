@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var noSummary bool
+
+const footerFailedNames = 5
+
+// summaryFooter renders the one-line result of a run, such as
+// "42 contexts: 40 ok, 2 failed (ctx-a, ctx-b), total 8.2s".
+func summaryFooter(results []contextResult, total time.Duration) string {
+	var failed []string
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, result.context)
+		}
+	}
+
+	noun := "contexts"
+	if len(results) == 1 {
+		noun = "context"
+	}
+	footer := fmt.Sprintf("%d %s: %d ok, %d failed", len(results), noun, len(results)-len(failed), len(failed))
+	if len(failed) > 0 {
+		names := failed
+		if len(names) > footerFailedNames {
+			names = append(names[:footerFailedNames:footerFailedNames], fmt.Sprintf("%d more", len(failed)-footerFailedNames))
+		}
+		footer += " (" + strings.Join(names, ", ") + ")"
+	}
+	return footer + ", total " + formatDuration(total)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryFooter(t *testing.T) {
+	assert.Equal(t, "1 context: 1 ok, 0 failed, total 0.5s",
+		summaryFooter([]contextResult{{context: "prod"}}, 500*time.Millisecond))
+
+	assert.Equal(t, "3 contexts: 1 ok, 2 failed (ctx-a, ctx-b), total 8.2s",
+		summaryFooter([]contextResult{
+			{context: "ctx-a", err: fmt.Errorf("timeout")},
+			{context: "ok"},
+			{context: "ctx-b", err: fmt.Errorf("exit status 1")},
+		}, 8200*time.Millisecond))
+
+	var results []contextResult
+	for i := 1; i <= 7; i++ {
+		results = append(results, contextResult{context: fmt.Sprintf("c%d", i), err: fmt.Errorf("down")})
+	}
+	assert.Equal(t, "7 contexts: 0 ok, 7 failed (c1, c2, c3, c4, c5, 2 more), total 1m5s",
+		summaryFooter(results, 65*time.Second))
+}
@@ -1,19 +1,95 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
 var getCmd = &cobra.Command{
-	Use:                "get",
-	Short:              "Run kubectl get against all contexts",
-	Long:               `Run kubectl get command against all contexts in parallel. Supports streaming with -w/--watch flag.`,
+	Use:   "get",
+	Short: "Run kubectl get against all contexts",
+	Long: `Run kubectl get command against all contexts in parallel. Supports streaming with -w/--watch flag.
+
+With -w --tui, the objects of every context are shown as one table that is updated in place, highlighting the cells that changed.
+
+With --refresh 10s, the merged table is fetched again at that interval and redrawn in place, for resources that do not watch well.
+
+With --count, only the number of matching objects in each context is printed.
+
+With --collapse-identical, each object is printed once with the contexts it was found in, flagging those whose copy differs.
+
+With --totals, the merged table ends with a TOTAL row holding the number of objects and the sums of count columns such as READY and RESTARTS.
+
+With --unhealthy, a pod table keeps only the pods that are not Running or Completed, are not ready, or have restarted more than --unhealthy-restarts times (default 5).`,
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		tui, args := extractBoolFlag(args, "--tui")
+		count, args := extractBoolFlag(args, "--count")
+		collapse, args := extractBoolFlag(args, "--collapse-identical")
+		totals, args := extractBoolFlag(args, "--totals")
+		refresh, args, err := parseRefresh(args)
+		if err != nil {
+			return err
+		}
+		restarts, args, err := parseUnhealthy(args)
+		if err != nil {
+			return err
+		}
+		if restarts >= 0 {
+			if count || collapse || isWatchMode(args) || tui {
+				return fmt.Errorf("--unhealthy cannot be combined with --count, --collapse-identical, -w, or --tui")
+			}
+			if format := detectOutputFormat(args); format != formatDefault && format != formatWide {
+				return fmt.Errorf("--unhealthy requires table output")
+			}
+			unhealthyRestarts = restarts
+			defer func() { unhealthyRestarts = -1 }()
+		}
+		if totals {
+			if count || collapse || isWatchMode(args) || tui {
+				return fmt.Errorf("--totals cannot be combined with --count, --collapse-identical, -w, or --tui")
+			}
+			switch detectOutputFormat(args) {
+			case formatDefault, formatWide, formatCustomColumns:
+			default:
+				return fmt.Errorf("--totals requires table output")
+			}
+			showTotals = true
+			defer func() { showTotals = false }()
+		}
+		run := func() error {
+			if count {
+				return runCountCommand(args)
+			}
+			if collapse {
+				return runCollapsedCommand(args)
+			}
+			return runCommand("get", args)
+		}
+		if count && (isWatchMode(args) || tui) {
+			return fmt.Errorf("--count cannot be combined with -w or --tui")
+		}
+		if collapse && (count || isWatchMode(args) || tui) {
+			return fmt.Errorf("--collapse-identical cannot be combined with --count, -w, or --tui")
+		}
+		if refresh > 0 {
+			if isWatchMode(args) || tui {
+				return fmt.Errorf("--refresh cannot be combined with -w or --tui")
+			}
+			return runRefreshing(refresh, "get "+strings.Join(args, " "), run)
+		}
+		if tui {
+			if !isWatchMode(args) {
+				return fmt.Errorf("--tui requires -w")
+			}
+			return runWatchTable(args)
+		}
 		if isWatchMode(args) {
 			return runStreamingCommand("get", args, true)
 		}
-		return runCommand("get", args)
+		return run()
 	},
 }
 
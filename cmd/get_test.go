@@ -65,3 +65,22 @@ func TestIsWatchMode(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTUIRequiresWatch(t *testing.T) {
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--tui"}), "--tui requires -w")
+}
+
+func TestGetUnhealthyRequiresTable(t *testing.T) {
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--unhealthy", "-o", "json"}), "--unhealthy requires table output")
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--unhealthy", "--count"}), "cannot be combined")
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--unhealthy-restarts", "many"}), "invalid --unhealthy-restarts")
+}
+
+func TestGetCollapseIdenticalRejectsWatch(t *testing.T) {
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"clusterissuers", "--collapse-identical", "-w"}), "--collapse-identical cannot be combined")
+}
+
+func TestGetTotalsRequiresTable(t *testing.T) {
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--totals", "-o", "yaml"}), "--totals requires table output")
+	assert.ErrorContains(t, getCmd.RunE(getCmd, []string{"pods", "--totals", "--count"}), "cannot be combined")
+}
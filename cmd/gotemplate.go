@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// goTemplate extracts the template from -o go-template=... or
+// go-template-file=... when it refers to .Context. kubectl cannot supply that
+// field, so such templates are rendered by kubectl-x from -o json output.
+func goTemplate(args []string) (string, bool, error) {
+	var text string
+	var readErr error
+	rewriteOutputFormat(args, func(format string) string {
+		if value, ok := strings.CutPrefix(format, "go-template="); ok {
+			text = value
+		} else if path, ok := strings.CutPrefix(format, "go-template-file="); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				readErr = fmt.Errorf("failed to read go-template file: %w", err)
+			}
+			text = string(data)
+		}
+		return format
+	})
+	if readErr != nil {
+		return "", false, readErr
+	}
+	return text, strings.Contains(text, ".Context"), nil
+}
+
+// templateFuncs mirrors the helpers kubectl adds to go-templates.
+var templateFuncs = template.FuncMap{
+	"exists": func(item interface{}, indices ...interface{}) bool {
+		value := reflect.ValueOf(item)
+		for _, index := range indices {
+			for value.Kind() == reflect.Interface {
+				value = value.Elem()
+			}
+			switch value.Kind() {
+			case reflect.Map:
+				value = value.MapIndex(reflect.ValueOf(index))
+			case reflect.Slice, reflect.Array:
+				i, ok := index.(int)
+				if !ok || i < 0 || i >= value.Len() {
+					return false
+				}
+				value = value.Index(i)
+			default:
+				return false
+			}
+			if !value.IsValid() {
+				return false
+			}
+		}
+		return true
+	},
+	"base64decode": func(value string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("base64decode: %w", err)
+		}
+		return string(decoded), nil
+	},
+}
+
+// renderGoTemplate executes the template against the object kubectl returned
+// as JSON, with a top-level Context field added.
+func renderGoTemplate(tmpl *template.Template, context, output string) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return "", fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	data["Context"] = context
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to execute go-template: %w", err)
+	}
+	return rendered.String(), nil
+}
+
+// runGoTemplate runs kubectl with -o json in every context and renders text
+// locally, printing each context's result as is.
+func runGoTemplate(contexts []string, subcommand string, extraArgs []string, text string) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid go-template: %w", err)
+	}
+	jsonArgs := rewriteOutputFormat(extraArgs, func(format string) string {
+		if strings.HasPrefix(format, "go-template") {
+			return "json"
+		}
+		return format
+	})
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		output, err := runKubectlCommand(context, subcommand, jsonArgs)
+		if err != nil {
+			return output, err
+		}
+		return renderGoTemplate(tmpl, context, output)
+	})
+	return formatVerbatimOutput(results)
+}
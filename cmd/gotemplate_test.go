@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoTemplate(t *testing.T) {
+	text, usesContext, err := goTemplate([]string{"pods", "-o", "go-template={{.Context}}"})
+	require.NoError(t, err)
+	assert.True(t, usesContext)
+	assert.Equal(t, "{{.Context}}", text)
+
+	_, usesContext, err = goTemplate([]string{"pods", "-o", "go-template={{.metadata.name}}"})
+	require.NoError(t, err)
+	assert.False(t, usesContext)
+
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{range .items}}{{$.Context}} {{.metadata.name}}\n{{end}}"), 0o600))
+	_, usesContext, err = goTemplate([]string{"pods", "--output=go-template-file=" + path})
+	require.NoError(t, err)
+	assert.True(t, usesContext)
+
+	_, _, err = goTemplate([]string{"pods", "-o", "go-template-file=" + filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to read go-template file")
+}
+
+func TestRenderGoTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(templateFuncs).Parse(
+		`{{.Context}}: {{.metadata.name}} {{if exists . "data" "token"}}{{base64decode .data.token}}{{else}}-{{end}}`))
+
+	rendered, err := renderGoTemplate(tmpl, "prod", `{"metadata":{"name":"s1"},"data":{"token":"c2VjcmV0"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "prod: s1 secret", rendered)
+
+	rendered, err = renderGoTemplate(tmpl, "prod", `{"metadata":{"name":"s2"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "prod: s2 -", rendered)
+
+	_, err = renderGoTemplate(tmpl, "prod", "not json")
+	assert.ErrorContains(t, err, "failed to parse JSON")
+}
+
+func TestRunCommandGoTemplateWithContext(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"prod", "staging"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"pods", "-o", "json"}, extraArgs)
+		return fmt.Sprintf(`{"items":[{"metadata":{"name":"web-%s"}}]}`, context), nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runCommand("get", []string{"pods", "-o", `go-template={{range .items}}{{$.Context}} {{.metadata.name}}{{"\n"}}{{end}}`}))
+	})
+	assert.Equal(t, "prod web-prod\nstaging web-staging\n", output)
+}
+
+func TestRunGoTemplateInvalid(t *testing.T) {
+	err := runGoTemplate([]string{"prod"}, "get", []string{"pods"}, "{{.Context")
+	assert.ErrorContains(t, err, "invalid go-template")
+}
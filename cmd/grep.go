@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var grepPatterns []string
+var grepInvertPatterns []string
+
+var grepRegexes, grepInvertRegexes []*regexp.Regexp
+
+// compileGrepPatterns compiles --grep and --grep-v once per invocation.
+func compileGrepPatterns() error {
+	var err error
+	if grepRegexes, err = compilePatterns("--grep", grepPatterns); err != nil {
+		return err
+	}
+	grepInvertRegexes, err = compilePatterns("--grep-v", grepInvertPatterns)
+	return err
+}
+
+func compilePatterns(flag string, patterns []string) ([]*regexp.Regexp, error) {
+	var regexes []*regexp.Regexp
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", flag, pattern, err)
+		}
+		regexes = append(regexes, regex)
+	}
+	return regexes, nil
+}
+
+var colorCode = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func stripColor(text string) string {
+	return colorCode.ReplaceAllString(text, "")
+}
+
+// keepRow reports whether a formatted data row passes --grep (any pattern
+// matches) and --grep-v (no pattern matches). Headers are never filtered.
+func keepRow(row string) bool {
+	for _, regex := range grepInvertRegexes {
+		if regex.MatchString(row) {
+			return false
+		}
+	}
+	if len(grepRegexes) == 0 {
+		return true
+	}
+	for _, regex := range grepRegexes {
+		if regex.MatchString(row) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setGrep(t *testing.T, patterns, inverted []string) {
+	t.Helper()
+	grepPatterns, grepInvertPatterns = patterns, inverted
+	t.Cleanup(func() {
+		grepPatterns, grepInvertPatterns = []string{}, []string{}
+		grepRegexes, grepInvertRegexes = nil, nil
+	})
+	require.NoError(t, compileGrepPatterns())
+}
+
+func TestKeepRow(t *testing.T) {
+	assert.True(t, keepRow("prod  web  Running"))
+
+	setGrep(t, []string{"CrashLoop", "Error"}, []string{"^staging"})
+	assert.True(t, keepRow("prod     web    CrashLoopBackOff"))
+	assert.True(t, keepRow("prod     job    Error"))
+	assert.False(t, keepRow("prod     api    Running"))
+	assert.False(t, keepRow("staging  web    CrashLoopBackOff"))
+}
+
+func TestCompileGrepPatternsInvalid(t *testing.T) {
+	grepInvertPatterns = []string{"("}
+	t.Cleanup(func() { grepInvertPatterns = []string{} })
+	assert.ErrorContains(t, compileGrepPatterns(), `invalid --grep-v pattern "("`)
+}
+
+func TestStripColor(t *testing.T) {
+	assert.Equal(t, "prod", stripColor("\033[92mprod\033[0m"))
+}
+
+func TestFormatDefaultOutputGrep(t *testing.T) {
+	setGrep(t, []string{"CrashLoop"}, nil)
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    STATUS\npod1    Running\npod2    CrashLoopBackOff"},
+			{context: "ctx2", output: "NAME    STATUS\npod3    CrashLoopBackOff"},
+		}))
+	})
+	assert.Equal(t, "CONTEXT  NAME    STATUS\nctx1     pod2    CrashLoopBackOff\nctx2     pod3    CrashLoopBackOff\n", output)
+}
+
+func TestFormatRawOutputGrepMatchesContext(t *testing.T) {
+	setGrep(t, nil, []string{"^ctx2 "})
+	output := captureStdout(func() {
+		require.NoError(t, formatRawOutput([]contextResult{
+			{context: "ctx1", output: "a"},
+			{context: "ctx2", output: "b"},
+		}))
+	})
+	assert.Equal(t, "ctx1  a\n", output)
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contextGroup is a named set of contexts selectable with --group. Groups
+// imported from kubie also carry the kubeconfig file their contexts live in.
+type contextGroup struct {
+	contexts   []string
+	kubeconfig string
+}
+
+// contextGroups holds every known group once the config has been loaded.
+var contextGroups map[string]contextGroup
+
+// kubieDefaultIncludes mirrors kubie's defaults for configs.include.
+var kubieDefaultIncludes = []string{
+	"~/.kube/config",
+	"~/.kube/*.yml",
+	"~/.kube/*.yaml",
+	"~/.kube/configs/*.yml",
+	"~/.kube/configs/*.yaml",
+	"~/.kube/kubie/*.yml",
+	"~/.kube/kubie/*.yaml",
+}
+
+type kubieConfig struct {
+	Configs struct {
+		Include []string `yaml:"include"`
+		Exclude []string `yaml:"exclude"`
+	} `yaml:"configs"`
+}
+
+func kubieConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kube", "kubie.yaml")
+}
+
+func expandHome(pattern string) string {
+	if pattern != "~" && !strings.HasPrefix(pattern, "~/") {
+		return pattern
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return pattern
+	}
+	return filepath.Join(home, strings.TrimPrefix(pattern, "~"))
+}
+
+// loadKubieGroups turns each kubeconfig file kubie manages into a group named
+// after the file, so ~/.kube/configs/prod.yaml becomes the group "prod". It
+// returns no groups when kubie is not configured.
+func loadKubieGroups(path string) (map[string]contextGroup, error) {
+	groups := make(map[string]contextGroup)
+	if path == "" {
+		return groups, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return groups, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubie config %s: %w", path, err)
+	}
+	var config kubieConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse kubie config %s: %w", path, err)
+	}
+
+	includes := config.Configs.Include
+	if len(includes) == 0 {
+		includes = kubieDefaultIncludes
+	}
+	excluded := make(map[string]bool)
+	for _, pattern := range config.Configs.Exclude {
+		matches, _ := filepath.Glob(expandHome(pattern))
+		for _, match := range matches {
+			excluded[match] = true
+		}
+	}
+
+	for _, pattern := range includes {
+		matches, err := filepath.Glob(expandHome(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q in kubie config %s: %w", pattern, path, err)
+		}
+		for _, match := range matches {
+			if excluded[match] {
+				continue
+			}
+			name := strings.TrimSuffix(filepath.Base(match), filepath.Ext(match))
+			if _, ok := groups[name]; ok {
+				continue
+			}
+			// kubie skips files it cannot parse, and so do we.
+			contexts, err := loadContextsFromFile(match)
+			if err != nil || len(contexts) == 0 {
+				continue
+			}
+			groups[name] = contextGroup{contexts: contexts, kubeconfig: match}
+		}
+	}
+	return groups, nil
+}
+
+// loadContextGroups merges kubie's groups with those from the config file,
+// which win on a name clash.
+func loadContextGroups(configured map[string][]string) (map[string]contextGroup, error) {
+	groups, err := loadKubieGroups(kubieConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	for name, contexts := range configured {
+		groups[name] = contextGroup{contexts: contexts}
+	}
+	return groups, nil
+}
+
+// resolveGroups returns the member contexts of the named groups (each value
+// may be a comma-separated list) and the kubeconfig files they need.
+func resolveGroups(groups map[string]contextGroup, names []string) (map[string]bool, []string, error) {
+	members := make(map[string]bool)
+	var files []string
+	for _, value := range names {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			group, ok := groups[name]
+			if !ok {
+				return nil, nil, unknownGroupError(groups, name)
+			}
+			for _, ctx := range group.contexts {
+				members[ctx] = true
+			}
+			if group.kubeconfig != "" {
+				files = append(files, group.kubeconfig)
+			}
+		}
+	}
+	return members, files, nil
+}
+
+func unknownGroupError(groups map[string]contextGroup, name string) error {
+	if len(groups) == 0 {
+		return fmt.Errorf("unknown group %q: no groups are defined in %s or kubie", name, configPath())
+	}
+	var names []string
+	for known := range groups {
+		names = append(names, known)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown group %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// filterContextsByGroups keeps the contexts that belong to any of the named
+// groups, in kubeconfig order.
+func filterContextsByGroups(contexts []string, groups map[string]contextGroup, names []string) ([]string, error) {
+	members, _, err := resolveGroups(groups, names)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []string
+	for _, ctx := range contexts {
+		if members[ctx] {
+			filtered = append(filtered, ctx)
+		}
+	}
+	return filtered, nil
+}
+
+// addGroupKubeconfigs makes the kubeconfig files of kubie groups visible to
+// kubectl-x and kubectl alongside the usual ones.
+func addGroupKubeconfigs(groups map[string]contextGroup, names []string) error {
+	_, files, err := resolveGroups(groups, names)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	paths := append([]string{}, getKubeconfigPaths()...)
+	for _, file := range files {
+		if !contains(paths, file) {
+			paths = append(paths, file)
+		}
+	}
+	kubeconfigPaths = paths
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKubieSetup(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configs := filepath.Join(home, ".kube", "configs")
+	require.NoError(t, os.MkdirAll(configs, 0o700))
+
+	prod := writeMinimalKubeconfig(t, []string{"prod-us", "prod-eu"})
+	staging := writeMinimalKubeconfig(t, []string{"staging"})
+	for name, source := range map[string]string{"prod.yaml": prod, "staging.yml": staging} {
+		data, err := os.ReadFile(source)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(configs, name), data, 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(configs, "notes.yaml"), []byte("not: [a kubeconfig"), 0o600))
+	return home
+}
+
+func TestLoadKubieGroups(t *testing.T) {
+	home := writeKubieSetup(t)
+	path := filepath.Join(home, ".kube", "kubie.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("shell: bash\n"), 0o600))
+
+	groups, err := loadKubieGroups(path)
+	require.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.ElementsMatch(t, []string{"prod-us", "prod-eu"}, groups["prod"].contexts)
+	assert.Equal(t, filepath.Join(home, ".kube", "configs", "prod.yaml"), groups["prod"].kubeconfig)
+	assert.Equal(t, []string{"staging"}, groups["staging"].contexts)
+}
+
+func TestLoadKubieGroupsIncludeExclude(t *testing.T) {
+	home := writeKubieSetup(t)
+	path := filepath.Join(home, ".kube", "kubie.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("configs:\n  include:\n    - ~/.kube/configs/*\n  exclude:\n    - ~/.kube/configs/staging.yml\n"), 0o600))
+
+	groups, err := loadKubieGroups(path)
+	require.NoError(t, err)
+	assert.Len(t, groups, 1)
+	assert.Contains(t, groups, "prod")
+}
+
+func TestLoadKubieGroupsWithoutKubie(t *testing.T) {
+	groups, err := loadKubieGroups(filepath.Join(t.TempDir(), "kubie.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func TestLoadContextGroupsConfigWins(t *testing.T) {
+	home := writeKubieSetup(t)
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".kube", "kubie.yaml"), []byte("{}\n"), 0o600))
+
+	groups, err := loadContextGroups(map[string][]string{"prod": {"prod-us"}, "payments": {"pay-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, contextGroup{contexts: []string{"prod-us"}}, groups["prod"])
+	assert.Equal(t, []string{"pay-1"}, groups["payments"].contexts)
+	assert.Contains(t, groups, "staging")
+}
+
+func TestFilterContextsByGroups(t *testing.T) {
+	groups := map[string]contextGroup{
+		"payments": {contexts: []string{"pay-eu", "pay-us"}},
+		"search":   {contexts: []string{"search-eu"}},
+	}
+	contexts := []string{"search-eu", "pay-us", "other", "pay-eu"}
+
+	filtered, err := filterContextsByGroups(contexts, groups, []string{"payments"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pay-us", "pay-eu"}, filtered)
+
+	filtered, err = filterContextsByGroups(contexts, groups, []string{"payments,search"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search-eu", "pay-us", "pay-eu"}, filtered)
+
+	_, err = filterContextsByGroups(contexts, groups, []string{"billing"})
+	assert.ErrorContains(t, err, `unknown group "billing" (available: payments, search)`)
+}
+
+func TestAddGroupKubeconfigs(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/home/me/.kube/config")
+	kubeconfigPaths = nil
+	t.Cleanup(func() { kubeconfigPaths = []string{} })
+	groups := map[string]contextGroup{
+		"prod":     {contexts: []string{"prod-us"}, kubeconfig: "/home/me/.kube/configs/prod.yaml"},
+		"payments": {contexts: []string{"pay-eu"}},
+	}
+
+	require.NoError(t, addGroupKubeconfigs(groups, []string{"payments"}))
+	assert.Empty(t, kubeconfigPaths)
+
+	require.NoError(t, addGroupKubeconfigs(groups, []string{"prod", "payments"}))
+	assert.Equal(t, []string{"/home/me/.kube/config", "/home/me/.kube/configs/prod.yaml"}, kubeconfigPaths)
+}
+
+func TestSelectContextsByGroup(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"pay-eu", "pay-us", "search-eu"}))
+	oldGroups := contextGroups
+	contextGroups = map[string]contextGroup{"payments": {contexts: []string{"pay-eu", "pay-us", "gone"}}}
+	groupNames = []string{"payments"}
+	filterPatterns = []string{"eu"}
+	t.Cleanup(func() {
+		contextGroups = oldGroups
+		groupNames = []string{}
+		filterPatterns = []string{}
+	})
+
+	contexts, err := selectContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pay-eu"}, contexts)
+}
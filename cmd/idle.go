@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// idleWatcher tracks when each context last produced output while
+// following, so that a quiet context can be told apart from a stream that
+// silently died.
+type idleWatcher struct {
+	after time.Duration
+
+	mu    sync.Mutex
+	order []string
+	last  map[string]time.Time
+}
+
+func newIdleWatcher(after time.Duration) *idleWatcher {
+	return &idleWatcher{after: after, last: make(map[string]time.Time)}
+}
+
+// parseIdleAfter extracts --idle-after, the quiet time after which a
+// context is reported. Reports are opt-in: without the flag, or with zero,
+// there are none.
+func parseIdleAfter(args []string) (time.Duration, []string, error) {
+	values, args := extractStringFlag(args, "--idle-after")
+	if len(values) == 0 {
+		return 0, args, nil
+	}
+	after, err := time.ParseDuration(values[len(values)-1])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid --idle-after: %w", err)
+	}
+	return after, args, nil
+}
+
+// seen records output, or the start of a stream, from context.
+func (w *idleWatcher) seen(context string, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.last[context]; !ok {
+		w.order = append(w.order, context)
+	}
+	w.last[context] = now
+}
+
+// idle returns the contexts that have been quiet for at least w.after.
+func (w *idleWatcher) idle(now time.Time) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var quiet []string
+	for _, context := range w.order {
+		if now.Sub(w.last[context]) >= w.after {
+			quiet = append(quiet, context)
+		}
+	}
+	return quiet
+}
+
+// report prints the idle contexts, if any, on one line.
+func (w *idleWatcher) report(dest io.Writer, now time.Time) {
+	quiet := w.idle(now)
+	if len(quiet) == 0 {
+		return
+	}
+	colored := make([]string, len(quiet))
+	for i, context := range quiet {
+		colored[i] = colorizeContext(context)
+	}
+	fmt.Fprintf(dest, "No output for %s from: %s\n", formatDuration(w.after), strings.Join(colored, ", "))
+}
+
+// run reports idle contexts every w.after until stop is closed.
+func (w *idleWatcher) run(dest io.Writer, stop <-chan struct{}) {
+	ticker := time.NewTicker(w.after)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			w.report(dest, now)
+		}
+	}
+}
+
+// idleReader marks its context as seen whenever output comes through.
+type idleReader struct {
+	reader  io.Reader
+	context string
+	watcher *idleWatcher
+}
+
+func (r idleReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.watcher.seen(r.context, time.Now())
+	}
+	return n, err
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdleAfter(t *testing.T) {
+	after, args, err := parseIdleAfter([]string{"-f"})
+	require.NoError(t, err)
+	assert.Zero(t, after, "idle reports are opt-in")
+	assert.Equal(t, []string{"-f"}, args)
+
+	after, _, err = parseIdleAfter([]string{"--idle-after=30s"})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, after)
+
+	_, _, err = parseIdleAfter([]string{"--idle-after", "soon"})
+	assert.Error(t, err)
+}
+
+func TestIdleWatcherReport(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	watcher := newIdleWatcher(time.Minute)
+	watcher.seen("ctx1", start)
+	watcher.seen("ctx2", start)
+	watcher.seen("ctx3", start)
+	watcher.seen("ctx2", start.Add(30*time.Second))
+
+	var buf bytes.Buffer
+	watcher.report(&buf, start.Add(time.Minute))
+	assert.Equal(t, "No output for 1m0s from: ctx1, ctx3\n", buf.String())
+
+	buf.Reset()
+	watcher.report(&buf, start.Add(10*time.Second))
+	assert.Empty(t, buf.String())
+}
+
+func TestIdleReaderMarksContextSeen(t *testing.T) {
+	watcher := newIdleWatcher(time.Minute)
+	_, err := io.ReadAll(idleReader{reader: strings.NewReader("line\n"), context: "ctx1", watcher: watcher})
+	require.NoError(t, err)
+	assert.Empty(t, watcher.idle(time.Now()))
+	assert.Equal(t, []string{"ctx1"}, watcher.idle(time.Now().Add(time.Hour)))
+}
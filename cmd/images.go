@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const imagesJSONPath = `jsonpath={range .items[*]}{range .spec.initContainers[*]}{.image}{"\n"}{end}{range .spec.containers[*]}{.image}{"\n"}{end}{end}`
+
+var imagesCmd = &cobra.Command{
+	Use:                "images",
+	Short:              "Inventory container images running across contexts",
+	Long:               `List every container image running in pods across all contexts, deduplicated per context and grouped by image so differing tags stand out. Pods in all namespaces are scanned unless -n is given; other arguments such as -l are passed through to kubectl get.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImages(args)
+	},
+}
+
+// splitImage separates an image reference into repository and tag (or
+// digest). Registry ports are not mistaken for tags and an untagged image
+// reports "latest" like the container runtime would.
+func splitImage(image string) (string, string) {
+	if repo, digest, ok := strings.Cut(image, "@"); ok {
+		return repo, digest
+	}
+	slash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon > slash {
+		return image[:colon], image[colon+1:]
+	}
+	return image, "latest"
+}
+
+type imageUsage struct {
+	context string
+	repo    string
+	tag     string
+	count   int
+}
+
+// collectImages counts containers per context and image, ordered by image
+// repository, tag, and then the order contexts were queried in.
+func collectImages(results []contextResult) []imageUsage {
+	var usages []imageUsage
+	index := make(map[[3]string]int)
+	contextOrder := make(map[string]int)
+	for i, result := range results {
+		contextOrder[result.context] = i
+		if result.err != nil {
+			continue
+		}
+		for _, line := range strings.Split(result.output, "\n") {
+			image := strings.TrimSpace(line)
+			if image == "" {
+				continue
+			}
+			repo, tag := splitImage(image)
+			key := [3]string{result.context, repo, tag}
+			if i, ok := index[key]; ok {
+				usages[i].count++
+				continue
+			}
+			index[key] = len(usages)
+			usages = append(usages, imageUsage{context: result.context, repo: repo, tag: tag, count: 1})
+		}
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].repo != usages[j].repo {
+			return usages[i].repo < usages[j].repo
+		}
+		if usages[i].tag != usages[j].tag {
+			return usages[i].tag < usages[j].tag
+		}
+		return contextOrder[usages[i].context] < contextOrder[usages[j].context]
+	})
+	return usages
+}
+
+func runImages(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	getArgs := append([]string{"pods", "-o", imagesJSONPath}, args...)
+	if namespaces, _ := extractStringFlag(args, "-n", "--namespace"); len(namespaces) == 0 {
+		getArgs = append(getArgs, "--all-namespaces")
+	}
+	results := runAcrossContexts(contexts, "get", getArgs)
+
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+		}
+	}
+
+	var rows [][]string
+	for _, usage := range collectImages(results) {
+		rows = append(rows, []string{usage.context, usage.repo, usage.tag, strconv.Itoa(usage.count)})
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "IMAGE", "TAG", "CONTAINERS"}, rows)
+	return nil
+}
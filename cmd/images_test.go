@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagesCmd(t *testing.T) {
+	require.NotNil(t, imagesCmd)
+	assert.Equal(t, "images", imagesCmd.Use)
+	assert.True(t, imagesCmd.DisableFlagParsing)
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		repo  string
+		tag   string
+	}{
+		{"nginx:1.25", "nginx", "1.25"},
+		{"nginx", "nginx", "latest"},
+		{"registry.local:5000/team/app", "registry.local:5000/team/app", "latest"},
+		{"registry.local:5000/team/app:v2", "registry.local:5000/team/app", "v2"},
+		{"ghcr.io/org/app@sha256:abc123", "ghcr.io/org/app", "sha256:abc123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			repo, tag := splitImage(tt.image)
+			assert.Equal(t, tt.repo, repo)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
+
+func TestCollectImages(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "nginx:1.25\nredis:7\nnginx:1.25\n"},
+		{context: "ctx2", output: "nginx:1.24\n"},
+		{context: "ctx3", err: fmt.Errorf("exit status 1")},
+	}
+
+	usages := collectImages(results)
+	assert.Equal(t, []imageUsage{
+		{context: "ctx2", repo: "nginx", tag: "1.24", count: 1},
+		{context: "ctx1", repo: "nginx", tag: "1.25", count: 2},
+		{context: "ctx1", repo: "redis", tag: "7", count: 1},
+	}, usages)
+}
+
+func TestRunImages(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1"})
+	t.Setenv("KUBECONFIG", path)
+
+	t.Run("all namespaces by default", func(t *testing.T) {
+		var gotArgs []string
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			gotArgs = extraArgs
+			return "nginx:1.25\n", nil
+		})
+		output := captureStdout(func() {
+			require.NoError(t, runImages(nil))
+		})
+		assert.Equal(t, []string{"pods", "-o", imagesJSONPath, "--all-namespaces"}, gotArgs)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "CONTEXT  IMAGE    TAG     CONTAINERS", lines[0])
+		assert.Regexp(t, `^ctx1\s+nginx\s+1\.25\s+1$`, lines[1])
+	})
+
+	t.Run("namespace given", func(t *testing.T) {
+		var gotArgs []string
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			gotArgs = extraArgs
+			return "", nil
+		})
+		captureStdout(func() {
+			require.NoError(t, runImages([]string{"-n", "web"}))
+		})
+		assert.Equal(t, []string{"pods", "-o", imagesJSONPath, "-n", "web"}, gotArgs)
+	})
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import "strings"
+
+var errorsInline bool
+
+const inlineErrorReasonWidth = 60
+
+// inlineErrorReason is the last line of kubectl's output for a failed
+// context, or the error itself, cut to fit on one table row.
+func inlineErrorReason(result contextResult) string {
+	reason := result.err.Error()
+	if strings.TrimSpace(result.output) != "" {
+		reason = lastLine(result.output)
+	}
+	if runes := []rune(reason); len(runes) > inlineErrorReasonWidth {
+		reason = string(runes[:inlineErrorReasonWidth-3]) + "..."
+	}
+	return reason
+}
+
+// inlineErrorRow builds the merged table row for a failed context: ERROR
+// under STATUS (or the first column when there is none), blanks elsewhere,
+// and the reason after the last column.
+func inlineErrorRow(header []string, reason string) []string {
+	if len(header) == 0 {
+		return []string{"ERROR", reason}
+	}
+	row := make([]string, len(header), len(header)+1)
+	status := findSortColumn(header, "STATUS")
+	if status < 0 {
+		status = 0
+	}
+	row[status] = "ERROR"
+	return append(row, reason)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineErrorReason(t *testing.T) {
+	assert.Equal(t, "connection refused", inlineErrorReason(contextResult{err: fmt.Errorf("connection refused")}))
+	assert.Equal(t, "error: Unauthorized", inlineErrorReason(contextResult{output: "warning\nerror: Unauthorized\n", err: fmt.Errorf("exit status 1")}))
+
+	long := inlineErrorReason(contextResult{output: strings.Repeat("x", 100), err: fmt.Errorf("exit status 1")})
+	assert.Len(t, long, inlineErrorReasonWidth)
+	assert.True(t, strings.HasSuffix(long, "..."))
+
+	wide := inlineErrorReason(contextResult{output: strings.Repeat("é", 100), err: fmt.Errorf("exit status 1")})
+	assert.Equal(t, strings.Repeat("é", inlineErrorReasonWidth-3)+"...", wide)
+	assert.True(t, utf8.ValidString(wide))
+}
+
+func TestInlineErrorRow(t *testing.T) {
+	assert.Equal(t, []string{"", "", "ERROR", "", "timeout"}, inlineErrorRow([]string{"NAME", "READY", "STATUS", "AGE"}, "timeout"))
+	assert.Equal(t, []string{"ERROR", "", "timeout"}, inlineErrorRow([]string{"NAME", "AGE"}, "timeout"))
+	assert.Equal(t, []string{"ERROR", "timeout"}, inlineErrorRow(nil, "timeout"))
+}
+
+func TestFormatDefaultOutputErrorsInline(t *testing.T) {
+	errorsInline = true
+	t.Cleanup(func() { errorsInline = false })
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, formatDefaultOutput([]contextResult{
+				{context: "ctx1", output: "NAME    STATUS     AGE\npod1    Running    5m"},
+				{context: "ctx2", output: "error: You must be logged in to the server (Unauthorized)", err: fmt.Errorf("exit status 1")},
+			}))
+		})
+	})
+	assert.Empty(t, stderr)
+	assert.Equal(t, "CONTEXT  NAME    STATUS     AGE\n"+
+		"ctx1     pod1    Running    5m\n"+
+		"ctx2             ERROR             error: You must be logged in to the server (Unauthorized)\n", output)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonLogs parses structured log lines so that they can be filtered by level
+// and reduced to a few fields, which keeps merged output readable.
+type jsonLogs struct {
+	fields   []string
+	minLevel int // 0 keeps every line
+}
+
+var levelRanks = map[string]int{
+	"trace": 1, "debug": 2, "info": 3, "information": 3, "notice": 3,
+	"warn": 4, "warning": 4, "error": 5, "err": 5,
+	"fatal": 6, "critical": 6, "crit": 6, "panic": 6, "emergency": 6,
+}
+
+// levelKeys are the names loggers commonly give the level field.
+var levelKeys = []string{"level", "lvl", "severity", "log.level"}
+
+// parseJSONLogs extracts --json-logs, --fields, and --level. It returns nil
+// when --json-logs is not given; --fields and --level imply it.
+func parseJSONLogs(args []string) (*jsonLogs, []string, error) {
+	enabled, args := extractBoolFlag(args, "--json-logs")
+	fields, args := extractStringFlag(args, "--fields")
+	levels, args := extractStringFlag(args, "--level")
+	if !enabled && len(fields) == 0 && len(levels) == 0 {
+		return nil, args, nil
+	}
+
+	logs := &jsonLogs{}
+	for _, list := range fields {
+		for _, field := range strings.Split(list, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				logs.fields = append(logs.fields, field)
+			}
+		}
+	}
+	if len(levels) > 0 {
+		rank, ok := levelRanks[strings.ToLower(levels[len(levels)-1])]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid --level %q: use trace, debug, info, warn, error, or fatal", levels[len(levels)-1])
+		}
+		logs.minLevel = rank
+	}
+	return logs, args, nil
+}
+
+// filter returns how a log line is printed and whether it is kept. Lines
+// that are not JSON objects are printed as they are unless --level is set.
+func (j *jsonLogs) filter(line string) (string, bool) {
+	if j == nil {
+		return line, true
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return line, j.minLevel == 0
+	}
+	if j.minLevel > 0 && entryLevel(entry) < j.minLevel {
+		return line, false
+	}
+	if len(j.fields) == 0 {
+		return line, true
+	}
+
+	pairs := make([]string, 0, len(j.fields))
+	for _, field := range j.fields {
+		value, ok := lookupField(entry, field)
+		if !ok {
+			continue
+		}
+		pairs = append(pairs, field+"="+logfmtValue(value))
+	}
+	return strings.Join(pairs, " "), true
+}
+
+// entryLevel ranks the entry's level, by name or as a pino-style number
+// (10 trace to 60 fatal). Entries without a level rank as info.
+func entryLevel(entry map[string]interface{}) int {
+	for _, key := range levelKeys {
+		switch level := entry[key].(type) {
+		case string:
+			if rank, ok := levelRanks[strings.ToLower(level)]; ok {
+				return rank
+			}
+		case float64:
+			return min(max(int(level)/10, 1), 6)
+		}
+	}
+	return levelRanks["info"]
+}
+
+// lookupField finds a field by name, or by dotted path into nested objects.
+func lookupField(entry map[string]interface{}, field string) (interface{}, bool) {
+	if value, ok := entry[field]; ok {
+		return value, true
+	}
+	head, rest, nested := strings.Cut(field, ".")
+	if !nested {
+		return nil, false
+	}
+	child, ok := entry[head].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookupField(child, rest)
+}
+
+// logfmtValue formats a value as logfmt does, quoting strings that contain
+// spaces or quotes.
+func logfmtValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\"=") {
+			return strconv.Quote(v)
+		}
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
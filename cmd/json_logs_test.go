@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONLogs(t *testing.T) {
+	logs, args, err := parseJSONLogs([]string{"web-1", "-f"})
+	require.NoError(t, err)
+	assert.Nil(t, logs)
+	assert.Equal(t, []string{"web-1", "-f"}, args)
+
+	logs, args, err = parseJSONLogs([]string{"web-1", "--fields", "level, msg", "--level=WARN"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"level", "msg"}, logs.fields)
+	assert.Equal(t, levelRanks["warn"], logs.minLevel)
+	assert.Equal(t, []string{"web-1"}, args)
+
+	logs, _, err = parseJSONLogs([]string{"--json-logs"})
+	require.NoError(t, err)
+	assert.Equal(t, &jsonLogs{}, logs)
+
+	_, _, err = parseJSONLogs([]string{"--level", "loud"})
+	assert.Error(t, err)
+}
+
+func TestJSONLogsFilter(t *testing.T) {
+	logs := &jsonLogs{fields: []string{"level", "msg", "http.status"}, minLevel: levelRanks["warn"]}
+
+	text, keep := logs.filter(`{"level":"error","msg":"connection refused","http":{"status":503}}`)
+	assert.True(t, keep)
+	assert.Equal(t, `level=error msg="connection refused" http.status=503`, text)
+
+	_, keep = logs.filter(`{"level":"info","msg":"ok"}`)
+	assert.False(t, keep)
+
+	_, keep = logs.filter("panic: runtime error")
+	assert.False(t, keep)
+
+	text, keep = (&jsonLogs{}).filter("panic: runtime error")
+	assert.True(t, keep)
+	assert.Equal(t, "panic: runtime error", text)
+
+	var disabled *jsonLogs
+	text, keep = disabled.filter(`{"level":"debug"}`)
+	assert.True(t, keep)
+	assert.Equal(t, `{"level":"debug"}`, text)
+}
+
+func TestEntryLevel(t *testing.T) {
+	assert.Equal(t, levelRanks["warn"], entryLevel(map[string]interface{}{"severity": "WARNING"}))
+	assert.Equal(t, levelRanks["error"], entryLevel(map[string]interface{}{"level": float64(50)}))
+	assert.Equal(t, levelRanks["info"], entryLevel(map[string]interface{}{"msg": "no level"}))
+}
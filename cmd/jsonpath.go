@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// contextVariable may appear in a -o jsonpath template and is replaced with
+// the name of the context each kubectl invocation runs against. Templates
+// that use it control their own layout, so their output is printed as is
+// instead of behind a context prefix.
+const contextVariable = "{context}"
+
+// inlineJSONPathFile turns -o jsonpath-file=<path> into an inline jsonpath
+// template when the file uses {context}, so it can be substituted per context.
+func inlineJSONPathFile(args []string) ([]string, error) {
+	var readErr error
+	args = rewriteOutputFormat(args, func(format string) string {
+		path, ok := strings.CutPrefix(format, "jsonpath-file=")
+		if !ok {
+			return format
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			readErr = fmt.Errorf("failed to read jsonpath file: %w", err)
+			return format
+		}
+		if !strings.Contains(string(data), contextVariable) {
+			return format
+		}
+		return "jsonpath=" + strings.TrimRight(string(data), "\n")
+	})
+	return args, readErr
+}
+
+func usesContextVariable(args []string) bool {
+	used := false
+	rewriteOutputFormat(args, func(format string) string {
+		if strings.HasPrefix(format, "jsonpath=") && strings.Contains(format, contextVariable) {
+			used = true
+		}
+		return format
+	})
+	return used
+}
+
+func withContextVariable(args []string, context string) []string {
+	return rewriteOutputFormat(args, func(format string) string {
+		if !strings.HasPrefix(format, "jsonpath=") {
+			return format
+		}
+		return strings.ReplaceAll(format, contextVariable, context)
+	})
+}
+
+// formatVerbatimOutput prints each context's output unmodified, in context
+// order, ending each with a newline since jsonpath output usually lacks one.
+func formatVerbatimOutput(results []contextResult) error {
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+		}
+	}
+	for _, result := range results {
+		if result.err != nil || result.output == "" {
+			continue
+		}
+		fmt.Print(result.output)
+		if !strings.HasSuffix(result.output, "\n") {
+			fmt.Println()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContextVariable(t *testing.T) {
+	args := []string{"pods", "-o", `jsonpath={range .items[*]}{context}{"\t"}{.metadata.name}{"\n"}{end}`}
+	assert.True(t, usesContextVariable(args))
+	assert.Equal(t, `jsonpath={range .items[*]}prod{"\t"}{.metadata.name}{"\n"}{end}`, withContextVariable(args, "prod")[2])
+
+	assert.False(t, usesContextVariable([]string{"pods", "-o", "jsonpath={.items[*].metadata.name}"}))
+	assert.False(t, usesContextVariable([]string{"pods", "-l", "app={context}"}))
+}
+
+func TestInlineJSONPathFile(t *testing.T) {
+	dir := t.TempDir()
+	withVariable := filepath.Join(dir, "with.tmpl")
+	require.NoError(t, os.WriteFile(withVariable, []byte("{context} {.metadata.name}\n"), 0o600))
+	without := filepath.Join(dir, "without.tmpl")
+	require.NoError(t, os.WriteFile(without, []byte("{.metadata.name}\n"), 0o600))
+
+	args, err := inlineJSONPathFile([]string{"pod", "web", "-o", "jsonpath-file=" + withVariable})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pod", "web", "-o", "jsonpath={context} {.metadata.name}"}, args)
+
+	args, err = inlineJSONPathFile([]string{"pod", "--output=jsonpath-file=" + without})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pod", "--output=jsonpath-file=" + without}, args)
+
+	_, err = inlineJSONPathFile([]string{"pod", "-o", "jsonpath-file=" + filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "failed to read jsonpath file")
+}
+
+func TestRunCommandJSONPathWithContextVariable(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"prod", "staging"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		template := strings.TrimPrefix(extraArgs[2], "jsonpath=")
+		if context == "staging" {
+			return "error: connection refused", fmt.Errorf("exit status 1")
+		}
+		return strings.ReplaceAll(template, "{.metadata.name}", "web"), nil
+	})
+
+	var stdout string
+	stderr := captureStderr(func() {
+		stdout = captureStdout(func() {
+			require.NoError(t, runCommand("get", []string{"pod", "-o", "jsonpath={context}/{.metadata.name}"}))
+		})
+	})
+	assert.Equal(t, "prod/web\n", stdout)
+	assert.Contains(t, stderr, "Context staging: Error: exit status 1")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultDropColumns are the columns dropped first, in order, when a merged
+// table is wider than the terminal. Columns only -o wide prints are not
+// listed, as wide output is never fitted. The dropColumns config setting
+// replaces them.
+var defaultDropColumns = []string{"LABELS", "CLUSTER-IP", "UP-TO-DATE", "ROLES", "RESTARTS"}
+
+var dropColumns = defaultDropColumns
+
+// minColumnWidth is the narrowest a column is cut to once there is nothing
+// left to drop.
+const minColumnWidth = 8
+
+const columnGap = len("    ")
+
+// stdoutWidth is the terminal width, or 0 when stdout is not a terminal. It
+// is a variable so tests can fake a terminal.
+var stdoutWidth = func() int {
+	if !isTerminal() {
+		return 0
+	}
+	width, _, err := term.GetSize(int(terminalStdout().Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// columnLayout is the subset of a table's columns that fits the terminal.
+type columnLayout struct {
+	header int   // number of header columns the layout was fitted for
+	keep   []int // indexes of the kept columns, in order
+	widths []int // width each kept column is cut to
+}
+
+// fitColumns drops columns listed in dropColumns, then narrows the widest
+// remaining ones, until the table fits in available columns. The first
+// column, usually NAME, is always kept.
+func fitColumns(header []string, widths []int, available int) columnLayout {
+	layout := columnLayout{header: len(header)}
+	for i := range header {
+		layout.keep = append(layout.keep, i)
+		layout.widths = append(layout.widths, widths[i])
+	}
+
+	for _, name := range dropColumns {
+		if layout.total() <= available {
+			break
+		}
+		for k, index := range layout.keep {
+			if index > 0 && strings.EqualFold(header[index], name) {
+				layout.keep = append(layout.keep[:k:k], layout.keep[k+1:]...)
+				layout.widths = append(layout.widths[:k:k], layout.widths[k+1:]...)
+				break
+			}
+		}
+	}
+
+	for overflow := layout.total() - available; overflow > 0; overflow = layout.total() - available {
+		widest := -1
+		for k, width := range layout.widths {
+			if width > minColumnWidth && (widest < 0 || width > layout.widths[widest]) {
+				widest = k
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		layout.widths[widest] -= min(overflow, layout.widths[widest]-minColumnWidth)
+	}
+	return layout
+}
+
+func (l columnLayout) total() int {
+	total := 0
+	for _, width := range l.widths {
+		total += width
+	}
+	if len(l.widths) > 1 {
+		total += columnGap * (len(l.widths) - 1)
+	}
+	return total
+}
+
+// apply projects a row onto the kept columns, cutting cells that are too
+// wide. Cells beyond the header, such as --errors-inline reasons, are kept.
+func (l columnLayout) apply(cells []string) []string {
+	var projected []string
+	for k, index := range l.keep {
+		cell := ""
+		if index < len(cells) {
+			cell = truncateCell(cells[index], l.widths[k])
+		}
+		projected = append(projected, cell)
+	}
+	if len(cells) > l.header {
+		projected = append(projected, cells[l.header:]...)
+	}
+	return projected
+}
+
+func truncateCell(cell string, width int) string {
+	if visibleWidth(cell) <= width {
+		return cell
+	}
+	runes := []rune(stripColor(cell))
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeTerminalWidth(t *testing.T, width int) {
+	t.Helper()
+	original := stdoutWidth
+	stdoutWidth = func() int { return width }
+	t.Cleanup(func() { stdoutWidth = original })
+}
+
+func TestFitColumnsFits(t *testing.T) {
+	layout := fitColumns([]string{"NAME", "STATUS"}, []int{4, 7}, 80)
+	assert.Equal(t, []int{0, 1}, layout.keep)
+	assert.Equal(t, []int{4, 7}, layout.widths)
+}
+
+func TestFitColumnsDropsLowPriorityColumns(t *testing.T) {
+	header := []string{"NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE", "LABELS"}
+	widths := []int{20, 5, 10, 9, 3, 30}
+
+	layout := fitColumns(header, widths, 80)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, layout.keep)
+	assert.LessOrEqual(t, layout.total(), 80)
+
+	layout = fitColumns(header, widths, 60)
+	assert.Equal(t, []int{0, 1, 3, 4}, layout.keep)
+	assert.LessOrEqual(t, layout.total(), 60)
+}
+
+func TestFitColumnsShrinksWidestColumn(t *testing.T) {
+	layout := fitColumns([]string{"NAME", "STATUS", "MESSAGE"}, []int{30, 7, 40}, 60)
+	assert.Equal(t, []int{0, 1, 2}, layout.keep)
+	assert.Equal(t, []int{30, 7, 15}, layout.widths)
+
+	layout = fitColumns([]string{"NAME", "STATUS"}, []int{30, 7}, 10)
+	assert.Equal(t, []int{8, 7}, layout.widths, "columns are not cut below minColumnWidth")
+}
+
+func TestColumnLayoutApply(t *testing.T) {
+	layout := columnLayout{header: 3, keep: []int{0, 2}, widths: []int{8, 5}}
+	assert.Equal(t, []string{"web", "12d"}, layout.apply([]string{"web", "Running", "12d"}))
+	assert.Equal(t, []string{"web-123…", ""}, layout.apply([]string{"web-123456789", "Running"}))
+	assert.Equal(t, []string{"", "", "reason"}, layout.apply([]string{"", "", "", "reason"}))
+}
+
+func TestTruncateCell(t *testing.T) {
+	assert.Equal(t, "Running", truncateCell("Running", 8))
+	assert.Equal(t, "Runn…", truncateCell("Running", 5))
+	assert.Equal(t, "Runn…", truncateCell(colorGreen+"Running"+colorReset, 5))
+}
+
+func TestFormatDefaultOutputFitsTerminal(t *testing.T) {
+	fakeTerminalWidth(t, 45)
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    READY   STATUS    RESTARTS   AGE\nweb-5d8f9   1/1     Running   0          5m"},
+		}))
+	})
+	assert.Equal(t, "CONTEXT  NAME         READY    STATUS     AGE\n"+
+		"ctx1     web-5d8f9    1/1      Running    5m\n", output)
+}
+
+func TestFormatWideOutputNotFitted(t *testing.T) {
+	fakeTerminalWidth(t, 20)
+
+	output := captureStdout(func() {
+		require.NoError(t, formatTableOutput([]contextResult{
+			{context: "ctx1", output: "NAME   RESTARTS\nweb    0"},
+		}, true))
+	})
+	assert.Contains(t, output, "RESTARTS")
+}
+
+func TestLoadAndApplyConfigDropColumns(t *testing.T) {
+	resetConfigState(t)
+	t.Setenv("KUBECTL_X_CONFIG", writeConfig(t, "dropColumns: [AGE, IP]\n"))
+
+	require.NoError(t, loadAndApplyConfig(&cobra.Command{Use: "get"}, nil))
+	assert.Equal(t, []string{"AGE", "IP"}, dropColumns)
+}
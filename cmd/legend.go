@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+var showLegend bool
+
+var legendOnce sync.Once
+
+// legend maps each context to its color, for example "■ prod  ■ staging"
+// with every entry in its context's color.
+func legend(contexts []string) string {
+	var entries []string
+	for _, context := range contexts {
+		entries = append(entries, getContextColor(context)+"■ "+context+colorReset)
+	}
+	return "Contexts: " + strings.Join(entries, "  ")
+}
+
+// printLegendOnce prints the --legend before the first fan-out of the run.
+// It is skipped when colors are off, where it would carry no information.
+func printLegendOnce(w io.Writer, contexts []string) {
+	if !showLegend || !colorEnabled() || progressDisabled {
+		return
+	}
+	legendOnce.Do(func() {
+		fmt.Fprintln(w, legend(contexts))
+	})
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableLegend(t *testing.T) {
+	t.Helper()
+	showLegend = true
+	colorMode = "always"
+	legendOnce = sync.Once{}
+	t.Cleanup(func() {
+		showLegend = false
+		colorMode = "auto"
+		legendOnce = sync.Once{}
+	})
+}
+
+func TestLegend(t *testing.T) {
+	colorMode = "always"
+	t.Cleanup(func() { colorMode = "auto" })
+	setColorRules(t, []ColorRule{{Match: "prod", Color: "red"}, {Match: "dev", Color: "green"}})
+
+	assert.Equal(t, "Contexts: "+colorRed+"■ prod"+colorReset+"  "+colorGreen+"■ dev"+colorReset, legend([]string{"prod", "dev"}))
+}
+
+func TestPrintLegendOnce(t *testing.T) {
+	enableLegend(t)
+
+	var out bytes.Buffer
+	printLegendOnce(&out, []string{"prod"})
+	printLegendOnce(&out, []string{"prod"})
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("Contexts:")))
+}
+
+func TestPrintLegendOnceWithoutColor(t *testing.T) {
+	enableLegend(t)
+	colorMode = "never"
+
+	var out bytes.Buffer
+	printLegendOnce(&out, []string{"prod"})
+	assert.Empty(t, out.String())
+}
+
+func TestNewStreamerPrintsLegend(t *testing.T) {
+	enableLegend(t)
+
+	stderr := captureStderr(func() {
+		s := newStreamer([]string{"prod", "staging"}, "logs", streamOptions{})
+		require.NoError(t, s.wait())
+	})
+	assert.Contains(t, stderr, "Contexts: ")
+	assert.Contains(t, stderr, "■ staging")
+}
@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	// logViewerMaxLines bounds the lines the viewer keeps, and separately
+	// those it holds while paused.
+	logViewerMaxLines = 10000
+	// logViewerNotices is how many stderr lines the viewer shows.
+	logViewerNotices = 3
+	// logViewerRedraw limits how often a busy stream redraws the screen.
+	logViewerRedraw = 100 * time.Millisecond
+)
+
+type viewerLine struct {
+	at      time.Time
+	context string
+	label   string
+	text    string
+}
+
+// logViewer is the state of logs -f --tui: the lines received so far, which
+// contexts are shown, and where the user has scrolled to.
+type logViewer struct {
+	contexts []string
+	format   logFormat
+	hidden   map[string]bool
+	lines    []viewerLine
+	width    int
+	notices  []string
+	ended    bool
+
+	paused  bool
+	held    []viewerLine
+	dropped int
+
+	// scroll is how many shown lines are below the bottom of the view.
+	scroll int
+	page   int
+
+	search    string
+	searching bool
+
+	picking bool
+	pick    int
+}
+
+func newLogViewer(contexts []string, format logFormat) *logViewer {
+	return &logViewer{contexts: contexts, format: format, hidden: make(map[string]bool), page: 1}
+}
+
+// add takes a line from a stream. While paused it is held back, keeping the
+// newest logViewerMaxLines.
+func (v *logViewer) add(line viewerLine) {
+	v.width = max(v.width, len(line.label))
+	if !v.paused {
+		v.insert(line)
+		return
+	}
+	v.held = append(v.held, line)
+	if excess := len(v.held) - logViewerMaxLines; excess > 0 {
+		v.held = v.held[excess:]
+		v.dropped += excess
+	}
+}
+
+// insert adds a line in timestamp order when merging, and at the end
+// otherwise. A view scrolled away from the end stays where it is.
+func (v *logViewer) insert(line viewerLine) {
+	i := len(v.lines)
+	if v.format.merged {
+		for i > 0 && v.lines[i-1].at.After(line.at) {
+			i--
+		}
+	}
+	v.lines = slices.Insert(v.lines, i, line)
+	if excess := len(v.lines) - logViewerMaxLines; excess > 0 {
+		v.lines = v.lines[excess:]
+	}
+	if v.scroll > 0 && v.shows(line) {
+		v.scroll++
+	}
+}
+
+func (v *logViewer) notice(text string) {
+	v.notices = append(v.notices, text)
+	if len(v.notices) > logViewerNotices {
+		v.notices = v.notices[len(v.notices)-logViewerNotices:]
+	}
+}
+
+func (v *logViewer) setPaused(paused bool) {
+	v.paused = paused
+	if paused {
+		return
+	}
+	for _, line := range v.held {
+		v.insert(line)
+	}
+	v.held = nil
+	v.dropped = 0
+}
+
+func (v *logViewer) toggle(context string) {
+	v.hidden[context] = !v.hidden[context]
+	v.scroll = 0
+}
+
+func (v *logViewer) searchPattern() *regexp.Regexp {
+	if v.search == "" {
+		return nil
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(v.search))
+}
+
+func (v *logViewer) shows(line viewerLine) bool {
+	if v.hidden[line.context] {
+		return false
+	}
+	return v.search == "" || strings.Contains(strings.ToLower(line.label+"  "+line.text), strings.ToLower(v.search))
+}
+
+func (v *logViewer) visibleLines() []viewerLine {
+	var lines []viewerLine
+	for _, line := range v.lines {
+		if v.shows(line) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// handleKey applies a keypress and reports whether the user quit.
+func (v *logViewer) handleKey(key string) bool {
+	if key == "\x03" {
+		return true
+	}
+
+	if v.searching {
+		switch key {
+		case "\r", "\x1b":
+			v.searching = false
+		case "\x7f", "\b":
+			if len(v.search) > 0 {
+				v.search = v.search[:len(v.search)-1]
+			}
+		default:
+			if len(key) == 1 && key[0] >= ' ' {
+				v.search += key
+			}
+		}
+		v.scroll = 0
+		return false
+	}
+
+	if v.picking {
+		switch key {
+		case "\x1b", "c", "q":
+			v.picking = false
+		case "\x1b[A", "k":
+			if v.pick > 0 {
+				v.pick--
+			}
+		case "\x1b[B", "j":
+			if v.pick < len(v.contexts)-1 {
+				v.pick++
+			}
+		case " ", "\r":
+			v.toggle(v.contexts[v.pick])
+		case "a":
+			v.hidden = make(map[string]bool)
+		}
+		return false
+	}
+
+	switch key {
+	case "q":
+		return true
+	case " ", "p":
+		v.setPaused(!v.paused)
+	case "/":
+		v.searching = true
+	case "\x1b":
+		v.search = ""
+		v.scroll = 0
+	case "c":
+		v.picking = true
+	case "\x1b[A", "k":
+		v.scroll++
+	case "\x1b[B", "j":
+		v.scroll = max(v.scroll-1, 0)
+	case "\x1b[5~":
+		v.scroll += v.page
+	case "\x1b[6~":
+		v.scroll = max(v.scroll-v.page, 0)
+	case "g", "\x1b[H":
+		v.scroll = len(v.lines)
+	case "G", "\x1b[F":
+		v.scroll = 0
+	default:
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			if i := int(key[0] - '1'); i < len(v.contexts) {
+				v.toggle(v.contexts[i])
+			}
+		}
+	}
+	return false
+}
+
+// render draws the current view as height lines at most.
+func (v *logViewer) render(width, height int) []string {
+	truncate := func(line string) string {
+		if width > 0 && utf8.RuneCountInString(line) > width {
+			return string([]rune(line)[:width])
+		}
+		return line
+	}
+
+	if v.picking {
+		lines := []string{truncate("Contexts shown")}
+		for i, context := range v.contexts {
+			mark := "[x]"
+			if v.hidden[context] {
+				mark = "[ ]"
+			}
+			line := truncate(fmt.Sprintf("%s %d %s", mark, i+1, context))
+			if i == v.pick {
+				line = colorHighlight + line + colorReset
+			}
+			lines = append(lines, line)
+		}
+		return append(lines, colorGray+truncate("up/down move  space toggle  a show all  esc back")+colorReset)
+	}
+
+	title := fmt.Sprintf("kubectl x logs: %d lines", len(v.lines))
+	if shown := len(v.contexts) - v.hiddenCount(); shown < len(v.contexts) {
+		title += fmt.Sprintf("  %d of %d contexts shown", shown, len(v.contexts))
+	}
+	if v.paused {
+		title += fmt.Sprintf("  PAUSED (%d new", len(v.held))
+		if v.dropped > 0 {
+			title += fmt.Sprintf(", %d dropped", v.dropped)
+		}
+		title += ")"
+	}
+	if v.ended {
+		title += "  all streams ended"
+	}
+	if v.searching || v.search != "" {
+		title += "  search: " + v.search
+		if v.searching {
+			title += "_"
+		}
+	}
+	lines := []string{truncate(title)}
+
+	v.page = max(height-2-len(v.notices), 1)
+	visible := v.visibleLines()
+	v.scroll = min(v.scroll, max(len(visible)-v.page, 0))
+	end := len(visible) - v.scroll
+	pattern := v.searchPattern()
+	for _, line := range visible[max(end-v.page, 0):end] {
+		lines = append(lines, v.renderLine(line, width, pattern))
+	}
+
+	for _, notice := range v.notices {
+		lines = append(lines, colorGray+truncate(notice)+colorReset)
+	}
+	help := "space pause  up/down scroll  / search  c contexts  1-9 toggle  q quit"
+	if v.scroll > 0 {
+		help = "G back to the end  " + help
+	}
+	return append(lines, colorGray+truncate(help)+colorReset)
+}
+
+func (v *logViewer) hiddenCount() int {
+	count := 0
+	for _, context := range v.contexts {
+		if v.hidden[context] {
+			count++
+		}
+	}
+	return count
+}
+
+// renderLine prefixes a line with its label and cuts it to width, marking
+// search matches, or --highlight matches when not searching.
+func (v *logViewer) renderLine(line viewerLine, width int, search *regexp.Regexp) string {
+	padding := fillWidth(line.label, v.width)
+	prefix := contextPrefix(coloredLabel(line.context, line.label), padding)
+	text := line.text
+	if room := width - visibleWidth(prefix); width > 0 && utf8.RuneCountInString(text) > room {
+		text = string([]rune(text)[:max(room, 0)])
+	}
+	if search == nil {
+		return prefix + v.format.render(text)
+	}
+	return prefix + search.ReplaceAllStringFunc(text, func(match string) string {
+		return colorHighlight + match + colorReset
+	})
+}
+
+// openViewerTerminal takes over the terminal for the viewer. Until restore
+// is called, output to stderr, such as reconnect notices, is sent to
+// notices instead of being drawn over the screen.
+func openViewerTerminal() (<-chan string, func(), error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !isTerminal() {
+		return nil, nil, fmt.Errorf("--tui requires an interactive terminal")
+	}
+	if !ansiStdout {
+		return nil, nil, fmt.Errorf("--tui requires a terminal that supports ANSI escape sequences")
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to capture stderr: %w", err)
+	}
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		reader.Close()
+		writer.Close()
+		return nil, nil, fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	progressDisabled = true
+	fmt.Print("\033[?1049h\033[?25l")
+
+	stderr := os.Stderr
+	os.Stderr = writer
+	notices := make(chan string, 64)
+	go func() {
+		scanner := newLineScanner(reader)
+		for scanner.Scan() {
+			select {
+			case notices <- stripColor(scanner.Text()):
+			default:
+			}
+		}
+		reader.Close()
+	}()
+
+	return notices, func() {
+		os.Stderr = stderr
+		writer.Close()
+		fmt.Print("\033[?25h\033[?1049l")
+		term.Restore(int(os.Stdin.Fd()), state)
+	}, nil
+}
+
+// runLogViewer follows logs like streamFormattedLogs, but into a logViewer
+// until the user quits, which stops the streams.
+func runLogViewer(contexts []string, args []string, query *podQuery, format logFormat, reconnect bool, opts streamOptions, notices <-chan string) error {
+	viewer := newLogViewer(contexts, format)
+	stop := make(chan struct{})
+	lines := make(chan viewerLine, 1024)
+	opts.stop = stop
+	opts.handleStdout = func(reader io.Reader, coloredCtx, padding string) {
+		context := stripColor(coloredCtx)
+		last := time.Now()
+		scanner := newLineScanner(reader)
+		for scanner.Scan() {
+			label, text, at, stamped := format.split(context, scanner.Text())
+			if stamped {
+				last = at
+			} else if !format.merged {
+				last = time.Now()
+			}
+			text, keep := format.filter(text)
+			if !keep || !keepRow(label+"  "+text) {
+				continue
+			}
+			format.ship(context, label, text, last)
+			select {
+			case lines <- viewerLine{at: last, context: context, label: label, text: text}:
+			case <-stop:
+			}
+		}
+		finishScan(scanner, reader, coloredCtx)
+	}
+
+	streamed := make(chan error, 1)
+	go func() { streamed <- streamLogs(contexts, args, query, reconnect, opts) }()
+
+	draw := func() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil || height == 0 {
+			width, height = 0, 24
+		}
+		fmt.Print("\033[H\033[2J" + strings.Join(viewer.render(width, height), "\r\n"))
+	}
+
+	keys := make(chan string)
+	go readKeys(keys)
+	ticker := time.NewTicker(logViewerRedraw)
+	defer ticker.Stop()
+
+	draw()
+	dirty := false
+	for {
+		select {
+		case line := <-lines:
+			viewer.add(line)
+			dirty = true
+		case notice := <-notices:
+			viewer.notice(notice)
+			dirty = true
+		case <-streamed:
+			streamed = nil
+			viewer.ended = true
+			dirty = true
+		case <-ticker.C:
+			if dirty {
+				draw()
+				dirty = false
+			}
+		case key, ok := <-keys:
+			if !ok || viewer.handleKey(key) {
+				close(stop)
+				if streamed != nil {
+					<-streamed
+				}
+				return nil
+			}
+			draw()
+			dirty = false
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogViewer(format logFormat) *logViewer {
+	v := newLogViewer([]string{"prod", "staging"}, format)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	v.add(viewerLine{at: base.Add(2 * time.Second), context: "prod", label: "prod", text: "GET /health 200"})
+	v.add(viewerLine{at: base, context: "staging", label: "staging", text: "starting"})
+	v.add(viewerLine{at: base.Add(3 * time.Second), context: "prod", label: "prod", text: "GET /api 500"})
+	return v
+}
+
+func viewerTexts(lines []viewerLine) []string {
+	var result []string
+	for _, line := range lines {
+		result = append(result, line.text)
+	}
+	return result
+}
+
+func TestLogViewerAdd(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	assert.Equal(t, []string{"GET /health 200", "starting", "GET /api 500"}, viewerTexts(v.lines))
+	assert.Equal(t, len("staging"), v.width)
+
+	merged := newTestLogViewer(logFormat{merged: true})
+	assert.Equal(t, []string{"starting", "GET /health 200", "GET /api 500"}, viewerTexts(merged.lines))
+}
+
+func TestLogViewerPause(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	assert.False(t, v.handleKey(" "))
+	assert.True(t, v.paused)
+
+	v.add(viewerLine{context: "prod", label: "prod", text: "held"})
+	assert.Len(t, v.lines, 3)
+	assert.Len(t, v.held, 1)
+	assert.Contains(t, v.render(0, 10)[0], "PAUSED (1 new)")
+
+	v.handleKey("p")
+	assert.False(t, v.paused)
+	assert.Equal(t, "held", v.lines[3].text)
+	assert.Empty(t, v.held)
+}
+
+func TestLogViewerToggleContexts(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	v.handleKey("2")
+	assert.Equal(t, []string{"GET /health 200", "GET /api 500"}, viewerTexts(v.visibleLines()))
+	assert.Contains(t, v.render(0, 10)[0], "1 of 2 contexts shown")
+
+	v.handleKey("c")
+	require.True(t, v.picking)
+	lines := v.render(0, 10)
+	assert.Equal(t, colorHighlight+"[x] 1 prod"+colorReset, lines[1])
+	assert.Equal(t, "[ ] 2 staging", lines[2])
+
+	v.handleKey(" ")
+	assert.Empty(t, v.visibleLines())
+	v.handleKey("a")
+	v.handleKey("\x1b")
+	assert.False(t, v.picking)
+	assert.Len(t, v.visibleLines(), 3)
+}
+
+func TestLogViewerSearch(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	for _, key := range []string{"/", "g", "e", "t", "\r"} {
+		v.handleKey(key)
+	}
+	assert.Equal(t, "get", v.search)
+	assert.Equal(t, []string{"GET /health 200", "GET /api 500"}, viewerTexts(v.visibleLines()))
+
+	lines := v.render(0, 10)
+	assert.Equal(t, "prod     "+colorHighlight+"GET"+colorReset+" /health 200", lines[1])
+
+	v.handleKey("\x1b")
+	assert.Empty(t, v.search)
+	assert.Len(t, v.visibleLines(), 3)
+}
+
+func TestLogViewerScroll(t *testing.T) {
+	v := newLogViewer([]string{"prod"}, logFormat{})
+	for i := range 10 {
+		v.add(viewerLine{context: "prod", label: "prod", text: fmt.Sprintf("line %d", i)})
+	}
+
+	lines := v.render(0, 5)
+	assert.Equal(t, []string{"prod  line 7", "prod  line 8", "prod  line 9"}, lines[1:4])
+
+	v.handleKey("k")
+	v.add(viewerLine{context: "prod", label: "prod", text: "line 10"})
+	lines = v.render(0, 5)
+	assert.Equal(t, []string{"prod  line 6", "prod  line 7", "prod  line 8"}, lines[1:4])
+	assert.Contains(t, lines[4], "G back to the end")
+
+	v.handleKey("g")
+	lines = v.render(0, 5)
+	assert.Equal(t, "prod  line 0", lines[1])
+
+	v.handleKey("G")
+	lines = v.render(0, 5)
+	assert.Equal(t, "prod  line 10", lines[3])
+}
+
+func TestLogViewerRenderTruncates(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	v.notice("Context staging: stream ended, reconnecting in 1s")
+	lines := v.render(20, 8)
+	assert.LessOrEqual(t, len(lines), 8)
+	for _, line := range lines {
+		assert.LessOrEqual(t, visibleWidth(line), 20)
+	}
+	assert.Equal(t, "prod     GET /api 50", lines[3])
+}
+
+func TestLogViewerQuit(t *testing.T) {
+	v := newTestLogViewer(logFormat{})
+	assert.True(t, v.handleKey("q"))
+	assert.True(t, v.handleKey("\x03"))
+
+	v.handleKey("/")
+	assert.False(t, v.handleKey("q"))
+	assert.Equal(t, "q", v.search)
+}
@@ -1,19 +1,89 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 var logsCmd = &cobra.Command{
-	Use:                "logs",
-	Short:              "Run kubectl logs against all contexts",
-	Long:               `Run kubectl logs command against all contexts in parallel. Supports streaming with -f/--follow flag.`,
+	Use:   "logs",
+	Short: "Run kubectl logs against all contexts",
+	Long: `Run kubectl logs command against all contexts in parallel. Supports streaming with -f/--follow flag.
+
+With --pods REGEX or -l/--selector, the pods in each context are listed first and the logs of every matching pod are fetched or followed, like stern. When following, the pods are listed again every 10s and new ones are followed too.
+
+With --raw, output is copied through exactly as kubectl prints it, without splitting it into lines or adding context prefixes, for binary or very high-volume logs.
+
+With --json-logs, JSON log lines can be filtered with --level (the minimum level, e.g. error) and reduced to --fields (e.g. level,msg), printed as key=value pairs.
+
+With --label pod or --label pod,container, lines are prefixed with context/pod or context/pod[container] instead of just the context. kubectl's own --prefix is passed through.
+
+With --merge-sorted, lines from all contexts are interleaved in timestamp order. kubectl is asked for --timestamps, which are stripped again unless --timestamps was given. When following, lines are held for --reorder-window (default 2s) to absorb late arrivals.
+
+When following, a context whose stream fails, for example because the connection dropped, is reconnected after a backoff and resumes from its last line, unless its pod has Succeeded or Failed. Streams that end cleanly are not reconnected. Use --no-reconnect to let it end instead. --highlight REGEX marks matches within lines, across every context. --sink loki://HOST:PORT or --sink URL also ships every printed line to Loki or a webhook. --total-tail N prints only the N most recent lines of all contexts together. --max-lines-per-sec limits how many lines each context may print per second, and --max-lines-per-sec-total all of them together; suppressed lines are counted on stderr. With --idle-after DURATION, contexts that print nothing for that long are listed on stderr.
+
+With -f --tui, lines are shown in a scrollable viewer instead. Keys: space to pause and resume, up/down or j/k and page up/down to scroll, G to go back to the end, / to search, c to pick the contexts shown, 1-9 to toggle one, q to quit.`,
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if isFollowMode(args) {
-			return runStreamingCommand("logs", args, false)
+		mergeSorted, args := extractBoolFlag(args, "--merge-sorted")
+		raw, args := extractBoolFlag(args, "--raw")
+		follow, args, err := parseLogFollow(args)
+		if err != nil {
+			return err
+		}
+		prefix, args, err := parseLogPrefix(args)
+		if err != nil {
+			return err
+		}
+		highlight, args, err := parseHighlight(args)
+		if err != nil {
+			return err
+		}
+		jsonLogs, args, err := parseJSONLogs(args)
+		if err != nil {
+			return err
+		}
+		sink, args, err := parseLogSink(args)
+		if err != nil {
+			return err
+		}
+		totalTail, args, err := extractRate(args, "--total-tail")
+		if err != nil {
+			return err
 		}
-		return runCommand("logs", args)
+		query, args, err := parsePodQuery(args)
+		if err != nil {
+			return err
+		}
+		format := logFormat{prefix: prefix, merged: mergeSorted, json: jsonLogs, highlight: highlight, sink: sink, totalTail: totalTail}
+		if raw {
+			if !format.plain() {
+				return fmt.Errorf("--raw cannot be combined with --label, --merge-sorted, --json-logs, --highlight, --sink, or --total-tail")
+			}
+			if follow.throttle != nil {
+				return fmt.Errorf("--raw cannot be combined with --max-lines-per-sec")
+			}
+			if follow.tui {
+				return fmt.Errorf("--raw cannot be combined with --tui")
+			}
+			format.raw = true
+		}
+		if follow.tui && !isFollowMode(args) {
+			return fmt.Errorf("--tui requires -f")
+		}
+		if format.plain() && query == nil && !isFollowMode(args) {
+			return runCommand("logs", args)
+		}
+		return runLogsCommand(args, query, format, follow)
 	},
 }
 
@@ -25,3 +95,767 @@ func isFollowMode(args []string) bool {
 	}
 	return false
 }
+
+// logFollow holds the settings that only matter when following logs.
+type logFollow struct {
+	reconnect bool
+	throttle  *lineThrottle
+	idleAfter time.Duration
+	// tui shows the lines in an interactive viewer.
+	tui bool
+}
+
+func parseLogFollow(args []string) (logFollow, []string, error) {
+	noReconnect, args := extractBoolFlag(args, "--no-reconnect")
+	tui, args := extractBoolFlag(args, "--tui")
+	throttle, args, err := parseLineThrottle(args)
+	if err != nil {
+		return logFollow{}, nil, err
+	}
+	idleAfter, args, err := parseIdleAfter(args)
+	if err != nil {
+		return logFollow{}, nil, err
+	}
+	return logFollow{reconnect: !noReconnect, throttle: throttle, idleAfter: idleAfter, tui: tui}, args, nil
+}
+
+// inBackground runs fn until the returned function is called, which waits
+// for fn to return.
+func inBackground(fn func(stop <-chan struct{})) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		fn(stop)
+		close(done)
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+func runLogsCommand(args []string, query *podQuery, format logFormat, follow logFollow) error {
+	window, args, err := reorderWindow(args)
+	if err != nil {
+		return err
+	}
+	following := isFollowMode(args)
+	if following {
+		if err := rejectOnlyErrors("logs -f"); err != nil {
+			return err
+		}
+	}
+	contexts, err := logContexts(args)
+	if err != nil {
+		return err
+	}
+
+	if following && format.totalTail > 0 {
+		return fmt.Errorf("--total-tail cannot be combined with -f")
+	}
+	if following && window <= 0 {
+		format.merged = false
+	}
+	if format.totalTail > 0 {
+		args = totalTailArgs(args, format)
+	}
+	if format.stamped() {
+		format.showTimestamps, args = extractBoolFlag(args, "--timestamps")
+		args = append(args, "--timestamps")
+	}
+	if format.prefix != prefixContext {
+		args = append(args, "--prefix")
+	}
+	if format.sink != nil {
+		defer inBackground(format.sink.run)()
+	}
+
+	if !following {
+		results := runLogs(contexts, args, query)
+		switch {
+		case format.raw:
+			return formatVerbatimOutput(results)
+		case format.plain():
+			return formatRawOutput(results)
+		}
+		return formatLogs(results, format)
+	}
+	opts := streamOptions{raw: format.raw, throttle: follow.throttle}
+	var notices <-chan string
+	if follow.tui {
+		var restore func()
+		if notices, restore, err = openViewerTerminal(); err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if follow.throttle != nil {
+		defer inBackground(func(stop <-chan struct{}) { follow.throttle.run(os.Stderr, stop) })()
+	}
+	if follow.idleAfter > 0 {
+		opts.idle = newIdleWatcher(follow.idleAfter)
+		defer inBackground(func(stop <-chan struct{}) { opts.idle.run(os.Stderr, stop) })()
+	}
+	if follow.tui {
+		return runLogViewer(contexts, args, query, format, follow.reconnect, opts, notices)
+	}
+	if format.raw || format.plain() {
+		return streamLogs(contexts, args, query, follow.reconnect, opts)
+	}
+	return streamFormattedLogs(contexts, args, query, format, window, follow.reconnect, opts)
+}
+
+func logContexts(args []string) ([]string, error) {
+	contexts, err := getContexts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("no contexts found in kubeconfig")
+	}
+	warnNamespaceSkew(contexts, "logs", args)
+	return contexts, nil
+}
+
+// podQuery selects the pods whose logs are tailed in each context: those
+// whose name matches pattern, if set, among those matching selector.
+type podQuery struct {
+	pattern  *regexp.Regexp
+	selector string
+	nsArgs   []string
+}
+
+// parsePodQuery extracts --pods and -l/--selector. It returns a nil query
+// when neither is given, in which case args name the pod as usual.
+func parsePodQuery(args []string) (*podQuery, []string, error) {
+	patterns, args := extractStringFlag(args, "--pods")
+	selectors, args := extractStringFlag(args, "-l", "--selector")
+	if len(patterns) == 0 && len(selectors) == 0 {
+		return nil, args, nil
+	}
+
+	query := &podQuery{nsArgs: namespaceArgs(args)}
+	if len(patterns) > 0 {
+		pattern, err := regexp.Compile(patterns[len(patterns)-1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --pods pattern: %w", err)
+		}
+		query.pattern = pattern
+	}
+	if len(selectors) > 0 {
+		query.selector = selectors[len(selectors)-1]
+	}
+	// kubectl logs has no --all-namespaces; the pods are listed across
+	// namespaces instead and each is fetched from its own namespace.
+	var all bool
+	all, args = extractBoolFlag(args, "-A", "--all-namespaces")
+	if all {
+		query.nsArgs = []string{"--all-namespaces"}
+	}
+	return query, args, nil
+}
+
+// logArgs returns one kubectl logs argument list per pod the query matches
+// in the context, or args itself for a nil query.
+func (q *podQuery) logArgs(context string, args []string) ([][]string, error) {
+	if q == nil {
+		return [][]string{args}, nil
+	}
+	getArgs := append([]string{"pods", "--no-headers", "-o", "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name"}, q.nsArgs...)
+	if q.selector != "" {
+		getArgs = append(getArgs, "--selector", q.selector)
+	}
+	output, err := runKubectlCommand(context, "get", getArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %s", strings.TrimSpace(output))
+	}
+
+	var sets [][]string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || (q.pattern != nil && !q.pattern.MatchString(fields[1])) {
+			continue
+		}
+		set := append(append([]string{}, args...), "--namespace", fields[0], "pod/"+fields[1])
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// runLogs fetches the logs of every pod the query matches, concatenating
+// them per context. A pod whose logs cannot be fetched does not stop the
+// others; its error is reported alongside theirs.
+func runLogs(contexts []string, args []string, query *podQuery) []contextResult {
+	return runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		sets, err := query.logArgs(context, args)
+		if err != nil {
+			return "", err
+		}
+		var output strings.Builder
+		var errs []error
+		for _, set := range sets {
+			out, err := runKubectlCommand(context, "logs", set)
+			output.WriteString(out)
+			if err != nil {
+				if query != nil {
+					err = fmt.Errorf("%s: %w", set[len(set)-1], err)
+				}
+				errs = append(errs, err)
+			}
+		}
+		return output.String(), errors.Join(errs...)
+	})
+}
+
+// podRediscoverInterval is how often the pods are listed again while
+// following, to pick up pods that appeared since.
+const podRediscoverInterval = 10 * time.Second
+
+// streamLogs follows the logs of every pod the query matches, running one
+// kubectl process per pod, and starts following pods that appear later. With
+// reconnect, a stream that ends is resumed from where it left off.
+func streamLogs(contexts []string, args []string, query *podQuery, reconnect bool, opts streamOptions) error {
+	if query == nil {
+		return streamAcrossContexts(contexts, "logs", func(int, string) []string { return args }, withLogReconnect(opts, reconnect))
+	}
+
+	sets := make([][][]string, len(contexts))
+	results := runAcrossContextsIndexed(contexts, func(i int, context string) (string, error) {
+		found, err := query.logArgs(context, args)
+		sets[i] = found
+		return "", err
+	})
+
+	tails := newPodTails()
+	opts = withLogReconnect(opts, reconnect)
+	if resume := opts.reconnect; resume != nil {
+		// A pod that is gone is not reconnected to.
+		opts.reconnect = func(context string, args []string, lastOutput time.Time) []string {
+			if !tails.listed(context, args) {
+				return nil
+			}
+			return resume(context, args, lastOutput)
+		}
+	}
+	s := newStreamer(contexts, "logs", opts)
+
+	started := 0
+	for i, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		tails.list(result.context, sets[i])
+		for _, set := range sets[i] {
+			tails.follow(s, result.context, set)
+			started++
+		}
+	}
+	if started == 0 {
+		fmt.Fprintln(os.Stderr, "No pods match yet; waiting for new ones")
+	}
+
+	s.every(podRediscoverInterval, func() {
+		forEachContext(contexts, func(context string) {
+			found, err := query.logArgs(context, args)
+			if err != nil {
+				return
+			}
+			tails.list(context, found)
+			for _, set := range found {
+				if tails.follow(s, context, set) {
+					s.notice(context, "+ "+set[len(set)-1]+" (new)")
+				}
+			}
+		})
+	})
+	return s.wait()
+}
+
+// forEachContext calls fn for every context, batchSize at a time, without
+// the progress display and result recording of runAcrossContextsFunc.
+func forEachContext(contexts []string, fn func(context string)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, batchSize)
+	for _, ctx := range contexts {
+		wg.Add(1)
+		go func(context string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			fn(context)
+		}(ctx)
+	}
+	wg.Wait()
+}
+
+// podTails tracks which pods have been followed and which were found by the
+// latest listing of each context. A pod is only followed once, so that the
+// logs of a completed pod are not printed again on every listing.
+type podTails struct {
+	mu       sync.Mutex
+	followed map[string]bool
+	found    map[string]map[string]bool
+}
+
+func newPodTails() *podTails {
+	return &podTails{followed: make(map[string]bool), found: make(map[string]map[string]bool)}
+}
+
+// podKey identifies the pod a logs argument list from podQuery.logArgs
+// targets, in the form namespace/pod/name.
+func podKey(args []string) string {
+	namespaces, rest := extractStringFlag(args, "--namespace")
+	pod := ""
+	for _, arg := range rest {
+		if strings.HasPrefix(arg, "pod/") {
+			pod = arg
+		}
+	}
+	if len(namespaces) == 0 {
+		return pod
+	}
+	return namespaces[len(namespaces)-1] + "/" + pod
+}
+
+// list records the pods a listing of context found.
+func (t *podTails) list(context string, sets [][]string) {
+	found := make(map[string]bool, len(sets))
+	for _, set := range sets {
+		found[podKey(set)] = true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.found[context] = found
+}
+
+// listed reports whether the latest listing of context found the pod.
+func (t *podTails) listed(context string, args []string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.found[context][podKey(args)]
+}
+
+// follow starts following the pod unless it already was, reporting whether
+// it did.
+func (t *podTails) follow(s *streamer, context string, args []string) bool {
+	key := context + " " + podKey(args)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.followed[key] {
+		return false
+	}
+	t.followed[key] = true
+	s.start(context, args)
+	return true
+}
+
+func withLogReconnect(opts streamOptions, reconnect bool) streamOptions {
+	if reconnect {
+		opts.reconnect = func(context string, args []string, lastOutput time.Time) []string {
+			if podFinished(context, args) {
+				return nil
+			}
+			return resumeLogArgs(args, lastOutput)
+		}
+	}
+	return opts
+}
+
+// logValueFlags are kubectl logs flags that take a separate value, which
+// must not be mistaken for the pod.
+var logValueFlags = []string{"-c", "--container", "-n", "--namespace", "-l", "--selector", "--since", "--since-time", "--tail", "--limit-bytes", "--max-log-requests", "--pod-running-timeout"}
+
+// logPod returns the pod that logs args name, as pod/NAME, or "" when they
+// name another kind of resource or use a selector.
+func logPod(args []string) string {
+	_, rest := extractStringFlag(args, logValueFlags...)
+	for _, arg := range rest {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		kind, name, found := strings.Cut(arg, "/")
+		switch {
+		case !found:
+			return "pod/" + arg
+		case kind == "pod" || kind == "pods" || kind == "po":
+			return "pod/" + name
+		}
+		return ""
+	}
+	return ""
+}
+
+// podFinished reports whether the pod that logs args follow has Succeeded
+// or Failed, so that its logs will not grow again.
+func podFinished(context string, args []string) bool {
+	pod := logPod(args)
+	if pod == "" {
+		return false
+	}
+	output, err := runKubectlCommand(context, "get", append([]string{pod, "-o", "jsonpath={.status.phase}"}, namespaceArgs(args)...))
+	phase := strings.TrimSpace(output)
+	return err == nil && (phase == "Succeeded" || phase == "Failed")
+}
+
+// resumeLogArgs replaces the range of logs asked for with everything since
+// the given time, so a reconnected stream picks up where it left off. Lines
+// from that second may be printed twice.
+func resumeLogArgs(args []string, since time.Time) []string {
+	_, args = extractStringFlag(args, "--tail", "--since", "--since-time")
+	return append(args, "--since-time="+since.UTC().Format(time.RFC3339))
+}
+
+// splitLogTimestamp separates the RFC3339 timestamp that kubectl logs
+// --timestamps puts in front of every line from the rest of the line.
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	stamp, rest, _ := strings.Cut(line, " ")
+	at, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return at, rest, true
+}
+
+// logPrefix is what identifies the source of each log line.
+type logPrefix int
+
+const (
+	prefixContext logPrefix = iota
+	prefixPod
+	prefixContainer
+)
+
+// parseLogPrefix extracts --label: pod, or pod,container. It is not named
+// --prefix, which is kubectl's own boolean flag.
+func parseLogPrefix(args []string) (logPrefix, []string, error) {
+	values, args := extractStringFlag(args, "--label")
+	if len(values) == 0 {
+		return prefixContext, args, nil
+	}
+	parts := map[string]bool{}
+	for _, part := range strings.Split(values[len(values)-1], ",") {
+		parts[strings.TrimSpace(part)] = true
+	}
+	switch {
+	case len(parts) == 1 && parts["context"]:
+		return prefixContext, args, nil
+	case len(parts) == 1 && parts["pod"]:
+		return prefixPod, args, nil
+	case len(parts) == 2 && parts["pod"] && parts["container"]:
+		return prefixContainer, args, nil
+	}
+	return prefixContext, nil, fmt.Errorf("invalid --label %q: use pod or pod,container", values[len(values)-1])
+}
+
+// logFormat describes how kubectl-x rewrites log lines before printing them.
+type logFormat struct {
+	prefix logPrefix
+	// merged interleaves the lines of all contexts in timestamp order.
+	// kubectl is asked for --timestamps, which are stripped again unless
+	// showTimestamps is set.
+	merged         bool
+	showTimestamps bool
+	// json filters and reformats structured log lines.
+	json *jsonLogs
+	// raw prints logs exactly as kubectl does, without context prefixes.
+	raw bool
+	// highlight marks matches within lines.
+	highlight *regexp.Regexp
+	// sink, if set, also receives every printed line.
+	sink *logSink
+	// totalTail, if set, keeps only the most recent lines of all contexts
+	// together.
+	totalTail int
+}
+
+// plain reports whether lines are only prefixed with their context.
+func (f logFormat) plain() bool {
+	return f.prefix == prefixContext && !f.merged && f.json == nil && !f.raw && f.highlight == nil && f.sink == nil && f.totalTail == 0
+}
+
+// stamped reports whether kubectl is asked for --timestamps, to order lines
+// across contexts.
+func (f logFormat) stamped() bool {
+	return f.merged || f.totalTail > 0
+}
+
+// totalTailArgs asks each context for no more lines than --total-tail keeps,
+// unless --tail is given or lines are filtered after they are fetched.
+func totalTailArgs(args []string, format logFormat) []string {
+	if tails, _ := extractStringFlag(args, "--tail"); len(tails) > 0 {
+		return args
+	}
+	if format.json != nil || len(grepRegexes) > 0 || len(grepInvertRegexes) > 0 {
+		return args
+	}
+	return append(args, fmt.Sprintf("--tail=%d", format.totalTail))
+}
+
+// newest marks the n most recent of times, or all of them if there are no
+// more than n. Of equal times, the later ones win.
+func newest(times []time.Time, n int) []bool {
+	keep := make([]bool, len(times))
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if !times[a].Equal(times[b]) {
+			return times[a].After(times[b])
+		}
+		return a > b
+	})
+	for _, i := range order[:min(n, len(order))] {
+		keep[i] = true
+	}
+	return keep
+}
+
+// colorHighlight is reverse video, so that matches stand out whatever the
+// colors of the context and the line.
+const colorHighlight = "\033[7m"
+
+// parseHighlight extracts --highlight, combining repeated patterns.
+func parseHighlight(args []string) (*regexp.Regexp, []string, error) {
+	patterns, args := extractStringFlag(args, "--highlight")
+	if len(patterns) == 0 {
+		return nil, args, nil
+	}
+	alternatives := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, nil, fmt.Errorf("invalid --highlight pattern %q: %w", pattern, err)
+		}
+		alternatives[i] = "(?:" + pattern + ")"
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|")), args, nil
+}
+
+// render marks --highlight matches in text when colors are enabled.
+func (f logFormat) render(text string) string {
+	if f.highlight == nil || !colorEnabled() {
+		return text
+	}
+	return f.highlight.ReplaceAllStringFunc(text, func(match string) string {
+		if match == "" {
+			return match
+		}
+		return colorHighlight + match + colorReset
+	})
+}
+
+// kubectlLogPrefix matches what kubectl logs --prefix puts in front of lines.
+var kubectlLogPrefix = regexp.MustCompile(`^\[pod/([^/\]]+)/([^\]]+)\] ?`)
+
+// split returns the label a line is printed under, such as
+// "context/pod[container]", and the rest of the line. When merging it also
+// returns the line's timestamp, if it has one.
+func (f logFormat) split(context, line string) (label, text string, at time.Time, stamped bool) {
+	label, text = context, line
+	if f.prefix != prefixContext {
+		if match := kubectlLogPrefix.FindStringSubmatch(line); match != nil {
+			label += "/" + match[1]
+			if f.prefix == prefixContainer {
+				label += "[" + match[2] + "]"
+			}
+			text = line[len(match[0]):]
+		}
+	}
+	if f.stamped() {
+		var rest string
+		if at, rest, stamped = splitLogTimestamp(text); stamped && !f.showTimestamps {
+			text = rest
+		}
+	}
+	return label, text, at, stamped
+}
+
+// filter applies --json-logs to the text of a line, keeping a timestamp
+// kubectl put in front of it.
+func (f logFormat) filter(text string) (string, bool) {
+	if f.json == nil {
+		return text, true
+	}
+	if _, rest, stamped := splitLogTimestamp(text); stamped {
+		filtered, keep := f.json.filter(rest)
+		return text[:len(text)-len(rest)] + filtered, keep
+	}
+	return f.json.filter(text)
+}
+
+// ship sends a printed line to the --sink, if any. Lines without a
+// timestamp are sent with the time they were read.
+func (f logFormat) ship(context, label, text string, at time.Time) {
+	if f.sink == nil {
+		return
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	pod, container := sourceOf(logSource(context, label))
+	f.sink.send(sinkEntry{At: at, Context: context, Pod: pod, Container: container, Line: text})
+}
+
+// coloredLabel colorizes the context at the start of a label.
+func coloredLabel(context, label string) string {
+	return colorizeContext(context) + strings.TrimPrefix(label, context)
+}
+
+// formatLogs prints the lines of every context under their labels, as one
+// timeline when merging, or in a section per context with
+// --group-by-context. With --total-tail, only the most recent lines of all
+// contexts together are printed. Lines without a timestamp stay behind the line
+// before them in the same context.
+func formatLogs(results []contextResult, format logFormat) error {
+	type labeledLine struct {
+		at      time.Time
+		context string
+		label   string
+		text    string
+	}
+
+	var lines []labeledLine
+	width := 0
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		output := strings.TrimSpace(result.output)
+		if output == "" {
+			continue
+		}
+		var last time.Time
+		for _, line := range strings.Split(output, "\n") {
+			label, text, at, stamped := format.split(result.context, line)
+			if stamped {
+				last = at
+			}
+			text, keep := format.filter(text)
+			if !keep {
+				continue
+			}
+			width = max(width, len(label))
+			lines = append(lines, labeledLine{at: last, context: result.context, label: label, text: text})
+		}
+	}
+
+	if format.merged {
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].at.Before(lines[j].at) })
+	}
+	kept := lines[:0]
+	for _, line := range lines {
+		if keepRow(line.label + fillWidth(line.label, width) + "  " + line.text) {
+			kept = append(kept, line)
+		}
+	}
+	lines = kept
+	if format.totalTail > 0 {
+		times := make([]time.Time, len(lines))
+		for i, line := range lines {
+			times[i] = line.at
+		}
+		keep := newest(times, format.totalTail)
+		kept = lines[:0]
+		for i, line := range lines {
+			if keep[i] {
+				kept = append(kept, line)
+			}
+		}
+		lines = kept
+	}
+
+	grouped := groupByContext && !format.merged
+	sourceWidth := 0
+	for _, line := range lines {
+		sourceWidth = max(sourceWidth, len(logSource(line.context, line.label)))
+	}
+	var sections contextSections
+	current := ""
+	for _, line := range lines {
+		padding := fillWidth(line.label, width)
+		format.ship(line.context, line.label, line.text, line.at)
+		if !grouped {
+			fmt.Printf("%s%s\n", contextPrefix(coloredLabel(line.context, line.label), padding), format.render(line.text))
+			continue
+		}
+		if line.context != current {
+			sections.start(line.context)
+			current = line.context
+		}
+		if source := logSource(line.context, line.label); source != "" {
+			fmt.Printf("%s%s  ", source, fillWidth(source, sourceWidth))
+		}
+		fmt.Println(format.render(line.text))
+	}
+	return nil
+}
+
+// logSource is the part of a label after the context, such as
+// "pod[container]", which still tells lines apart under a context header.
+func logSource(context, label string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(label, context), "/")
+}
+
+// streamFormattedLogs follows logs like streamLogs, labeling each line and,
+// when merging, holding lines for window to print them in timestamp order.
+// Labels are padded to the widest seen so far since pods are not known up
+// front.
+func streamFormattedLogs(contexts []string, args []string, query *podQuery, format logFormat, window time.Duration, reconnect bool, opts streamOptions) error {
+	var merger *eventMerger
+	stop := make(chan struct{})
+	flushed := make(chan struct{})
+	if format.merged {
+		merger = newEventMerger(os.Stdout, window)
+		go func() {
+			merger.run(stop)
+			close(flushed)
+		}()
+	} else {
+		close(flushed)
+	}
+
+	var mu sync.Mutex
+	width := 0
+	opts.handleStdout = func(reader io.Reader, coloredCtx, padding string) {
+		context := stripColor(coloredCtx)
+		last := time.Now()
+		scanner := newLineScanner(reader)
+		for scanner.Scan() {
+			label, text, at, stamped := format.split(context, scanner.Text())
+			if stamped {
+				last = at
+			} else if merger == nil {
+				last = time.Now()
+			}
+			text, keep := format.filter(text)
+			if !keep {
+				continue
+			}
+			mu.Lock()
+			width = max(width, len(context)+len(padding), len(label))
+			labelPadding := fillWidth(label, width)
+			mu.Unlock()
+			if !keepRow(label + labelPadding + "  " + text) {
+				continue
+			}
+			format.ship(context, label, text, last)
+			row := contextPrefix(coloredCtx+strings.TrimPrefix(label, context), labelPadding) + format.render(text)
+			if merger != nil {
+				merger.add(last, row)
+				continue
+			}
+			mu.Lock()
+			fmt.Println(row)
+			mu.Unlock()
+		}
+		finishScan(scanner, reader, coloredCtx)
+	}
+	err := streamLogs(contexts, args, query, reconnect, opts)
+
+	close(stop)
+	<-flushed
+	return err
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsFollowMode(t *testing.T) {
@@ -55,3 +61,310 @@ func TestIsFollowMode(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitLogTimestamp(t *testing.T) {
+	at, text, ok := splitLogTimestamp("2025-01-01T12:00:00.123456789Z started server")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.UTC), at)
+	assert.Equal(t, "started server", text)
+
+	_, text, ok = splitLogTimestamp("    at main.go:12")
+	assert.False(t, ok)
+	assert.Equal(t, "    at main.go:12", text)
+}
+
+func TestFormatLogsMerged(t *testing.T) {
+	results := []contextResult{
+		{context: "east", output: "2025-01-01T12:00:02Z third\n2025-01-01T12:00:00Z first\n"},
+		{context: "west", output: "2025-01-01T12:00:01Z second\n  continued\n2025-01-01T12:00:03Z fourth\n"},
+		{context: "down", err: errors.New("exit status 1")},
+	}
+
+	output := captureStdout(func() {
+		assert.NoError(t, formatLogs(results, logFormat{merged: true}))
+	})
+	assert.Equal(t, "east  first\nwest  second\nwest    continued\neast  third\nwest  fourth\n", output)
+
+	output = captureStdout(func() {
+		assert.NoError(t, formatLogs(results[1:2], logFormat{merged: true, showTimestamps: true}))
+	})
+	assert.Equal(t, "west  2025-01-01T12:00:01Z second\nwest    continued\nwest  2025-01-01T12:00:03Z fourth\n", output)
+}
+
+func TestFormatLogsTotalTail(t *testing.T) {
+	results := []contextResult{
+		{context: "east", output: "2025-01-01T12:00:02Z third\n2025-01-01T12:00:00Z first\n"},
+		{context: "west", output: "2025-01-01T12:00:01Z second\n  continued\n2025-01-01T12:00:03Z fourth\n"},
+	}
+
+	output := captureStdout(func() {
+		assert.NoError(t, formatLogs(results, logFormat{totalTail: 3}))
+	})
+	assert.Equal(t, "east  third\nwest    continued\nwest  fourth\n", output)
+
+	output = captureStdout(func() {
+		assert.NoError(t, formatLogs(results, logFormat{merged: true, totalTail: 2}))
+	})
+	assert.Equal(t, "east  third\nwest  fourth\n", output)
+}
+
+func TestNewest(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{base.Add(2 * time.Second), base, base.Add(time.Second), base.Add(time.Second)}
+	assert.Equal(t, []bool{true, false, false, true}, newest(times, 2))
+	assert.Equal(t, []bool{true, true, true, true}, newest(times, 10))
+	assert.Empty(t, newest(nil, 3))
+}
+
+func TestTotalTailArgs(t *testing.T) {
+	assert.Equal(t, []string{"web", "--tail=50"}, totalTailArgs([]string{"web"}, logFormat{totalTail: 50}))
+	assert.Equal(t, []string{"web", "--tail", "10"}, totalTailArgs([]string{"web", "--tail", "10"}, logFormat{totalTail: 50}))
+	assert.Equal(t, []string{"web"}, totalTailArgs([]string{"web"}, logFormat{totalTail: 50, json: &jsonLogs{}}))
+}
+
+func TestParsePodQuery(t *testing.T) {
+	query, args, err := parsePodQuery([]string{"web-1", "-n", "prod", "--tail=5"})
+	require.NoError(t, err)
+	assert.Nil(t, query)
+	assert.Equal(t, []string{"web-1", "-n", "prod", "--tail=5"}, args)
+
+	query, args, err = parsePodQuery([]string{"--pods", "^web-", "-l", "tier=front", "-A", "-f"})
+	require.NoError(t, err)
+	require.NotNil(t, query)
+	assert.Equal(t, "^web-", query.pattern.String())
+	assert.Equal(t, "tier=front", query.selector)
+	assert.Equal(t, []string{"--all-namespaces"}, query.nsArgs)
+	assert.Equal(t, []string{"-f"}, args)
+
+	query, _, err = parsePodQuery([]string{"--selector=app=web", "-n", "prod"})
+	require.NoError(t, err)
+	assert.Nil(t, query.pattern)
+	assert.Equal(t, []string{"--namespace", "prod"}, query.nsArgs)
+
+	_, _, err = parsePodQuery([]string{"--pods", "("})
+	assert.Error(t, err)
+}
+
+func TestPodQueryLogArgs(t *testing.T) {
+	var listed []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		listed = append([]string{subcommand}, extraArgs...)
+		return "prod   web-1\nprod   worker-1\nstage  web-2\n", nil
+	})
+
+	query := &podQuery{pattern: regexp.MustCompile("^web-"), selector: "app=x", nsArgs: []string{"--all-namespaces"}}
+	sets, err := query.logArgs("ctx1", []string{"--tail=5"})
+	require.NoError(t, err)
+	assert.Contains(t, strings.Join(listed, " "), "--all-namespaces --selector app=x")
+	assert.Equal(t, [][]string{
+		{"--tail=5", "--namespace", "prod", "pod/web-1"},
+		{"--tail=5", "--namespace", "stage", "pod/web-2"},
+	}, sets)
+
+	var nilQuery *podQuery
+	sets, err = nilQuery.logArgs("ctx1", []string{"web-1"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"web-1"}}, sets)
+}
+
+func TestRunLogsConcatenatesPods(t *testing.T) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if subcommand == "get" {
+			return "default  web-1\ndefault  web-2\n", nil
+		}
+		return "from " + extraArgs[len(extraArgs)-1] + "\n", nil
+	})
+
+	var results []contextResult
+	captureStderr(func() {
+		results = runLogs([]string{"ctx1"}, nil, &podQuery{})
+	})
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].err)
+	assert.Equal(t, "from pod/web-1\nfrom pod/web-2\n", results[0].output)
+}
+
+func TestRunLogsKeepsGoingAfterAPodFails(t *testing.T) {
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		switch {
+		case subcommand == "get":
+			return "default  web-1\ndefault  web-2\ndefault  web-3\n", nil
+		case extraArgs[len(extraArgs)-1] == "pod/web-2":
+			return "", errors.New("container is waiting to start")
+		}
+		return "from " + extraArgs[len(extraArgs)-1] + "\n", nil
+	})
+
+	var results []contextResult
+	captureStderr(func() {
+		results = runLogs([]string{"ctx1"}, nil, &podQuery{})
+	})
+	require.Len(t, results, 1)
+	assert.EqualError(t, results[0].err, "pod/web-2: container is waiting to start")
+	assert.Equal(t, "from pod/web-1\nfrom pod/web-3\n", results[0].output)
+}
+
+func TestParseLogPrefix(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected logPrefix
+		wantErr  bool
+	}{
+		{args: []string{"web-1"}, expected: prefixContext},
+		{args: []string{"--label", "pod", "web-1"}, expected: prefixPod},
+		{args: []string{"--label=pod,container"}, expected: prefixContainer},
+		{args: []string{"--label=container,pod"}, expected: prefixContainer},
+		{args: []string{"--label", "container"}, wantErr: true},
+		{args: []string{"--label=true"}, wantErr: true},
+		{args: []string{"deploy/web", "--prefix", "-f"}, expected: prefixContext},
+		{args: []string{"--prefix=true"}, expected: prefixContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			prefix, _, err := parseLogPrefix(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, prefix)
+		})
+	}
+
+	_, args, err := parseLogPrefix([]string{"deploy/web", "--prefix", "-f"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy/web", "--prefix", "-f"}, args, "kubectl's --prefix is passed through")
+}
+
+func TestLogFormatSplit(t *testing.T) {
+	line := "[pod/web-1/app] 2025-01-01T12:00:00Z ready"
+
+	label, text, _, stamped := logFormat{prefix: prefixPod}.split("east", line)
+	assert.Equal(t, "east/web-1", label)
+	assert.Equal(t, "2025-01-01T12:00:00Z ready", text)
+	assert.False(t, stamped)
+
+	label, text, at, stamped := logFormat{prefix: prefixContainer, merged: true}.split("east", line)
+	assert.Equal(t, "east/web-1[app]", label)
+	assert.Equal(t, "ready", text)
+	assert.True(t, stamped)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), at)
+
+	label, text, _, _ = logFormat{prefix: prefixPod}.split("east", "unprefixed")
+	assert.Equal(t, "east", label)
+	assert.Equal(t, "unprefixed", text)
+}
+
+func TestFormatLogsPrefixesPods(t *testing.T) {
+	results := []contextResult{
+		{context: "east", output: "[pod/web-1/app] one\n[pod/web-22/app] two\n"},
+	}
+
+	output := captureStdout(func() {
+		assert.NoError(t, formatLogs(results, logFormat{prefix: prefixContainer}))
+	})
+	assert.Equal(t, "east/web-1[app]   one\neast/web-22[app]  two\n", output)
+}
+
+func TestResumeLogArgs(t *testing.T) {
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	args := resumeLogArgs([]string{"web-1", "-f", "--tail=100", "--since", "1h"}, since)
+	assert.Equal(t, []string{"web-1", "-f", "--since-time=2025-01-01T12:00:00Z"}, args)
+}
+
+func TestLogPod(t *testing.T) {
+	assert.Equal(t, "pod/web-1", logPod([]string{"-c", "app", "web-1", "-f"}))
+	assert.Equal(t, "pod/web-1", logPod([]string{"--namespace", "shop", "pod/web-1"}))
+	assert.Equal(t, "", logPod([]string{"deploy/web", "-f"}))
+	assert.Equal(t, "", logPod([]string{"-l", "app=web", "-f"}))
+}
+
+func TestPodFinished(t *testing.T) {
+	phases := map[string]string{"prod": "Succeeded", "staging": "Running"}
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"pod/job-1", "-o", "jsonpath={.status.phase}", "--namespace", "batch"}, extraArgs)
+		return phases[context], nil
+	})
+	assert.True(t, podFinished("prod", []string{"job-1", "-n", "batch", "-f"}))
+	assert.False(t, podFinished("staging", []string{"job-1", "-n", "batch", "-f"}))
+	assert.False(t, podFinished("prod", []string{"deploy/web", "-f"}))
+}
+
+func TestLogFormatFilterKeepsTimestamp(t *testing.T) {
+	format := logFormat{json: &jsonLogs{fields: []string{"msg"}}}
+	text, keep := format.filter(`2025-01-01T12:00:00Z {"msg":"ready"}`)
+	assert.True(t, keep)
+	assert.Equal(t, "2025-01-01T12:00:00Z msg=ready", text)
+}
+
+func TestParseHighlight(t *testing.T) {
+	pattern, args, err := parseHighlight([]string{"-f", "--highlight", "ERROR", "--highlight=req-[0-9]+"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-f"}, args)
+	assert.True(t, pattern.MatchString("req-42"))
+	assert.True(t, pattern.MatchString("ERROR"))
+
+	pattern, _, err = parseHighlight([]string{"-f"})
+	require.NoError(t, err)
+	assert.Nil(t, pattern)
+
+	_, _, err = parseHighlight([]string{"--highlight", "("})
+	assert.Error(t, err)
+}
+
+func TestLogFormatRender(t *testing.T) {
+	old := colorMode
+	t.Cleanup(func() { colorMode = old })
+	format := logFormat{highlight: regexp.MustCompile("ERROR")}
+
+	colorMode = "always"
+	assert.Equal(t, "level "+colorHighlight+"ERROR"+colorReset+" failed", format.render("level ERROR failed"))
+
+	colorMode = "never"
+	assert.Equal(t, "level ERROR failed", format.render("level ERROR failed"))
+}
+
+func TestFormatLogsGroupByContext(t *testing.T) {
+	groupByContext = true
+	t.Cleanup(func() { groupByContext = false })
+
+	results := []contextResult{
+		{context: "east", output: "[pod/web-1/app] one\n[pod/web-22/app] two\n"},
+		{context: "west", output: "[pod/web-3/app] three\n"},
+	}
+
+	output := captureStdout(func() {
+		assert.NoError(t, formatLogs(results, logFormat{prefix: prefixPod}))
+	})
+	assert.Equal(t, "=== east ===\nweb-1   one\nweb-22  two\n\n=== west ===\nweb-3   three\n", output)
+}
+
+func TestPodKey(t *testing.T) {
+	assert.Equal(t, "prod/pod/web-1", podKey([]string{"-f", "--namespace", "prod", "pod/web-1", "--since-time=2025-01-01T12:00:00Z"}))
+	assert.Equal(t, "pod/web-1", podKey([]string{"pod/web-1"}))
+}
+
+func TestPodTailsListed(t *testing.T) {
+	tails := newPodTails()
+	web1 := []string{"-f", "--namespace", "prod", "pod/web-1"}
+	web2 := []string{"-f", "--namespace", "prod", "pod/web-2"}
+
+	tails.list("ctx1", [][]string{web1, web2})
+	assert.True(t, tails.listed("ctx1", append(web1, "--since-time=2025-01-01T12:00:00Z")))
+	assert.False(t, tails.listed("ctx2", web1))
+
+	tails.list("ctx1", [][]string{web2})
+	assert.False(t, tails.listed("ctx1", web1))
+}
+
+func TestForEachContext(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	forEachContext([]string{"ctx1", "ctx2", "ctx3"}, func(context string) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, context)
+	})
+	assert.ElementsMatch(t, []string{"ctx1", "ctx2", "ctx3"}, seen)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var ignoreMissingTypes bool
+
+// missingTypeErrors match kubectl's errors for a resource type, such as a
+// CRD, or a namespace that a cluster does not have.
+var missingTypeErrors = []*regexp.Regexp{
+	regexp.MustCompile(`the server doesn't have a resource type "[^"]*"`),
+	regexp.MustCompile(`no matches for kind "[^"]*" in version "[^"]*"`),
+	regexp.MustCompile(`namespaces "[^"]*" not found`),
+}
+
+// missingType returns the reason kubectl gave when every line of its output
+// is about a missing resource type or namespace. Any other error means the
+// context really failed.
+func missingType(output string) (string, bool) {
+	reason := ""
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		matched := false
+		for _, pattern := range missingTypeErrors {
+			if match := pattern.FindString(line); match != "" {
+				matched = true
+				if reason == "" {
+					reason = match
+				}
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+	}
+	return reason, reason != ""
+}
+
+// emptyMissingTypes turns the failures of contexts that lack the requested
+// resource type or namespace into empty output, noting each on stderr, so
+// that they neither print errors nor count as failed.
+func emptyMissingTypes(results []contextResult) []contextResult {
+	for i, result := range results {
+		if result.err == nil {
+			continue
+		}
+		if reason, ok := missingType(result.output); ok {
+			fmt.Fprintf(os.Stderr, "Note: context %s: %s; treated as empty\n", colorizeContext(result.context), reason)
+			results[i] = contextResult{context: result.context, duration: result.duration}
+		}
+	}
+	return results
+}
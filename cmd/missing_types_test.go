@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingType(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		reason string
+		ok     bool
+	}{
+		{
+			name:   "missing CRD",
+			output: "error: the server doesn't have a resource type \"certificates\"\n",
+			reason: `the server doesn't have a resource type "certificates"`,
+			ok:     true,
+		},
+		{
+			name:   "missing kind",
+			output: "error: resource mapping not found for name: \"web\" namespace: \"\" from \"web.yaml\": no matches for kind \"Rollout\" in version \"argoproj.io/v1alpha1\"\n",
+			reason: `no matches for kind "Rollout" in version "argoproj.io/v1alpha1"`,
+			ok:     true,
+		},
+		{
+			name:   "missing namespace",
+			output: "Error from server (NotFound): namespaces \"payments\" not found\n",
+			reason: `namespaces "payments" not found`,
+			ok:     true,
+		},
+		{
+			name:   "other error",
+			output: "error: You must be logged in to the server (Unauthorized)\n",
+		},
+		{
+			name:   "mixed errors",
+			output: "error: the server doesn't have a resource type \"certificates\"\nUnable to connect to the server: dial tcp: i/o timeout\n",
+		},
+		{
+			name: "no output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := missingType(tt.output)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestEmptyMissingTypes(t *testing.T) {
+	var results []contextResult
+	stderr := captureStderr(func() {
+		results = emptyMissingTypes([]contextResult{
+			{context: "prod", output: "NAME\nweb"},
+			{context: "edge", output: "error: the server doesn't have a resource type \"certificates\"", err: fmt.Errorf("exit status 1"), duration: time.Second},
+			{context: "down", output: "Unable to connect to the server", err: fmt.Errorf("exit status 1")},
+		})
+	})
+
+	assert.Equal(t, contextResult{context: "prod", output: "NAME\nweb"}, results[0])
+	assert.Equal(t, contextResult{context: "edge", duration: time.Second}, results[1])
+	assert.Error(t, results[2].err)
+	assert.Equal(t, "Note: context edge: the server doesn't have a resource type \"certificates\"; treated as empty\n", stderr)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// namespacedSubcommands are the subcommands whose results depend on the
+// namespace kubectl resolves for each context.
+var namespacedSubcommands = map[string]bool{
+	"get": true, "logs": true, "wait": true, "events": true, "rollout": true, "diff": true,
+	"apply": true, "delete": true, "scale": true, "attach": true, "top": true,
+}
+
+var clusterScopedKinds = map[string]bool{
+	"node": true, "nodes": true, "no": true,
+	"namespace": true, "namespaces": true, "ns": true,
+	"persistentvolume": true, "persistentvolumes": true, "pv": true,
+	"storageclass": true, "storageclasses": true, "sc": true,
+	"clusterrole": true, "clusterroles": true,
+	"clusterrolebinding": true, "clusterrolebindings": true,
+	"customresourcedefinition": true, "customresourcedefinitions": true, "crd": true, "crds": true,
+}
+
+func hasNamespaceFlag(args []string) bool {
+	if all, _ := extractBoolFlag(args, "-A", "--all-namespaces"); all {
+		return true
+	}
+	namespaces, _ := extractStringFlag(args, "-n", "--namespace")
+	return len(namespaces) > 0
+}
+
+// contextNamespaces returns the default namespace each context is configured
+// with, treating an unset namespace as "default".
+func contextNamespaces(contexts []string) (map[string]string, error) {
+	entries, err := loadContextEntries()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]string, len(contexts))
+	for _, ctx := range contexts {
+		namespace := entries[ctx].Context.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		namespaces[ctx] = namespace
+	}
+	return namespaces, nil
+}
+
+// warnNamespaceSkew tells the user, on stderr, which namespace each context
+// resolved to when they differ and nothing pinned the namespace.
+func warnNamespaceSkew(contexts []string, subcommand string, args []string) {
+	if !namespacedSubcommands[subcommand] || forcedNamespace != "" || preserveNamespace || hasNamespaceFlag(args) {
+		return
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			if clusterScopedKinds[strings.SplitN(arg, "/", 2)[0]] {
+				return
+			}
+			break
+		}
+	}
+
+	namespaces, err := contextNamespaces(contexts)
+	if err != nil {
+		return
+	}
+	distinct := make(map[string]bool)
+	for _, namespace := range namespaces {
+		distinct[namespace] = true
+	}
+	if len(distinct) < 2 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "Note: contexts have different default namespaces (use --namespace to force one, or --preserve-namespace to hide this note):")
+	var rows [][]string
+	for _, ctx := range contexts {
+		rows = append(rows, []string{ctx, namespaces[ctx]})
+	}
+	printContextTable(os.Stderr, []string{"CONTEXT", "NAMESPACE"}, rows)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const namespacedKubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- name: prod-us
+  context:
+    cluster: prod-us
+    namespace: payments
+- name: prod-eu
+  context:
+    cluster: prod-eu
+`
+
+func writeNamespacedKubeconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte(namespacedKubeconfig), 0o600))
+	return path
+}
+
+func TestHasNamespaceFlag(t *testing.T) {
+	assert.True(t, hasNamespaceFlag([]string{"pods", "-n", "web"}))
+	assert.True(t, hasNamespaceFlag([]string{"pods", "--namespace=web"}))
+	assert.True(t, hasNamespaceFlag([]string{"pods", "-A"}))
+	assert.False(t, hasNamespaceFlag([]string{"pods", "-l", "app=web"}))
+}
+
+func TestContextNamespaces(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeNamespacedKubeconfig(t))
+	namespaces, err := contextNamespaces([]string{"prod-us", "prod-eu"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"prod-us": "payments", "prod-eu": "default"}, namespaces)
+}
+
+func TestWarnNamespaceSkew(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeNamespacedKubeconfig(t))
+	contexts := []string{"prod-us", "prod-eu"}
+
+	output := captureStderr(func() { warnNamespaceSkew(contexts, "get", []string{"pods"}) })
+	assert.Contains(t, output, "different default namespaces")
+	assert.Regexp(t, `prod-us\s+payments`, output)
+	assert.Regexp(t, `prod-eu\s+default`, output)
+
+	assert.Empty(t, captureStderr(func() { warnNamespaceSkew(contexts, "get", []string{"pods", "-n", "web"}) }))
+	assert.Empty(t, captureStderr(func() { warnNamespaceSkew(contexts, "get", []string{"nodes"}) }))
+	assert.Empty(t, captureStderr(func() { warnNamespaceSkew(contexts, "version", nil) }))
+
+	preserveNamespace = true
+	assert.Empty(t, captureStderr(func() { warnNamespaceSkew(contexts, "get", []string{"pods"}) }))
+	preserveNamespace = false
+
+	forcedNamespace = "web"
+	t.Cleanup(func() { forcedNamespace = "" })
+	assert.Empty(t, captureStderr(func() { warnNamespaceSkew(contexts, "get", []string{"pods"}) }))
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var cordonCmd = &cobra.Command{
+	Use:                "cordon",
+	Short:              "Run kubectl cordon against all contexts",
+	Long:               `Mark nodes (by name or --selector) unschedulable in every context after confirmation (skip with --yes).`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfirmedCommand("cordon", args)
+	},
+}
+
+var uncordonCmd = &cobra.Command{
+	Use:                "uncordon",
+	Short:              "Run kubectl uncordon against all contexts",
+	Long:               `Mark nodes (by name or --selector) schedulable in every context after confirmation (skip with --yes).`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfirmedCommand("uncordon", args)
+	},
+}
+
+var drainCmd = &cobra.Command{
+	Use:                "drain",
+	Short:              "Run kubectl drain against all contexts",
+	Long:               `Drain nodes (by name or --selector) in every context after confirmation (skip with --yes). Eviction progress is streamed per context, followed by a status table. --canary drains a subset of contexts first.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDrain(args)
+	},
+}
+
+func runDrain(args []string) error {
+	yes, args := extractBoolFlag(args, "--yes", "-y")
+	canary, args := extractCanary(args)
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	description := strings.TrimSpace("kubectl drain " + strings.Join(args, " "))
+	if !confirmContexts(description, contexts, yes) {
+		return fmt.Errorf("aborted")
+	}
+
+	results, canaryErr := runWithCanary(contexts, canary, yes, func(contexts []string) []contextResult {
+		return runStreamingAcrossContexts(contexts, "drain", args)
+	})
+	if results == nil {
+		return canaryErr
+	}
+	if err := formatSummaryOutput(results); canaryErr == nil {
+		return err
+	}
+	return canaryErr
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenanceCmds(t *testing.T) {
+	for name, cmd := range map[string]interface{ Name() string }{
+		"cordon":   cordonCmd,
+		"uncordon": uncordonCmd,
+		"drain":    drainCmd,
+	} {
+		require.NotNil(t, cmd)
+		assert.Equal(t, name, cmd.Name())
+	}
+	assert.True(t, cordonCmd.DisableFlagParsing)
+	assert.True(t, uncordonCmd.DisableFlagParsing)
+	assert.True(t, drainCmd.DisableFlagParsing)
+}
+
+func TestRunDrain(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	old := runKubectlCommandStreaming
+	runKubectlCommandStreaming = func(context, subcommand string, extraArgs []string, onLine func(string)) (string, error) {
+		mu.Lock()
+		calls = append(calls, context+" "+subcommand+" "+strings.Join(extraArgs, " "))
+		mu.Unlock()
+		onLine("evicting pod default/web-1")
+		onLine("node/node-1 drained")
+		return "evicting pod default/web-1\nnode/node-1 drained\n", nil
+	}
+	t.Cleanup(func() { runKubectlCommandStreaming = old })
+
+	var err error
+	var output, stderr string
+	stderr = captureStderr(func() {
+		output = captureStdout(func() {
+			err = runDrain([]string{"node-1", "--ignore-daemonsets", "-y"})
+		})
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ctx1 drain node-1 --ignore-daemonsets", "ctx2 drain node-1 --ignore-daemonsets"}, calls)
+	assert.Contains(t, stderr, "ctx1  evicting pod default/web-1")
+	assert.Equal(t, "CONTEXT  RESULT    MESSAGE\nctx1     OK        node/node-1 drained\nctx2     OK        node/node-1 drained\n", output)
+}
+
+func TestRunDrainCanaryStopsOnFailure(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	old := runKubectlCommandStreaming
+	runKubectlCommandStreaming = func(context, subcommand string, extraArgs []string, onLine func(string)) (string, error) {
+		mu.Lock()
+		calls = append(calls, context+" "+strings.Join(extraArgs, " "))
+		mu.Unlock()
+		return "error: cannot evict pod default/web-1\n", fmt.Errorf("exit status 1")
+	}
+	t.Cleanup(func() { runKubectlCommandStreaming = old })
+
+	var err error
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			err = runDrain([]string{"node-1", "--canary", "1", "-y"})
+		})
+	})
+	assert.EqualError(t, err, "canary failed in 1 of 1 contexts; 1 remaining contexts were not changed")
+	assert.Equal(t, []string{"ctx1 node-1"}, calls)
+	assert.Contains(t, output, "ctx1     ERROR")
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const nodeInfoJSONPath = `jsonpath={range .items[*]}{.status.nodeInfo.kubeletVersion}{"\t"}{.status.nodeInfo.osImage}{"\t"}{.status.nodeInfo.containerRuntimeVersion}{"\n"}{end}`
+
+var nodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "List nodes across all contexts, or report node version skew",
+	Long: `Run kubectl get nodes against all contexts in parallel.
+
+With --versions, aggregate kubelet versions, OS images, and container runtimes per context with node counts, flagging contexts whose kubelets lag behind the newest version in the fleet.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		versions, args := extractBoolFlag(args, "--versions")
+		if versions {
+			return runNodeVersions(args)
+		}
+		return runCommand("get", append([]string{"nodes"}, args...))
+	},
+}
+
+var kubeletVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+
+type kubeletVersion struct {
+	major, minor, patch int
+}
+
+func parseKubeletVersion(version string) (kubeletVersion, bool) {
+	match := kubeletVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return kubeletVersion{}, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	return kubeletVersion{major, minor, patch}, true
+}
+
+func (v kubeletVersion) less(other kubeletVersion) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+// skew describes how far v lags behind newest, or "" when it doesn't.
+func (v kubeletVersion) skew(newest kubeletVersion) string {
+	switch {
+	case !v.less(newest):
+		return ""
+	case v.major != newest.major:
+		return fmt.Sprintf("%d major behind", newest.major-v.major)
+	case v.minor != newest.minor:
+		return fmt.Sprintf("%d minor behind", newest.minor-v.minor)
+	default:
+		return "patch behind"
+	}
+}
+
+type nodeVersionGroup struct {
+	context string
+	kubelet string
+	os      string
+	runtime string
+	count   int
+}
+
+// groupNodeVersions collapses identical kubelet/OS/runtime combinations
+// within each context into one group with a node count.
+func groupNodeVersions(results []contextResult) []nodeVersionGroup {
+	var groups []nodeVersionGroup
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		index := make(map[string]int)
+		for _, line := range strings.Split(result.output, "\n") {
+			fields := strings.Split(strings.TrimSpace(line), "\t")
+			if len(fields) != 3 {
+				continue
+			}
+			key := strings.Join(fields, "\t")
+			if i, ok := index[key]; ok {
+				groups[i].count++
+				continue
+			}
+			index[key] = len(groups)
+			groups = append(groups, nodeVersionGroup{
+				context: result.context,
+				kubelet: fields[0],
+				os:      fields[1],
+				runtime: fields[2],
+				count:   1,
+			})
+		}
+	}
+	return groups
+}
+
+func runNodeVersions(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	getArgs := append([]string{"nodes", "-o", nodeInfoJSONPath}, args...)
+	results := runAcrossContexts(contexts, "get", getArgs)
+
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+		}
+	}
+
+	groups := groupNodeVersions(results)
+	var newest kubeletVersion
+	for _, group := range groups {
+		if v, ok := parseKubeletVersion(group.kubelet); ok && newest.less(v) {
+			newest = v
+		}
+	}
+
+	var rows [][]string
+	for _, group := range groups {
+		skew := ""
+		if v, ok := parseKubeletVersion(group.kubelet); ok {
+			skew = v.skew(newest)
+		}
+		rows = append(rows, []string{group.context, group.kubelet, group.os, group.runtime, strconv.Itoa(group.count), skew})
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "KUBELET", "OS", "RUNTIME", "NODES", "SKEW"}, rows)
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesCmd(t *testing.T) {
+	require.NotNil(t, nodesCmd)
+	assert.Equal(t, "nodes", nodesCmd.Use)
+	assert.True(t, nodesCmd.DisableFlagParsing)
+}
+
+func TestNodesCmdPassesThroughToGet(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1"})
+	t.Setenv("KUBECONFIG", path)
+	var gotSubcommand string
+	var gotArgs []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		gotSubcommand = subcommand
+		gotArgs = extraArgs
+		return "NAME    STATUS\nnode-1  Ready\n", nil
+	})
+
+	captureStdout(func() {
+		require.NoError(t, nodesCmd.RunE(nodesCmd, []string{"-l", "pool=a"}))
+	})
+	assert.Equal(t, "get", gotSubcommand)
+	assert.Equal(t, []string{"nodes", "-l", "pool=a"}, gotArgs)
+}
+
+func TestKubeletVersionSkew(t *testing.T) {
+	newest, ok := parseKubeletVersion("v1.29.4-eks-1234")
+	require.True(t, ok)
+	assert.Equal(t, kubeletVersion{1, 29, 4}, newest)
+
+	tests := map[string]string{
+		"v1.29.4": "",
+		"v1.29.1": "patch behind",
+		"v1.27.9": "2 minor behind",
+		"v0.9.0":  "1 major behind",
+	}
+	for version, want := range tests {
+		v, ok := parseKubeletVersion(version)
+		require.True(t, ok)
+		assert.Equal(t, want, v.skew(newest), version)
+	}
+
+	_, ok = parseKubeletVersion("unknown")
+	assert.False(t, ok)
+}
+
+func TestGroupNodeVersions(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "v1.29.4\tUbuntu 22.04.3 LTS\tcontainerd://1.7.2\nv1.29.4\tUbuntu 22.04.3 LTS\tcontainerd://1.7.2\nv1.28.8\tUbuntu 22.04.3 LTS\tcontainerd://1.7.2\n"},
+		{context: "ctx2", err: fmt.Errorf("exit status 1")},
+	}
+
+	groups := groupNodeVersions(results)
+	assert.Equal(t, []nodeVersionGroup{
+		{context: "ctx1", kubelet: "v1.29.4", os: "Ubuntu 22.04.3 LTS", runtime: "containerd://1.7.2", count: 2},
+		{context: "ctx1", kubelet: "v1.28.8", os: "Ubuntu 22.04.3 LTS", runtime: "containerd://1.7.2", count: 1},
+	}, groups)
+}
+
+func TestRunNodeVersions(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"nodes", "-o", nodeInfoJSONPath}, extraArgs)
+		if context == "ctx1" {
+			return "v1.29.4\tBottlerocket OS 1.19.0\tcontainerd://1.6.28\n", nil
+		}
+		return "v1.28.8\tBottlerocket OS 1.19.0\tcontainerd://1.6.28\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, nodesCmd.RunE(nodesCmd, []string{"--versions"}))
+	})
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^CONTEXT\s+KUBELET\s+OS\s+RUNTIME\s+NODES\s+SKEW$`, lines[0])
+	assert.Regexp(t, `^ctx1\s+v1\.29\.4\s+Bottlerocket OS 1\.19\.0\s+containerd://1\.6\.28\s+1$`, lines[1])
+	assert.Regexp(t, `^ctx2\s+v1\.28\.8\s+.*\s+1\s+1 minor behind$`, lines[2])
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+var onlyErrors bool
+
+// formatOnlyErrors prints only the failed contexts, as --only-errors asks.
+func formatOnlyErrors(results []contextResult) error {
+	return formatRawOutput(failedAsOutput(results))
+}
+
+// rejectOnlyErrors fails commands that stream their output as it arrives,
+// since they cannot know which contexts failed until they are stopped.
+func rejectOnlyErrors(command string) error {
+	if onlyErrors {
+		return fmt.Errorf("--only-errors cannot be used with %s, whose output is streamed as it arrives", command)
+	}
+	return nil
+}
+
+// failedAsOutput keeps the failed contexts and turns each error, followed by
+// kubectl's own output, into regular output so that it is printed on stdout
+// with the usual context prefixes.
+func failedAsOutput(results []contextResult) []contextResult {
+	var failed []contextResult
+	for _, result := range results {
+		if result.err == nil {
+			continue
+		}
+		output := fmt.Sprintf("Error: %v", result.err)
+		if text := strings.TrimSpace(result.output); text != "" {
+			output += "\n" + text
+		}
+		failed = append(failed, contextResult{context: result.context, output: output, duration: result.duration})
+	}
+	return failed
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailedAsOutput(t *testing.T) {
+	failed := failedAsOutput([]contextResult{
+		{context: "ok", output: "NAME\npod1"},
+		{context: "down", err: fmt.Errorf("dial tcp: i/o timeout")},
+		{context: "denied", output: "error: Unauthorized\n", err: fmt.Errorf("exit status 1")},
+	})
+	require.Len(t, failed, 2)
+	assert.Equal(t, contextResult{context: "down", output: "Error: dial tcp: i/o timeout"}, failed[0])
+	assert.Equal(t, contextResult{context: "denied", output: "Error: exit status 1\nerror: Unauthorized"}, failed[1])
+}
+
+func TestFormatOutputOnlyErrors(t *testing.T) {
+	onlyErrors = true
+	t.Cleanup(func() { onlyErrors = false })
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, formatOutput([]contextResult{
+				{context: "ctx1", output: "NAME    STATUS\npod1    Running"},
+				{context: "ctx2", output: "error: Unauthorized", err: fmt.Errorf("exit status 1")},
+			}, formatDefault, "get"))
+		})
+	})
+	assert.Empty(t, stderr)
+	assert.Equal(t, "ctx2  Error: exit status 1\nctx2  error: Unauthorized\n", output)
+}
+
+func TestRunConfirmedCommandOnlyErrors(t *testing.T) {
+	onlyErrors = true
+	t.Cleanup(func() { onlyErrors = false })
+	kubeconfig := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", kubeconfig)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if context == "ctx2" {
+			return "error: deployments.apps \"web\" not found", fmt.Errorf("exit status 1")
+		}
+		return "deployment.apps/web scaled", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runConfirmedCommand("scale", []string{"deploy/web", "--replicas=2", "--yes"}))
+	})
+	assert.Equal(t, "ctx2  Error: exit status 1\nctx2  error: deployments.apps \"web\" not found\n", output)
+}
+
+func TestOnlyErrorsSummaryCommands(t *testing.T) {
+	onlyErrors = true
+	t.Cleanup(func() { onlyErrors = false })
+	results := []contextResult{
+		{context: "ctx1", output: "3\n"},
+		{context: "ctx2", output: "error: Unauthorized", err: fmt.Errorf("exit status 1")},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatCountOutput(results))
+	})
+	assert.Equal(t, "ctx2  Error: exit status 1\nctx2  error: Unauthorized\n", output)
+
+	output = captureStdout(func() {
+		assert.Error(t, formatSummaryOutput(results))
+	})
+	assert.Equal(t, "CONTEXT  RESULT    MESSAGE\nctx2     ERROR     error: Unauthorized\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatSummaryOutput(results[:1]))
+	})
+	assert.Empty(t, output)
+}
+
+func TestOnlyErrorsRejectedWhenStreaming(t *testing.T) {
+	onlyErrors = true
+	t.Cleanup(func() { onlyErrors = false })
+
+	err := streamAcrossContexts([]string{"ctx1"}, "events", func(int, string) []string { return nil }, streamOptions{})
+	assert.EqualError(t, err, "--only-errors cannot be used with events, whose output is streamed as it arrives")
+	assert.EqualError(t, runLogsCommand([]string{"web", "-f"}, nil, logFormat{}, logFollow{}),
+		"--only-errors cannot be used with logs -f, whose output is streamed as it arrives")
+}
+
+func TestOnlyErrorsMutatingTables(t *testing.T) {
+	onlyErrors = true
+	t.Cleanup(func() { onlyErrors = false })
+
+	output := captureStdout(func() {
+		captureStderr(func() {
+			assert.Error(t, formatDeleteOutput([]contextResult{
+				{context: "ctx1", output: "pod \"web\" deleted\n"},
+				{context: "ctx2", output: "error: Unauthorized", err: fmt.Errorf("exit status 1")},
+			}))
+		})
+	})
+	assert.Equal(t, "CONTEXT  DELETED    NOT-FOUND    RESULT\nctx2     0          0            ERROR\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatCanIOutput([]contextResult{{context: "ctx1", output: "yes\n"}}))
+	})
+	assert.Empty(t, output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatScaleOutput(
+			[]contextResult{{context: "ctx1", output: "web   2\n"}},
+			[]contextResult{{context: "ctx1", output: "deployment.apps/web scaled"}},
+			[]contextResult{{context: "ctx1", output: "web   3\n"}},
+		))
+	})
+	assert.Empty(t, output)
+}
@@ -3,10 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"hash/fnv"
+	"io"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
@@ -19,6 +22,10 @@ const (
 	formatJSON    outputFormat = "json"
 	formatYAML    outputFormat = "yaml"
 	formatRaw     outputFormat = "raw"
+	formatWide    outputFormat = "wide"
+	// formatCustomColumns covers -o custom-columns and custom-columns-file.
+	formatCustomColumns outputFormat = "custom-columns"
+	formatName          outputFormat = "name"
 )
 
 const (
@@ -49,22 +56,46 @@ var contextColors = []string{
 	"\033[36m", // Cyan
 }
 
+// colorMode is "auto", "always", or "never". In auto mode contexts are only
+// colorized when stdout is a terminal and NO_COLOR is not set.
+var colorMode = "auto"
+
 func isTerminal() bool {
-	return term.IsTerminal(int(os.Stdout.Fd()))
+	return term.IsTerminal(int(terminalStdout().Fd()))
 }
 
-// getContextColor returns a consistent color for a given context name
-func getContextColor(context string) string {
-	if !isTerminal() {
-		return "" // No colors when piping to files
+// colorEnabled reports whether output is colorized. NO_COLOR
+// (https://no-color.org) only affects auto mode, so --color=always still
+// forces color, e.g. into less -R.
+func colorEnabled() bool {
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
 	}
+	return os.Getenv("NO_COLOR") == "" && isTerminal() && ansiStdout
+}
 
-	// Use hash of context name to consistently assign colors
-	hash := fnv.New32a()
-	hash.Write([]byte(context))
-	hashValue := hash.Sum32()
+// ansiStdout and ansiStderr record whether the console interprets escape
+// sequences; only old Windows consoles do not.
+var ansiStdout, ansiStderr = true, true
 
-	return contextColors[hashValue%uint32(len(contextColors))]
+// enableANSI prepares the console for colors and the progress bar.
+func enableANSI() {
+	ansiStdout = enableVirtualTerminal(os.Stdout)
+	ansiStderr = enableVirtualTerminal(os.Stderr)
+}
+
+// getContextColor returns a consistent color for a given context name
+func getContextColor(context string) string {
+	if !colorEnabled() {
+		return ""
+	}
+	if code, ok := configuredColor(context); ok {
+		return code
+	}
+	return hashedColor(context)
 }
 
 func colorizeContext(context string) string {
@@ -75,6 +106,15 @@ func colorizeContext(context string) string {
 	return color + context + colorReset
 }
 
+// contextPrefix is what precedes each line of a context's output: the
+// context padded to the column width, or nothing with --hide-context.
+func contextPrefix(coloredContext, padding string) string {
+	if hideContext {
+		return ""
+	}
+	return coloredContext + padding + "  "
+}
+
 func detectOutputFormat(args []string) outputFormat {
 	parseFormat := func(format string) outputFormat {
 		format = strings.ToLower(format)
@@ -84,16 +124,22 @@ func detectOutputFormat(args []string) outputFormat {
 		if format == "yaml" {
 			return formatYAML
 		}
-		if format == "name" ||
-			strings.HasPrefix(format, "jsonpath=") ||
+		if format == "wide" {
+			return formatWide
+		}
+		if format == "name" {
+			return formatName
+		}
+		if strings.HasPrefix(format, "jsonpath=") ||
 			strings.HasPrefix(format, "jsonpath-as-json=") ||
 			strings.HasPrefix(format, "jsonpath-file=") ||
 			strings.HasPrefix(format, "go-template=") ||
-			strings.HasPrefix(format, "go-template-file=") ||
-			strings.HasPrefix(format, "custom-columns=") ||
-			strings.HasPrefix(format, "custom-columns-file=") {
+			strings.HasPrefix(format, "go-template-file=") {
 			return formatRaw
 		}
+		if strings.HasPrefix(format, "custom-columns=") || strings.HasPrefix(format, "custom-columns-file=") {
+			return formatCustomColumns
+		}
 		return formatDefault
 	}
 
@@ -125,6 +171,12 @@ func detectOutputFormat(args []string) outputFormat {
 }
 
 func formatOutput(results []contextResult, format outputFormat, subcommand string) error {
+	if onlyErrors {
+		return formatOnlyErrors(results)
+	}
+	if showTimings && !isTableOutput(format, subcommand) {
+		defer printTimingSummary(os.Stderr, results)
+	}
 	switch format {
 	case formatJSON:
 		return formatJSONOutput(results, subcommand)
@@ -132,6 +184,10 @@ func formatOutput(results []contextResult, format outputFormat, subcommand strin
 		return formatYAMLOutput(results, subcommand)
 	case formatRaw:
 		return formatRawOutput(results)
+	case formatName:
+		return formatNameOutput(results)
+	case formatWide, formatCustomColumns:
+		return formatTableOutput(results, true)
 	default:
 		if subcommand == "version" {
 			return formatVersionOutput(results)
@@ -144,8 +200,105 @@ func formatOutput(results []contextResult, format outputFormat, subcommand strin
 }
 
 func formatDefaultOutput(results []contextResult) error {
-	// kubectl output uses multiple spaces to separate columns
-	columnSeparator := regexp.MustCompile(`[ \t]{2,}`)
+	return formatTableOutput(results, false)
+}
+
+// kubectl output uses multiple spaces to separate columns
+var columnSeparator = regexp.MustCompile(`[ \t]{2,}`)
+
+// headerColumnStarts returns the offset at which each header column begins.
+func headerColumnStarts(header string) []int {
+	starts := []int{0}
+	for _, match := range columnSeparator.FindAllStringIndex(strings.TrimRight(header, " \t"), -1) {
+		starts = append(starts, match[1])
+	}
+	return starts
+}
+
+// splitAtColumnStarts cuts a line at the header's column offsets. kubectl
+// left-aligns every cell under its header, so this keeps empty cells in
+// place where splitting on whitespace would shift the cells after them, and
+// values with runs of spaces in one piece. Offsets count runes, as kubectl's
+// alignment does.
+func splitAtColumnStarts(line string, starts []int) []string {
+	runes := []rune(line)
+	columns := make([]string, len(starts))
+	for i, start := range starts {
+		if start >= len(runes) {
+			break
+		}
+		end := len(runes)
+		if i+1 < len(starts) && starts[i+1] < end {
+			end = starts[i+1]
+		}
+		columns[i] = strings.TrimSpace(string(runes[start:end]))
+	}
+	return columns
+}
+
+// alignedToHeader reports whether every line has a column gap of at least
+// two spaces before each of the header's column offsets it reaches, as
+// kubectl's own tables do. Tables that are not aligned, such as those of
+// kubectl plugins, are split on whitespace instead.
+func alignedToHeader(lines []string, starts []int) bool {
+	for _, line := range lines {
+		runes := []rune(strings.TrimRight(line, " \t"))
+		for _, start := range starts[1:] {
+			if start >= len(runes) {
+				break
+			}
+			if start < 2 || !unicode.IsSpace(runes[start-1]) || !unicode.IsSpace(runes[start-2]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// unionHeader merges the headers of contexts whose tables differ, such as
+// with -A where a resource type is namespaced in one cluster but not in
+// another, or where a newer kubectl or CRD version adds columns. A column
+// one context lacks goes after the column it follows in the context that has
+// it, so NAMESPACE stays in front of NAME.
+func unionHeader(headers [][]string) []string {
+	var union []string
+	for _, header := range headers {
+		at := 0
+		for _, column := range header {
+			if i := slices.Index(union, column); i >= 0 {
+				at = i + 1
+				continue
+			}
+			union = slices.Insert(union, at, column)
+			at++
+		}
+	}
+	return union
+}
+
+// remapColumns moves a row's cells from the positions of its own header to
+// those of union, leaving the columns its context lacks blank.
+func remapColumns(row, header, union []string) []string {
+	remapped := make([]string, len(union))
+	for i, cell := range row {
+		if i >= len(header) {
+			break
+		}
+		if j := slices.Index(union, header[i]); j >= 0 {
+			remapped[j] = cell
+		}
+	}
+	return remapped
+}
+
+// formatTableOutput merges kubectl tables under one header with a CONTEXT
+// column. Rows are cut at the header's column positions when they line up
+// with it, which keeps blank cells and values containing spaces intact, and
+// split on runs of whitespace otherwise. wide is set for -o wide and
+// custom-columns, which are always cut at the header's positions, because
+// their extra columns are often blank and user-chosen headers may contain
+// single spaces, and are not fitted to the terminal.
+func formatTableOutput(results []contextResult, wide bool) error {
 	parseColumns := func(line string) []string {
 		parts := columnSeparator.Split(line, -1)
 		var columns []string
@@ -160,24 +313,36 @@ func formatDefaultOutput(results []contextResult) error {
 
 	// First pass: collect all contexts and their outputs
 	type outputData struct {
-		context string
-		lines   []string
-		columns [][]string // Parsed columns for each line
-		err     error
-		errMsg  string
+		context  string
+		lines    []string
+		columns  [][]string // Parsed columns for each line
+		err      error
+		errMsg   string
+		duration time.Duration
+		inline   []string // --errors-inline row for a failed context
 	}
 	var allOutputs []outputData
 	maxContextWidth := len("CONTEXT")
 
 	for _, result := range results {
+		if result.cells != nil {
+			maxContextWidth = max(maxContextWidth, len(result.context))
+			allOutputs = append(allOutputs, outputData{
+				context:  result.context,
+				columns:  result.cells,
+				duration: result.duration,
+			})
+			continue
+		}
 		if result.err != nil {
 			if len(result.context) > maxContextWidth {
 				maxContextWidth = len(result.context)
 			}
 			allOutputs = append(allOutputs, outputData{
-				context: result.context,
-				err:     result.err,
-				errMsg:  result.output,
+				context:  result.context,
+				err:      result.err,
+				errMsg:   result.output,
+				duration: result.duration,
 			})
 			continue
 		}
@@ -198,28 +363,124 @@ func formatDefaultOutput(results []contextResult) error {
 
 		// Parse columns for each line
 		columns := make([][]string, len(lines))
+		var starts []int
+		if len(lines) > 1 {
+			starts = headerColumnStarts(lines[0])
+			if !wide && !alignedToHeader(lines[1:], starts) {
+				starts = nil
+			}
+		}
 		for i, line := range lines {
 			trimmed := strings.TrimSpace(line)
-			if trimmed != "" {
+			if trimmed == "" {
+				continue
+			}
+			if starts != nil {
+				columns[i] = splitAtColumnStarts(strings.TrimRight(line, " \t"), starts)
+			} else {
 				columns[i] = parseColumns(trimmed)
 			}
 		}
 
 		allOutputs = append(allOutputs, outputData{
-			context: result.context,
-			lines:   lines,
-			columns: columns,
+			context:  result.context,
+			lines:    lines,
+			columns:  columns,
+			duration: result.duration,
 		})
 	}
 
-	// Find the header from the first valid output
-	var headerColumns []string
-	var headerFound bool
+	// Merge the headers of all valid outputs, and move the cells of contexts
+	// whose columns differ under the merged header
+	var headers [][]string
 	for _, data := range allOutputs {
 		if data.err == nil && len(data.columns) > 1 && len(data.columns[0]) > 0 {
-			headerColumns = data.columns[0]
-			headerFound = true
-			break
+			headers = append(headers, data.columns[0])
+		}
+	}
+	headerColumns := unionHeader(headers)
+	headerFound := len(headers) > 0
+	for _, data := range allOutputs {
+		if data.err != nil || len(data.columns) < 2 || len(data.columns[0]) == 0 || slices.Equal(data.columns[0], headerColumns) {
+			continue
+		}
+		header := data.columns[0]
+		for i := range data.columns {
+			if len(data.columns[i]) > 0 {
+				data.columns[i] = remapColumns(data.columns[i], header, headerColumns)
+			}
+		}
+	}
+
+	if podRollup && headerFound {
+		if !isContainerTable(headerColumns) {
+			return fmt.Errorf("--by-pod needs the POD and NAME columns of kubectl x top pod --containers")
+		}
+		for i, data := range allOutputs {
+			if data.err == nil && len(data.columns) > 1 {
+				allOutputs[i].columns = append([][]string{data.columns[0]}, rollupContainers(headerColumns, data.columns[1:])...)
+			}
+		}
+		headerColumns = podRollupHeader(headerColumns)
+	}
+
+	if unhealthyRestarts >= 0 && headerFound {
+		if !slices.Contains(headerColumns, "STATUS") {
+			return fmt.Errorf("--unhealthy needs a STATUS column, as in kubectl x get pods")
+		}
+		for i, data := range allOutputs {
+			if data.err != nil || len(data.columns) < 2 {
+				continue
+			}
+			kept := data.columns[:1]
+			for _, row := range data.columns[1:] {
+				if len(row) > 0 && unhealthyPod(headerColumns, row, unhealthyRestarts) {
+					kept = append(kept, row)
+				}
+			}
+			if len(kept) == 1 {
+				kept = nil
+			}
+			allOutputs[i].columns = kept
+		}
+	}
+
+	if topDeltas != nil && headerFound {
+		header := headerColumns
+		headerColumns = withDeltaHeader(header)
+		for _, data := range allOutputs {
+			if data.err != nil {
+				continue
+			}
+			for j := 1; j < len(data.columns); j++ {
+				if len(data.columns[j]) > 0 {
+					data.columns[j] = topDeltas.annotate(data.context, header, data.columns[j])
+				}
+			}
+		}
+	}
+
+	if errorsInline {
+		for i, data := range allOutputs {
+			if data.err != nil {
+				allOutputs[i].inline = inlineErrorRow(headerColumns, inlineErrorReason(contextResult{output: data.errMsg, err: data.err}))
+			}
+		}
+	}
+
+	if showTimings && headerFound {
+		width := len(headerColumns)
+		headerColumns = withDurationColumn(headerColumns, width, "DURATION")
+		for i, data := range allOutputs {
+			for j := 1; j < len(data.columns); j++ {
+				if len(data.columns[j]) > 0 {
+					data.columns[j] = withDurationColumn(data.columns[j], width, formatDuration(data.duration))
+				}
+			}
+			if data.inline != nil {
+				reason := data.inline[len(data.inline)-1]
+				allOutputs[i].inline = append(withDurationColumn(data.inline[:width], width, formatDuration(data.duration)), reason)
+			}
 		}
 	}
 
@@ -228,13 +489,18 @@ func formatDefaultOutput(results []contextResult) error {
 	if headerFound {
 		for i, col := range headerColumns {
 			trimmed := strings.TrimSpace(col)
-			if trimmed != "" && len(trimmed) > maxColumnWidths[i] {
-				maxColumnWidths[i] = len(trimmed)
+			if trimmed != "" && visibleWidth(trimmed) > maxColumnWidths[i] {
+				maxColumnWidths[i] = visibleWidth(trimmed)
 			}
 		}
 	}
 
 	for _, data := range allOutputs {
+		for j, col := range data.inline {
+			if visibleWidth(col) > maxColumnWidths[j] {
+				maxColumnWidths[j] = visibleWidth(col)
+			}
+		}
 		if data.err != nil {
 			continue
 		}
@@ -245,44 +511,35 @@ func formatDefaultOutput(results []contextResult) error {
 		for i := startIdx; i < len(data.columns); i++ {
 			for j, col := range data.columns[i] {
 				trimmed := strings.TrimSpace(col)
-				if trimmed != "" && len(trimmed) > maxColumnWidths[j] {
-					maxColumnWidths[j] = len(trimmed)
+				if trimmed != "" && visibleWidth(trimmed) > maxColumnWidths[j] {
+					maxColumnWidths[j] = visibleWidth(trimmed)
 				}
 			}
 		}
 	}
 
 	formatColumns := func(columns []string) string {
-		var parts []string
-		for i, col := range columns {
-			width := maxColumnWidths[i]
-			if width == 0 {
-				width = len(col)
-			}
-			padded := col + strings.Repeat(" ", width-len(col))
-			parts = append(parts, padded)
+		widths := make([]int, len(columns))
+		for i := range columns {
+			widths[i] = maxColumnWidths[i]
 		}
-		// Join with 4 spaces (kubectl standard) and trim trailing spaces
-		return strings.TrimRight(strings.Join(parts, "    "), " ")
+		// Columns are separated by 4 spaces, like kubectl
+		return alignedLine(columns, widths, "    ")
 	}
 
 	for _, data := range allOutputs {
-		if data.err != nil {
-			coloredContext := colorizeContext(data.context)
-			fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", coloredContext, data.err)
-			if data.errMsg != "" {
-				fmt.Fprintf(os.Stderr, "Output: %s\n", data.errMsg)
-			}
+		if data.err != nil && data.inline == nil {
+			printContextError(contextResult{context: data.context, output: data.errMsg, err: data.err})
 		}
 	}
 
-	if headerFound {
-		contextPadding := strings.Repeat(" ", maxContextWidth-len("CONTEXT"))
-		formattedHeader := formatColumns(headerColumns)
-		fmt.Printf("%s%s  %s\n", "CONTEXT", contextPadding, formattedHeader)
-	}
-
+	var rows []tableRow
+	failedContexts := make(map[string]bool)
 	for _, data := range allOutputs {
+		if data.inline != nil {
+			rows = append(rows, tableRow{context: data.context, columns: data.inline})
+			failedContexts[data.context] = true
+		}
 		if data.err != nil {
 			continue
 		}
@@ -292,16 +549,135 @@ func formatDefaultOutput(results []contextResult) error {
 			startIdx = 1 // Skip header line
 		}
 
-		coloredContext := colorizeContext(data.context)
-		contextPadding := strings.Repeat(" ", maxContextWidth-len(data.context))
-
 		for i := startIdx; i < len(data.columns); i++ {
-			if len(data.columns[i]) == 0 {
+			if len(data.columns[i]) > 0 {
+				rows = append(rows, tableRow{context: data.context, columns: data.columns[i]})
+			}
+		}
+	}
+
+	if mergedSortColumn != "" && headerFound {
+		sortTableRows(rows, headerColumns, mergedSortColumn)
+	}
+
+	if terminalWidth := stdoutWidth(); terminalWidth > 0 && headerFound && !wide {
+		available := terminalWidth
+		if !hideContext {
+			available -= maxContextWidth + len("  ")
+		}
+		widths := make([]int, len(headerColumns))
+		for i := range headerColumns {
+			widths[i] = maxColumnWidths[i]
+		}
+		layout := fitColumns(headerColumns, widths, available)
+		headerColumns = layout.apply(headerColumns)
+		for i := range rows {
+			rows[i].columns = layout.apply(rows[i].columns)
+		}
+		fitted := make(map[int]int)
+		for k, width := range layout.widths {
+			fitted[k] = width
+		}
+		for i := layout.header; i < len(maxColumnWidths); i++ {
+			fitted[len(layout.keep)+i-layout.header] = maxColumnWidths[i]
+		}
+		maxColumnWidths = fitted
+	}
+
+	var totals []string
+	subtotals := make(map[string][]string)
+	if showTotals && headerFound {
+		var counted []tableRow
+		for _, row := range rows {
+			contextPadding := fillWidth(row.context, maxContextWidth)
+			if !failedContexts[row.context] && keepRow(row.context+contextPadding+"  "+formatColumns(row.columns)) {
+				counted = append(counted, row)
+			}
+		}
+		totals = tableTotals(headerColumns, counted)
+		extra := [][]string{totals}
+		if contextTotals {
+			byContext := make(map[string][]tableRow)
+			for _, row := range counted {
+				byContext[row.context] = append(byContext[row.context], row)
+			}
+			for context, contextRows := range byContext {
+				subtotal := tableTotals(headerColumns, contextRows)
+				label, _ := totalsLabel(headerColumns)
+				subtotal[label] = "TOTAL"
+				subtotals[context] = subtotal
+				extra = append(extra, subtotal)
+			}
+		}
+		for _, row := range extra {
+			for i, cell := range row {
+				if visibleWidth(cell) > maxColumnWidths[i] {
+					maxColumnWidths[i] = visibleWidth(cell)
+				}
+			}
+		}
+	}
+
+	if groupByContext {
+		var sections contextSections
+		for _, data := range allOutputs {
+			var lines []string
+			for _, row := range rows {
+				if row.context != data.context {
+					continue
+				}
+				contextPadding := fillWidth(row.context, maxContextWidth)
+				formattedLine := formatColumns(row.columns)
+				if keepRow(row.context + contextPadding + "  " + formattedLine) {
+					lines = append(lines, formattedLine)
+				}
+			}
+			if subtotal := subtotals[data.context]; subtotal != nil {
+				lines = append(lines, formatColumns(subtotal))
+			}
+			if len(lines) == 0 {
 				continue
 			}
-			formattedLine := formatColumns(data.columns[i])
-			fmt.Printf("%s%s  %s\n", coloredContext, contextPadding, formattedLine)
+			sections.start(data.context)
+			if headerFound && !noHeaders {
+				fmt.Println(formatColumns(headerColumns))
+			}
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+		}
+		if totals != nil {
+			sections.start("TOTAL")
+			if !noHeaders {
+				fmt.Println(formatColumns(headerColumns))
+			}
+			fmt.Println(formatColumns(totals))
+		}
+		return nil
+	}
+
+	if headerFound && !noHeaders {
+		contextPadding := fillWidth("CONTEXT", maxContextWidth)
+		formattedHeader := formatColumns(headerColumns)
+		fmt.Printf("%s%s\n", contextPrefix("CONTEXT", contextPadding), formattedHeader)
+	}
+
+	for _, row := range rows {
+		contextPadding := fillWidth(row.context, maxContextWidth)
+		formattedLine := formatColumns(row.columns)
+		if !keepRow(row.context + contextPadding + "  " + formattedLine) {
+			continue
 		}
+		fmt.Printf("%s%s\n", contextPrefix(colorizeContext(row.context), contextPadding), formattedLine)
+	}
+	for _, data := range allOutputs {
+		if subtotal := subtotals[data.context]; subtotal != nil {
+			contextPadding := fillWidth(data.context, maxContextWidth)
+			fmt.Printf("%s%s\n", contextPrefix(colorizeContext(data.context), contextPadding), formatColumns(subtotal))
+		}
+	}
+	if totals != nil {
+		fmt.Printf("%s%s\n", contextPrefix("TOTAL", fillWidth("TOTAL", maxContextWidth)), formatColumns(totals))
 	}
 
 	return nil
@@ -384,10 +760,7 @@ func formatVersionOutput(results []contextResult) error {
 
 	for _, result := range results {
 		if result.err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", result.context, result.err)
-			if result.output != "" {
-				fmt.Fprintf(os.Stderr, "Output: %s\n", result.output)
-			}
+			printContextError(result)
 		}
 	}
 
@@ -401,25 +774,31 @@ func formatVersionOutput(results []contextResult) error {
 		fmt.Println()
 	}
 
-	fmt.Printf("%-30s  %s\n", "CONTEXT", "SERVER VERSION")
-	fmt.Println(strings.Repeat("-", 50))
-
+	table := [][]string{{padRight("CONTEXT", 30), "SERVER VERSION"}}
 	for _, result := range results {
-		info := versionData[result.context]
-		coloredContext := colorizeContext(result.context)
-		// Calculate padding based on actual context length (without ANSI codes)
-		contextLen := len(result.context)
-		padding := ""
-		if contextLen < 30 {
-			padding = strings.Repeat(" ", 30-contextLen)
-		}
-		fmt.Printf("%s%s  %s\n", coloredContext, padding, info.serverVersion)
+		table = append(table, []string{colorizeContext(result.context), versionData[result.context].serverVersion})
+	}
+	widths := columnWidths(table)
+	fmt.Println(alignedLine(table[0], widths, "  "))
+	fmt.Println(strings.Repeat("-", 50))
+	for _, row := range table[1:] {
+		fmt.Println(alignedLine(row, widths, "  "))
 	}
 
 	return nil
 }
 
+// printContextError reports a failed context on stderr: the error, then
+// kubectl's output, which usually says what went wrong.
+func printContextError(result contextResult) {
+	fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", colorizeContext(result.context), result.err)
+	if result.output != "" {
+		fmt.Fprintf(os.Stderr, "Output: %s\n", result.output)
+	}
+}
+
 func formatRawOutput(results []contextResult) error {
+	var sections contextSections
 	maxContextWidth := 0
 	for _, result := range results {
 		if len(result.context) > maxContextWidth {
@@ -429,11 +808,7 @@ func formatRawOutput(results []contextResult) error {
 
 	for _, result := range results {
 		if result.err != nil {
-			coloredContext := colorizeContext(result.context)
-			fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", coloredContext, result.err)
-			if result.output != "" {
-				fmt.Fprintf(os.Stderr, "Output: %s\n", result.output)
-			}
+			printContextError(result)
 		}
 	}
 
@@ -449,22 +824,100 @@ func formatRawOutput(results []contextResult) error {
 
 		lines := strings.Split(output, "\n")
 		coloredContext := colorizeContext(result.context)
-		padding := strings.Repeat(" ", maxContextWidth-len(result.context))
+		padding := fillWidth(result.context, maxContextWidth)
 
+		var kept []string
 		for _, line := range lines {
-			fmt.Printf("%s%s  %s\n", coloredContext, padding, line)
+			if keepRow(result.context + padding + "  " + line) {
+				kept = append(kept, line)
+			}
+		}
+		if groupByContext {
+			if len(kept) > 0 {
+				sections.start(result.context)
+			}
+			for _, line := range kept {
+				fmt.Println(line)
+			}
+			continue
+		}
+		for _, line := range kept {
+			fmt.Printf("%s%s\n", contextPrefix(coloredContext, padding), line)
 		}
 	}
 
 	return nil
 }
 
+// contextSections prints the section headers of --group-by-context output,
+// separating sections with a blank line.
+type contextSections struct {
+	started bool
+}
+
+func (s *contextSections) start(context string) {
+	if s.started {
+		fmt.Println()
+	}
+	s.started = true
+	fmt.Printf("=== %s ===\n", colorizeContext(context))
+}
+
+// nameFormat lays out each -o name line; {context} and {name} (kubectl's
+// kind/name) are substituted. It can be changed in the config file.
+var nameFormat = "{context}/{name}"
+
+// formatNameOutput prints -o name lines as context/kind/name, uncolored so
+// they can be fed to other tools.
+func formatNameOutput(results []contextResult) error {
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+		}
+	}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		withContext := strings.ReplaceAll(nameFormat, "{context}", result.context)
+		if hideContext {
+			withContext = "{name}"
+		}
+		for _, name := range strings.Split(strings.TrimSpace(result.output), "\n") {
+			if name = strings.TrimSpace(name); name != "" && keepRow(result.context+"/"+name) {
+				fmt.Println(strings.ReplaceAll(withContext, "{name}", name))
+			}
+		}
+	}
+	return nil
+}
+
+// mergedListType returns the apiVersion and kind of the merged list: v1 List,
+// or with --typed-list the typed list (such as apps/v1 DeploymentList) when
+// every item has the same apiVersion and kind.
+func mergedListType(items []map[string]interface{}) (string, string) {
+	if !typedList || len(items) == 0 {
+		return "v1", "List"
+	}
+	apiVersion, _ := items[0]["apiVersion"].(string)
+	kind, _ := items[0]["kind"].(string)
+	if apiVersion == "" || kind == "" || strings.HasSuffix(kind, "List") {
+		return "v1", "List"
+	}
+	for _, item := range items[1:] {
+		if item["apiVersion"] != apiVersion || item["kind"] != kind {
+			return "v1", "List"
+		}
+	}
+	return apiVersion, kind + "List"
+}
+
 func formatJSONOutput(results []contextResult, subcommand string) error {
 	var allItems []map[string]interface{}
 
 	for _, result := range results {
 		if result.err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", result.context, result.err)
+			printContextError(contextResult{context: result.context, err: result.err})
 			if result.output != "" {
 				// Try to parse error output anyway
 				var errorData map[string]interface{}
@@ -516,9 +969,10 @@ func formatJSONOutput(results []contextResult, subcommand string) error {
 		}
 	}
 
+	apiVersion, kind := mergedListType(allItems)
 	output := map[string]interface{}{
-		"apiVersion": "v1",
-		"kind":       "List",
+		"apiVersion": apiVersion,
+		"kind":       kind,
 		"items":      allItems,
 	}
 
@@ -536,7 +990,7 @@ func formatYAMLOutput(results []contextResult, subcommand string) error {
 
 	for _, result := range results {
 		if result.err != nil {
-			fmt.Fprintf(os.Stderr, "Context %s: Error: %v\n", result.context, result.err)
+			printContextError(contextResult{context: result.context, err: result.err})
 			if result.output != "" {
 				// Try to parse error output anyway
 				var errorData map[string]interface{}
@@ -588,9 +1042,10 @@ func formatYAMLOutput(results []contextResult, subcommand string) error {
 		}
 	}
 
+	apiVersion, kind := mergedListType(allItems)
 	output := map[string]interface{}{
-		"apiVersion": "v1",
-		"kind":       "List",
+		"apiVersion": apiVersion,
+		"kind":       kind,
 		"items":      allItems,
 	}
 
@@ -602,3 +1057,57 @@ func formatYAMLOutput(results []contextResult, subcommand string) error {
 	fmt.Print(string(yamlData))
 	return nil
 }
+
+// printContextTable prints a table whose first column holds context names.
+// Widths are computed from the uncolored names so colorized contexts still
+// line up.
+func printContextTable(w io.Writer, header []string, rows [][]string) {
+	// --only-errors leaves nothing to print when every context was OK.
+	if onlyErrors && len(rows) == 0 {
+		return
+	}
+	table := [][]string{header}
+	for _, row := range rows {
+		colored := append([]string{}, row...)
+		if len(colored) > 0 {
+			colored[0] = colorizeContext(colored[0])
+		}
+		table = append(table, colored)
+	}
+	if noHeaders {
+		table = table[1:]
+	}
+	widths := columnWidths(append([][]string{header}, table...))
+	for _, row := range table {
+		fmt.Fprintln(w, alignedLine(row, widths, "  ", "    "))
+	}
+}
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// formatSummaryOutput prints one OK/ERROR row per context with the last line
+// kubectl printed, and returns an error if any context failed.
+func formatSummaryOutput(results []contextResult) error {
+	var rows [][]string
+	failed := 0
+	for _, result := range results {
+		status := "OK"
+		if result.err != nil {
+			status = "ERROR"
+			failed++
+		} else if onlyErrors {
+			continue
+		}
+		rows = append(rows, []string{result.context, status, lastLine(result.output)})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "RESULT", "MESSAGE"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts failed", failed, len(results))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -58,6 +59,11 @@ func TestDetectOutputFormat(t *testing.T) {
 			args:     []string{"pod", "-o", "table"},
 			expected: formatDefault,
 		},
+		{
+			name:     "wide output",
+			args:     []string{"pods", "-owide"},
+			expected: formatWide,
+		},
 		{
 			name:     "output flag without value",
 			args:     []string{"pod", "-o"},
@@ -106,7 +112,7 @@ func TestDetectOutputFormat(t *testing.T) {
 		{
 			name:     "name format",
 			args:     []string{"pods", "-o", "name"},
-			expected: formatRaw,
+			expected: formatName,
 		},
 		{
 			name:     "jsonpath format",
@@ -136,12 +142,12 @@ func TestDetectOutputFormat(t *testing.T) {
 		{
 			name:     "custom-columns format",
 			args:     []string{"pods", "-o", "custom-columns=NAME:.metadata.name"},
-			expected: formatRaw,
+			expected: formatCustomColumns,
 		},
 		{
 			name:     "custom-columns-file format",
 			args:     []string{"pods", "-o", "custom-columns-file=cols.txt"},
-			expected: formatRaw,
+			expected: formatCustomColumns,
 		},
 		{
 			name:     "jsonpath via equals flag",
@@ -263,6 +269,290 @@ func TestFormatDefaultOutput(t *testing.T) {
 	}
 }
 
+func TestFormatWideOutputKeepsBlankColumns(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "" +
+			"NAME   READY   STATUS    IP         NODE     NOMINATED NODE   READINESS GATES\n" +
+			"web    1/1     Running   10.0.0.1   node-1   <none>           <none>\n" +
+			"job    0/1     Pending                       <none>           <none>\n"},
+		{context: "ctx2", output: "" +
+			"NAME   READY   STATUS    IP         NODE            NOMINATED NODE   READINESS GATES\n" +
+			"api    1/1     Running   10.1.0.7   worker-node-2   worker-3         \n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatWide, "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    READY    STATUS     IP          NODE             NOMINATED NODE    READINESS GATES\n"+
+		"ctx1     web     1/1      Running    10.0.0.1    node-1           <none>            <none>\n"+
+		"ctx1     job     0/1      Pending                                 <none>            <none>\n"+
+		"ctx2     api     1/1      Running    10.1.0.7    worker-node-2    worker-3\n", output)
+}
+
+func TestFormatCustomColumnsOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "prod", output: "POD NAME   NODE\nweb-1      node-a\n"},
+		{context: "staging-eu", output: "POD NAME        NODE\nworker-7f9c2    <none>\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, detectOutputFormat([]string{"pods", "-o", "custom-columns=POD NAME:.metadata.name,NODE:.spec.nodeName"}), "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT     POD NAME        NODE\n"+
+		"prod        web-1           node-a\n"+
+		"staging-eu  worker-7f9c2    <none>\n", output)
+}
+
+func TestFormatNameOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "prod", output: "pod/web-1\npod/web-2\n"},
+		{context: "staging", output: "error: connection refused", err: fmt.Errorf("exit status 1")},
+		{context: "dev", output: ""},
+	}
+
+	var stdout string
+	stderr := captureStderr(func() {
+		stdout = captureStdout(func() {
+			require.NoError(t, formatOutput(results, formatName, "get"))
+		})
+	})
+	assert.Equal(t, "prod/pod/web-1\nprod/pod/web-2\n", stdout)
+	assert.Contains(t, stderr, "Context staging: Error: exit status 1")
+
+	old := nameFormat
+	nameFormat = "{name} --context={context}"
+	t.Cleanup(func() { nameFormat = old })
+	stdout = captureStdout(func() {
+		require.NoError(t, formatNameOutput(results[:1]))
+	})
+	assert.Equal(t, "pod/web-1 --context=prod\npod/web-2 --context=prod\n", stdout)
+}
+
+func TestFormatDefaultOutputNoHeaders(t *testing.T) {
+	noHeaders = true
+	t.Cleanup(func() { noHeaders = false })
+	results := []contextResult{
+		{context: "ctx1", output: "NAME    STATUS    AGE\npod1    Running   5m"},
+		{context: "ctx2", output: "NAME    STATUS    AGE\npod2    Pending   3m"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput(results))
+	})
+	assert.Equal(t, "ctx1     pod1    Running    5m\nctx2     pod2    Pending    3m\n", output)
+
+	var table bytes.Buffer
+	printContextTable(&table, []string{"CONTEXT", "RESULT"}, [][]string{{"ctx1", "OK"}})
+	assert.Equal(t, "ctx1     OK\n", table.String())
+}
+
+func TestFormatOutputHideContext(t *testing.T) {
+	hideContext = true
+	t.Cleanup(func() { hideContext = false })
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    STATUS\npod1    Running"},
+			{context: "long-context-2", output: "NAME    STATUS\npod2    Pending"},
+		}))
+	})
+	assert.Equal(t, "NAME    STATUS\npod1    Running\npod2    Pending\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatRawOutput([]contextResult{{context: "ctx1", output: "line one\nline two"}}))
+	})
+	assert.Equal(t, "line one\nline two\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatNameOutput([]contextResult{{context: "ctx1", output: "pod/web"}}))
+	})
+	assert.Equal(t, "pod/web\n", output)
+}
+
+func TestFormatOutputGroupByContext(t *testing.T) {
+	groupByContext = true
+	t.Cleanup(func() { groupByContext = false })
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    STATUS\npod1    Running"},
+			{context: "ctx2", output: "NAME    STATUS\nlong-pod-2    Pending"},
+		}))
+	})
+	assert.Equal(t, "=== ctx1 ===\nNAME          STATUS\npod1          Running\n\n=== ctx2 ===\nNAME          STATUS\nlong-pod-2    Pending\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, formatRawOutput([]contextResult{
+			{context: "ctx1", output: "line one\nline two"},
+			{context: "ctx2", output: "line three"},
+		}))
+	})
+	assert.Equal(t, "=== ctx1 ===\nline one\nline two\n\n=== ctx2 ===\nline three\n", output)
+}
+
+func TestFormatOutputGroupByContextSkipsFilteredSections(t *testing.T) {
+	groupByContext = true
+	setGrep(t, []string{"Pending"}, nil)
+	t.Cleanup(func() { groupByContext = false })
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    STATUS\npod1    Running"},
+			{context: "ctx2", output: "NAME    STATUS\npod2    Pending"},
+		}))
+	})
+	assert.Equal(t, "=== ctx2 ===\nNAME    STATUS\npod2    Pending\n", output)
+}
+
+func TestSplitAtColumnStarts(t *testing.T) {
+	starts := headerColumnStarts("NAME   IP         NOMINATED NODE")
+	assert.Equal(t, []int{0, 7, 18}, starts)
+	assert.Equal(t, []string{"job", "", "<none>"}, splitAtColumnStarts("job               <none>", starts))
+	assert.Equal(t, []string{"web", "10.0.0.1", ""}, splitAtColumnStarts("web    10.0.0.1", starts))
+	assert.Equal(t, []string{"café", "10.0.0.2", "node"}, splitAtColumnStarts("café   10.0.0.2   node", starts))
+}
+
+func TestAlignedToHeader(t *testing.T) {
+	starts := headerColumnStarts("NAME    STATUS    AGE")
+	assert.True(t, alignedToHeader([]string{"web-1   Running   5m", "job               3d", "web-2"}, starts))
+	assert.True(t, alignedToHeader([]string{"café    Running   5m"}, starts))
+	assert.False(t, alignedToHeader([]string{"very-long-name   Running   5m"}, starts))
+	assert.False(t, alignedToHeader([]string{"web-1   Running  5m"}, starts))
+}
+
+func TestFormatDefaultOutputCutsAtHeaderOffsets(t *testing.T) {
+	results := []contextResult{
+		{context: "prod", output: "" +
+			"NAME     TYPE        CLUSTER-IP   EXTERNAL-IP   PORT(S)   AGE\n" +
+			"web      ClusterIP   10.0.0.1                   80/TCP    3d\n" +
+			"legacy   Unusual  x  10.0.0.2     1.2.3.4       443/TCP   9d\n"},
+		{context: "staging", output: "" +
+			"NAME   TYPE        CLUSTER-IP   EXTERNAL-IP   PORT(S)   AGE\n" +
+			"web    ClusterIP   10.1.0.1     <none>        80/TCP    1h\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatDefault, "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME      TYPE          CLUSTER-IP    EXTERNAL-IP    PORT(S)    AGE\n"+
+		"prod     web       ClusterIP     10.0.0.1                     80/TCP     3d\n"+
+		"prod     legacy    Unusual  x    10.0.0.2      1.2.3.4        443/TCP    9d\n"+
+		"staging  web       ClusterIP     10.1.0.1      <none>         80/TCP     1h\n", output)
+}
+
+func TestFormatDefaultOutputAllNamespacesMixedColumns(t *testing.T) {
+	results := []contextResult{
+		{context: "prod", output: "" +
+			"NAMESPACE   NAME      AGE\n" +
+			"payments    web       3d\n"},
+		{context: "staging", output: "" +
+			"NAME      AGE\n" +
+			"web       1h\n"},
+		{context: "dev", output: "" +
+			"NAMESPACE   NAME   READY   AGE\n" +
+			"default     api    1/1     5m\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatDefault, "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAMESPACE    NAME    READY    AGE\n"+
+		"prod     payments     web              3d\n"+
+		"staging               web              1h\n"+
+		"dev      default      api     1/1      5m\n", output)
+}
+
+func TestFormatDefaultOutputUnhealthy(t *testing.T) {
+	unhealthyRestarts = 5
+	t.Cleanup(func() { unhealthyRestarts = -1 })
+	results := []contextResult{
+		{context: "prod", output: "" +
+			"NAME    READY   STATUS             RESTARTS      AGE\n" +
+			"web-1   1/1     Running            0             3d\n" +
+			"web-2   0/1     CrashLoopBackOff   12 (1m ago)   3d\n"},
+		{context: "staging", output: "" +
+			"NAME    READY   STATUS    RESTARTS   AGE\n" +
+			"web-1   1/1     Running   0          1h\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatDefault, "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME     READY    STATUS              RESTARTS       AGE\n"+
+		"prod     web-2    0/1      CrashLoopBackOff    12 (1m ago)    3d\n", output)
+
+	err := formatOutput([]contextResult{{context: "prod", output: "NAME   AGE\nweb    3d\n"}}, formatDefault, "get")
+	assert.ErrorContains(t, err, "needs a STATUS column")
+}
+
+func TestFormatDefaultOutputTotals(t *testing.T) {
+	showTotals = true
+	t.Cleanup(func() { showTotals = false })
+	results := []contextResult{
+		{context: "prod", output: "" +
+			"NAME   READY   UP-TO-DATE   AVAILABLE   AGE\n" +
+			"web    3/3     3            3           3d\n" +
+			"api    1/2     2            1           3d\n"},
+		{context: "staging", output: "" +
+			"NAME   READY   UP-TO-DATE   AVAILABLE   AGE\n" +
+			"web    1/1     1            1           1h\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatDefault, "get"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME         READY    UP-TO-DATE    AVAILABLE    AGE\n"+
+		"prod     web          3/3      3             3            3d\n"+
+		"prod     api          1/2      2             1            3d\n"+
+		"staging  web          1/1      1             1            1h\n"+
+		"TOTAL    3 objects    5/6      6             5\n", output)
+}
+
+func TestFormatDefaultOutputContextTotals(t *testing.T) {
+	showTotals, contextTotals = true, true
+	t.Cleanup(func() { showTotals, contextTotals = false, false })
+	results := []contextResult{
+		{context: "prod", output: "" +
+			"NAME     CPU(cores)   MEMORY(bytes)\n" +
+			"web-1    250m         128Mi\n" +
+			"web-2    750m         384Mi\n"},
+		{context: "staging", output: "" +
+			"NAME     CPU(cores)   MEMORY(bytes)\n" +
+			"web-1    5m           64Mi\n"},
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatOutput(results, formatDefault, "top"))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME         CPU(cores)    MEMORY(bytes)\n"+
+		"prod     web-1        250m          128Mi\n"+
+		"prod     web-2        750m          384Mi\n"+
+		"staging  web-1        5m            64Mi\n"+
+		"prod     TOTAL        1000m         512Mi\n"+
+		"staging  TOTAL        5m            64Mi\n"+
+		"TOTAL    3 objects    1005m         576Mi\n", output)
+}
+
+func TestUnionHeader(t *testing.T) {
+	assert.Equal(t, []string{"NAME", "AGE"}, unionHeader([][]string{{"NAME", "AGE"}, {"NAME", "AGE"}}))
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "AGE"}, unionHeader([][]string{{"NAME", "AGE"}, {"NAMESPACE", "NAME", "AGE"}}))
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "READY", "AGE"}, unionHeader([][]string{{"NAMESPACE", "NAME", "AGE"}, {"NAME", "READY", "AGE"}}))
+	assert.Nil(t, unionHeader(nil))
+}
+
+func TestRemapColumns(t *testing.T) {
+	union := []string{"NAMESPACE", "NAME", "READY", "AGE"}
+	assert.Equal(t, []string{"", "web", "", "1h"}, remapColumns([]string{"web", "1h"}, []string{"NAME", "AGE"}, union))
+	assert.Equal(t, []string{"default", "api", "", ""}, remapColumns([]string{"default", "api"}, []string{"NAMESPACE", "NAME", "AGE"}, union))
+}
+
 func TestFormatDefaultOutputErrorsBeforeOutput(t *testing.T) {
 	results := []contextResult{
 		{context: "ctx1", output: "NAME    STATUS\npod1    Running"},
@@ -646,6 +936,47 @@ func TestFormatYAMLOutput(t *testing.T) {
 	}
 }
 
+func TestFormatJSONOutputTypedList(t *testing.T) {
+	typedList = true
+	t.Cleanup(func() { typedList = false })
+	deployments := func(names ...string) string {
+		var items []string
+		for _, name := range names {
+			items = append(items, fmt.Sprintf(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":%q}}`, name))
+		}
+		return `{"apiVersion":"v1","kind":"List","items":[` + strings.Join(items, ",") + `]}`
+	}
+
+	output := captureStdout(func() {
+		require.NoError(t, formatJSONOutput([]contextResult{
+			{context: "ctx1", output: deployments("api")},
+			{context: "ctx2", output: deployments("web", "worker")},
+		}, "get"))
+	})
+	var merged map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &merged))
+	assert.Equal(t, "apps/v1", merged["apiVersion"])
+	assert.Equal(t, "DeploymentList", merged["kind"])
+	assert.Len(t, merged["items"], 3)
+}
+
+func TestMergedListType(t *testing.T) {
+	pod := map[string]interface{}{"apiVersion": "v1", "kind": "Pod"}
+	service := map[string]interface{}{"apiVersion": "v1", "kind": "Service"}
+
+	apiVersion, kind := mergedListType([]map[string]interface{}{pod, pod})
+	assert.Equal(t, []string{"v1", "List"}, []string{apiVersion, kind})
+
+	typedList = true
+	t.Cleanup(func() { typedList = false })
+	apiVersion, kind = mergedListType([]map[string]interface{}{pod, pod})
+	assert.Equal(t, []string{"v1", "PodList"}, []string{apiVersion, kind})
+	apiVersion, kind = mergedListType([]map[string]interface{}{pod, service})
+	assert.Equal(t, []string{"v1", "List"}, []string{apiVersion, kind})
+	apiVersion, kind = mergedListType(nil)
+	assert.Equal(t, []string{"v1", "List"}, []string{apiVersion, kind})
+}
+
 func TestFormatOutput(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -774,3 +1105,59 @@ func TestFormatOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintContextTable(t *testing.T) {
+	output := captureStdout(func() {
+		printContextTable(os.Stdout, []string{"CONTEXT", "STATUS", "MESSAGE"}, [][]string{
+			{"ctx1", "OK", "done"},
+			{"long-context", "Failed", ""},
+		})
+	})
+	assert.Equal(t, "CONTEXT       STATUS    MESSAGE\nctx1          OK        done\nlong-context  Failed\n", output)
+}
+
+func TestFormatSummaryOutput(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "deployment.apps/app restarted\n"},
+		{context: "ctx2", output: "Error from server (NotFound): deployments.apps \"app\" not found\n", err: fmt.Errorf("exit status 1")},
+	}
+	var err error
+	output := captureStdout(func() {
+		err = formatSummaryOutput(results)
+	})
+	require.Error(t, err)
+	assert.Equal(t, "1 of 2 contexts failed", err.Error())
+	assert.Equal(t, "CONTEXT  RESULT    MESSAGE\n"+
+		"ctx1     OK        deployment.apps/app restarted\n"+
+		"ctx2     ERROR     Error from server (NotFound): deployments.apps \"app\" not found\n", output)
+}
+
+func TestColorMode(t *testing.T) {
+	t.Cleanup(func() { colorMode = "auto" })
+
+	colorMode = "always"
+	colored := colorizeContext("prod")
+	assert.Contains(t, colored, "prod")
+	assert.True(t, strings.HasSuffix(colored, colorReset))
+
+	colorMode = "never"
+	assert.Equal(t, "prod", colorizeContext("prod"))
+}
+
+func TestColorEnabledNoColor(t *testing.T) {
+	t.Cleanup(func() { colorMode = "auto" })
+	t.Setenv("NO_COLOR", "1")
+
+	assert.False(t, colorEnabled())
+	colorMode = "always"
+	assert.True(t, colorEnabled(), "--color=always overrides NO_COLOR")
+}
+
+func TestPrintContextError(t *testing.T) {
+	stderr := captureStderr(func() {
+		printContextError(contextResult{context: "ctx1", output: "error: Unauthorized", err: fmt.Errorf("exit status 1")})
+		printContextError(contextResult{context: "ctx2", err: fmt.Errorf("dial tcp: i/o timeout")})
+	})
+	assert.Equal(t, "Context ctx1: Error: exit status 1\nOutput: error: Unauthorized\n"+
+		"Context ctx2: Error: dial tcp: i/o timeout\n", stderr)
+}
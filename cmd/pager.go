@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var noPager bool
+
+// pagedStdout and pagedStderr are the terminal while stdout and stderr are
+// redirected into the pager.
+var pagedStdout, pagedStderr *os.File
+
+// stopPager waits for the pager started by the current command, if any.
+var stopPager = func() {}
+
+// stdoutIsTerminal is a variable so tests can fake a terminal.
+var stdoutIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// pagedCommands print finite output that is worth paging. Commands that
+// prompt, stream, or take over the terminal are left alone.
+var pagedCommands = map[string]bool{
+	"get": true, "top": true, "events": true, "logs": true, "explain": true, "auth": true, "diff": true,
+	"api-resources": true, "api-versions": true, "version": true, "list": true, "contexts": true,
+	"images": true, "nodes": true, "summary": true, "find": true,
+}
+
+// terminalStdout is the file that decides whether output goes to a
+// terminal: the real stdout even while the pager is running.
+func terminalStdout() *os.File {
+	if pagedStdout != nil {
+		return pagedStdout
+	}
+	return os.Stdout
+}
+
+// pagerCommand follows git: $KUBECTL_X_PAGER, then $PAGER, then less when
+// it is installed. An empty value or cat disables paging.
+func pagerCommand() string {
+	pager, ok := os.LookupEnv("KUBECTL_X_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		if _, err := exec.LookPath("less"); err != nil {
+			return ""
+		}
+		pager = "less"
+	}
+	if pager = strings.TrimSpace(pager); pager == "cat" {
+		return ""
+	}
+	return pager
+}
+
+// shouldPage reports whether the command's output goes through the pager:
+// it must print finite output to a terminal.
+func shouldPage(cmd *cobra.Command, args []string) bool {
+	if noPager || !pagedCommands[cmd.Name()] || !stdoutIsTerminal() {
+		return false
+	}
+	for _, arg := range args {
+		switch arg {
+		case "-w", "--watch", "--watch-only", "--refresh":
+			return false
+		case "-f", "--follow":
+			if cmd.Name() == "logs" {
+				return false
+			}
+		}
+		if strings.HasPrefix(arg, "--watch=") || strings.HasPrefix(arg, "--follow=") || strings.HasPrefix(arg, "--refresh=") {
+			return false
+		}
+	}
+	return true
+}
+
+// startPager redirects stdout into the pager until the returned function is
+// called. LESS defaults to FRX like git, so less exits straight away when
+// the output fits on one screen and keeps colors. Stderr goes into the pager
+// too, so warnings and errors are not drawn over its screen.
+func startPager(command string) (func(), error) {
+	if fields := strings.Fields(command); len(fields) > 0 && !strings.Contains(fields[0], "=") {
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			return nil, fmt.Errorf("pager %q not found", fields[0])
+		}
+	}
+	pager := pagerShell(command)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start pager: %w", err)
+	}
+	pager.Stdin = reader
+	if err := pager.Start(); err != nil {
+		reader.Close()
+		writer.Close()
+		return nil, fmt.Errorf("failed to start pager %q: %w", command, err)
+	}
+	reader.Close()
+
+	pagedStdout, pagedStderr = os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = writer, writer
+	return func() {
+		os.Stdout, os.Stderr = pagedStdout, pagedStderr
+		pagedStdout, pagedStderr = nil, nil
+		writer.Close()
+		pager.Wait()
+	}, nil
+}
+
+// pageOutput starts the pager for the command when appropriate. A pager
+// that cannot be started, or is not installed, is reported and output goes
+// to the terminal.
+func pageOutput(cmd *cobra.Command, args []string) {
+	command := pagerCommand()
+	if command == "" || !shouldPage(cmd, args) {
+		return
+	}
+	stop, err := startPager(command)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	stopPager = func() {
+		stop()
+		stopPager = func() {}
+	}
+}
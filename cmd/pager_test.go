@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeStdoutTerminal(t *testing.T) {
+	t.Helper()
+	original := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return true }
+	t.Cleanup(func() { stdoutIsTerminal = original })
+}
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("KUBECTL_X_PAGER", "")
+	os.Unsetenv("KUBECTL_X_PAGER")
+	t.Setenv("PAGER", "more")
+	assert.Equal(t, "more", pagerCommand())
+
+	t.Setenv("KUBECTL_X_PAGER", "less -S")
+	assert.Equal(t, "less -S", pagerCommand())
+
+	t.Setenv("KUBECTL_X_PAGER", "cat")
+	assert.Equal(t, "", pagerCommand())
+
+	t.Setenv("KUBECTL_X_PAGER", "")
+	assert.Equal(t, "", pagerCommand())
+}
+
+func TestShouldPage(t *testing.T) {
+	fakeStdoutTerminal(t)
+	get := &cobra.Command{Use: "get"}
+	logs := &cobra.Command{Use: "logs"}
+
+	assert.True(t, shouldPage(get, []string{"pods", "-A"}))
+	assert.True(t, shouldPage(get, []string{"-f", "pods.yaml"}))
+	assert.False(t, shouldPage(get, []string{"pods", "-w"}))
+	assert.False(t, shouldPage(get, []string{"pods", "--watch=true"}))
+	assert.False(t, shouldPage(get, []string{"pods", "--refresh", "10s"}))
+	assert.False(t, shouldPage(get, []string{"pods", "--refresh=10s"}))
+	assert.True(t, shouldPage(logs, []string{"deploy/web"}))
+	assert.False(t, shouldPage(logs, []string{"deploy/web", "-f"}))
+	assert.False(t, shouldPage(&cobra.Command{Use: "exec"}, []string{"web", "--", "sh"}))
+
+	noPager = true
+	t.Cleanup(func() { noPager = false })
+	assert.False(t, shouldPage(get, []string{"pods"}))
+}
+
+func TestShouldPageNotTerminal(t *testing.T) {
+	assert.False(t, shouldPage(&cobra.Command{Use: "get"}, []string{"pods"}))
+}
+
+func TestStartPager(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paged")
+	original := os.Stdout
+
+	originalStderr := os.Stderr
+
+	stop, err := startPager("cat > " + path)
+	require.NoError(t, err)
+	assert.Equal(t, original, terminalStdout())
+	assert.Nil(t, newProgressDisplay([]string{"ctx1"}), "no progress is drawn over the pager")
+	fmt.Println("CONTEXT  NAME")
+	fmt.Fprintln(os.Stderr, "Warning: ctx2 is unreachable")
+	stop()
+
+	assert.Equal(t, original, os.Stdout)
+	assert.Equal(t, originalStderr, os.Stderr)
+	assert.Nil(t, pagedStdout)
+	assert.Equal(t, "CONTEXT  NAME\nWarning: ctx2 is unreachable\n", readFile(t, path))
+}
+
+func TestStartPagerNotInstalled(t *testing.T) {
+	original := os.Stdout
+	_, err := startPager("no-such-pager-x -R")
+	assert.EqualError(t, err, `pager "no-such-pager-x" not found`)
+	assert.Equal(t, original, os.Stdout)
+}
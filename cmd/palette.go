@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type colorDepth int
+
+const (
+	depthBasic colorDepth = iota
+	depth256
+	depthTrueColor
+)
+
+// terminalColorDepth detects the palette the terminal supports from the
+// COLORTERM and TERM conventions.
+func terminalColorDepth() colorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return depthTrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return depth256
+	}
+	return depthBasic
+}
+
+// extendedColors are the colors of the 256-color cube that read well on both
+// dark and light backgrounds: no grays, nothing too dark or too pale.
+var extendedColors = func() []string {
+	var codes []string
+	for r := 0; r <= 5; r++ {
+		for g := 0; g <= 5; g++ {
+			for b := 0; b <= 5; b++ {
+				sum := r + g + b
+				if (r == g && g == b) || sum < 5 || sum > 12 {
+					continue
+				}
+				codes = append(codes, color256(16+36*r+6*g+b))
+			}
+		}
+	}
+	return codes
+}()
+
+func color256(index int) string {
+	return fmt.Sprintf("\033[38;5;%dm", index)
+}
+
+func colorRGB(r, g, b int) string {
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
+// hueColor returns a truecolor code for a hue in degrees at a fixed
+// saturation and lightness, so every hue is equally readable.
+func hueColor(hue float64) string {
+	const saturation, lightness = 0.7, 0.6
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g = chroma, x
+	case hue < 120:
+		r, g = x, chroma
+	case hue < 180:
+		g, b = chroma, x
+	case hue < 240:
+		g, b = x, chroma
+	case hue < 300:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := lightness - chroma/2
+	channel := func(v float64) int { return int(math.Round((v + m) * 255)) }
+	return colorRGB(channel(r), channel(g), channel(b))
+}
+
+// hashedColor picks a context's color from the largest palette the terminal
+// supports, so large fleets rarely share a color.
+func hashedColor(context string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(context))
+	value := hash.Sum32()
+
+	switch terminalColorDepth() {
+	case depthTrueColor:
+		return hueColor(float64(value % 360))
+	case depth256:
+		return extendedColors[value%uint32(len(extendedColors))]
+	}
+	return contextColors[value%uint32(len(contextColors))]
+}
+
+// parseColor accepts a color name, a 256-color index such as 208, or a
+// truecolor hex value such as #ff8800.
+func parseColor(color string) (string, bool) {
+	color = strings.ToLower(color)
+	if code, ok := namedColors[color]; ok {
+		return code, true
+	}
+	if hex, ok := strings.CutPrefix(color, "#"); ok && len(hex) == 6 {
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return "", false
+		}
+		return colorRGB(int(rgb>>16), int(rgb>>8&0xff), int(rgb&0xff)), true
+	}
+	if index, err := strconv.Atoi(color); err == nil && index >= 0 && index <= 255 {
+		return color256(index), true
+	}
+	return "", false
+}
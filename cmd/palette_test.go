@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalColorDepth(t *testing.T) {
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm")
+	assert.Equal(t, depthBasic, terminalColorDepth())
+
+	t.Setenv("TERM", "xterm-256color")
+	assert.Equal(t, depth256, terminalColorDepth())
+
+	t.Setenv("COLORTERM", "truecolor")
+	assert.Equal(t, depthTrueColor, terminalColorDepth())
+}
+
+func TestExtendedColors(t *testing.T) {
+	assert.Greater(t, len(extendedColors), 100)
+	assert.NotContains(t, extendedColors, color256(16), "black")
+	assert.NotContains(t, extendedColors, color256(231), "white")
+}
+
+func TestHueColor(t *testing.T) {
+	assert.Equal(t, "\033[38;2;224;82;82m", hueColor(0))
+	assert.Equal(t, "\033[38;2;82;224;82m", hueColor(120))
+	assert.Equal(t, "\033[38;2;82;82;224m", hueColor(240))
+}
+
+func TestHashedColor(t *testing.T) {
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm")
+	assert.Contains(t, contextColors, hashedColor("prod"))
+
+	t.Setenv("TERM", "screen-256color")
+	assert.Contains(t, extendedColors, hashedColor("prod"))
+	assert.Equal(t, hashedColor("prod"), hashedColor("prod"))
+
+	t.Setenv("COLORTERM", "24bit")
+	assert.Contains(t, hashedColor("prod"), "\033[38;2;")
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		color string
+		code  string
+		ok    bool
+	}{
+		{"red", colorRed, true},
+		{"Bright-Cyan", "\033[96m", true},
+		{"208", "\033[38;5;208m", true},
+		{"#FF8800", "\033[38;2;255;136;0m", true},
+		{"256", "", false},
+		{"#ff88", "", false},
+		{"#gg8800", "", false},
+		{"purple", "", false},
+	}
+	for _, tt := range tests {
+		code, ok := parseColor(tt.color)
+		assert.Equal(t, tt.ok, ok, tt.color)
+		assert.Equal(t, tt.code, code, tt.color)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var pingTimeout time.Duration
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check health, latency, auth and server version of every context",
+	Long:  `Concurrently check each context's API server health (/healthz), round-trip latency, whether the current credentials are accepted, and the server version.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPing()
+	},
+}
+
+func init() {
+	pingCmd.Flags().DurationVar(&pingTimeout, "timeout", 5*time.Second, "How long to wait for each API server")
+}
+
+type pingResult struct {
+	health  string
+	latency time.Duration
+	auth    string
+	version string
+	message string
+}
+
+// pingContext runs the health, auth and version checks for one context. Auth
+// and version are skipped when the API server can't be reached.
+func pingContext(context string, timeout time.Duration) pingResult {
+	requestTimeout := fmt.Sprintf("--request-timeout=%s", timeout)
+	result := pingResult{health: "ok", auth: "-", version: "-"}
+
+	start := time.Now()
+	output, err := runKubectlCommand(context, "get", []string{"--raw", "/healthz", requestTimeout})
+	result.latency = time.Since(start)
+	if err != nil {
+		result.health = "unhealthy"
+		result.message = lastLine(output)
+		if !strings.Contains(output, "Error from server") {
+			return result
+		}
+	}
+
+	output, err = runKubectlCommand(context, "auth", []string{"can-i", "get", "namespaces", requestTimeout})
+	switch _, answered := canIAnswer(contextResult{output: output, err: err}); {
+	case answered:
+		result.auth = "ok"
+	case strings.Contains(output, "Unauthorized") || strings.Contains(output, "must be logged in"):
+		result.auth = "unauthorized"
+		result.message = lastLine(output)
+	default:
+		result.auth = "error"
+		result.message = lastLine(output)
+	}
+
+	output, err = runKubectlCommand(context, "version", []string{"-o", "json", requestTimeout})
+	if version := parseServerVersion(output); version != "" {
+		result.version = version
+	} else if err != nil && result.message == "" {
+		result.message = lastLine(output)
+	}
+	return result
+}
+
+func parseServerVersion(output string) string {
+	var version struct {
+		ServerVersion struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	start := strings.Index(output, "{")
+	if start == -1 || json.Unmarshal([]byte(output[start:]), &version) != nil {
+		return ""
+	}
+	return version.ServerVersion.GitVersion
+}
+
+func runPing() error {
+	contexts, err := selectContexts()
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	pings := make(map[string]pingResult, len(contexts))
+	runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		result := pingContext(context, pingTimeout)
+		mu.Lock()
+		pings[context] = result
+		mu.Unlock()
+		return "", nil
+	})
+
+	var rows [][]string
+	for _, ctx := range contexts {
+		p := pings[ctx]
+		rows = append(rows, []string{ctx, p.health, formatLatency(p.latency), p.auth, p.version, p.message})
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "HEALTH", "LATENCY", "AUTH", "VERSION", "MESSAGE"}, rows)
+	return nil
+}
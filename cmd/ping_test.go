@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingCmd(t *testing.T) {
+	require.NotNil(t, pingCmd)
+	assert.Equal(t, "ping", pingCmd.Use)
+	require.NotNil(t, pingCmd.Flags().Lookup("timeout"))
+}
+
+func TestParseServerVersion(t *testing.T) {
+	output := `{"clientVersion":{"gitVersion":"v1.30.1"},"serverVersion":{"gitVersion":"v1.28.0"}}`
+	assert.Equal(t, "v1.28.0", parseServerVersion(output))
+	assert.Equal(t, "v1.28.0", parseServerVersion("WARNING: version skew\n"+output))
+	assert.Equal(t, "", parseServerVersion("error: unable to connect"))
+}
+
+func TestPingContext(t *testing.T) {
+	t.Run("healthy and authorized", func(t *testing.T) {
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			switch subcommand {
+			case "get":
+				return "ok", nil
+			case "auth":
+				return "yes\n", nil
+			default:
+				return `{"serverVersion":{"gitVersion":"v1.29.2"}}`, nil
+			}
+		})
+		result := pingContext("ctx1", time.Second)
+		assert.Equal(t, "ok", result.health)
+		assert.Equal(t, "ok", result.auth)
+		assert.Equal(t, "v1.29.2", result.version)
+		assert.Empty(t, result.message)
+	})
+
+	t.Run("unreachable skips remaining checks", func(t *testing.T) {
+		var calls []string
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			calls = append(calls, subcommand)
+			return "Unable to connect to the server: dial tcp: i/o timeout\n", fmt.Errorf("exit status 1")
+		})
+		result := pingContext("ctx1", time.Second)
+		assert.Equal(t, []string{"get"}, calls)
+		assert.Equal(t, "unhealthy", result.health)
+		assert.Equal(t, "-", result.auth)
+		assert.Equal(t, "Unable to connect to the server: dial tcp: i/o timeout", result.message)
+	})
+
+	t.Run("expired credentials", func(t *testing.T) {
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			if subcommand == "get" {
+				return "ok", nil
+			}
+			return "error: You must be logged in to the server (Unauthorized)\n", fmt.Errorf("exit status 1")
+		})
+		result := pingContext("ctx1", time.Second)
+		assert.Equal(t, "unauthorized", result.auth)
+		assert.Equal(t, "-", result.version)
+	})
+}
+
+func TestRunPing(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		switch subcommand {
+		case "get":
+			return "ok", nil
+		case "auth":
+			return "no\n", exitStatus(t, 1)
+		default:
+			return `{"serverVersion":{"gitVersion":"v1.29.2"}}`, nil
+		}
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runPing())
+	})
+	assert.Contains(t, output, "CONTEXT  HEALTH    LATENCY    AUTH    VERSION")
+	assert.Regexp(t, regexp.MustCompile(`(?m)^ctx1\s+ok\s+\S+\s+ok\s+v1\.29\.2$`), output)
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// terminalInput is the controlling terminal, read for prompts when stdin
+// is taken.
+const terminalInput = "/dev/tty"
+
+// shutdownSignals stop streaming commands.
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// stopProcess asks a kubectl process to exit.
+func stopProcess(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
+
+// enableVirtualTerminal reports whether escape sequences written to f are
+// interpreted, which Unix terminals always do.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+func pagerShell(command string) *exec.Cmd {
+	return exec.Command("sh", "-c", command)
+}
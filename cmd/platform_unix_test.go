@@ -0,0 +1,40 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopProcess(t *testing.T) {
+	sleep := exec.Command("sleep", "10")
+	require.NoError(t, sleep.Start())
+
+	require.NoError(t, stopProcess(sleep.Process))
+	err := sleep.Wait()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, syscall.SIGTERM, exitErr.Sys().(syscall.WaitStatus).Signal())
+}
+
+func TestShutdownSignals(t *testing.T) {
+	assert.Equal(t, []os.Signal{os.Interrupt, syscall.SIGTERM}, shutdownSignals)
+}
+
+func TestEnableANSI(t *testing.T) {
+	t.Cleanup(func() { ansiStdout, ansiStderr = true, true })
+	ansiStdout, ansiStderr = false, false
+	enableANSI()
+	assert.True(t, ansiStdout)
+	assert.True(t, ansiStderr)
+}
+
+func TestTerminalInput(t *testing.T) {
+	assert.Equal(t, "/dev/tty", terminalInput)
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+
+	"golang.org/x/sys/windows"
+)
+
+// terminalInput is the console's input buffer, read for prompts when stdin
+// is taken.
+const terminalInput = "CONIN$"
+
+// shutdownSignals stop streaming commands. Windows only delivers Ctrl+C and
+// Ctrl+Break, both as os.Interrupt.
+var shutdownSignals = []os.Signal{os.Interrupt}
+
+// stopProcess ends a kubectl process. Windows cannot send SIGTERM; kubectl
+// shares the console and has already received the Ctrl+C, so this only ends
+// processes that linger.
+func stopProcess(process *os.Process) error {
+	return process.Kill()
+}
+
+// enableVirtualTerminal turns on escape sequence processing for the console
+// behind f. It reports false only for consoles that cannot do so, such as
+// legacy conhost; pipes and files pass escape sequences through.
+func enableVirtualTerminal(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return true
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+func pagerShell(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
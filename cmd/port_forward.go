@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:                "port-forward",
+	Short:              "Forward local ports to the same resource in every context",
+	Long:               `Run kubectl port-forward against every context at once. Local ports are shifted by a block per context (8080 and 8081, then 8082 and 8083, ...) so the forwards don't collide, ports that are already in use are reported before anything starts, and a context-to-port table is printed before forwarding starts. Ctrl-C stops all forwards.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPortForward(args)
+	},
+}
+
+var portSpecPattern = regexp.MustCompile(`^(\d*)(:\d+)?$`)
+
+// portForwardValueFlags take a separate value that must not be read as a port.
+var portForwardValueFlags = map[string]bool{
+	"-n": true, "--namespace": true, "--address": true, "--pod-running-timeout": true,
+}
+
+// offsetPorts adds offset to every explicit local port in args, so "8080" and
+// "8080:80" become "8081:8080" and "8081:80" for offset 1. Ports with no local
+// part (":80") are left for kubectl to choose.
+func offsetPorts(args []string, offset int) ([]string, []string) {
+	result := make([]string, 0, len(args))
+	var mappings []string
+	seenResource := false
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") || (i > 0 && portForwardValueFlags[args[i-1]]) {
+			result = append(result, arg)
+			continue
+		}
+		if !seenResource {
+			seenResource = true
+			result = append(result, arg)
+			continue
+		}
+
+		match := portSpecPattern.FindStringSubmatch(arg)
+		if match == nil || match[1] == "" {
+			result = append(result, arg)
+			if match != nil {
+				mappings = append(mappings, "random"+match[2])
+			}
+			continue
+		}
+
+		local, _ := strconv.Atoi(match[1])
+		remote := match[2]
+		if remote == "" {
+			remote = ":" + match[1]
+		}
+		spec := strconv.Itoa(local+offset) + remote
+		result = append(result, spec)
+		mappings = append(mappings, spec)
+	}
+	return result, mappings
+}
+
+// localPorts returns the explicit local ports of offsetPorts' mappings.
+func localPorts(mappings []string) []int {
+	var ports []int
+	for _, mapping := range mappings {
+		local, _, _ := strings.Cut(mapping, ":")
+		if port, err := strconv.Atoi(local); err == nil {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// portBlock is how far the local ports of each context are shifted from
+// the previous one's: the number of ports, so 8080 and 8081 become 8082 and
+// 8083 for the second context, widened until no two contexts share a port.
+func portBlock(ports []int, contexts int) int {
+	ports = slices.Compact(slices.Sorted(slices.Values(ports)))
+	for block := max(len(ports), 1); ; block++ {
+		seen := make(map[int]bool)
+		collides := false
+		for i := 0; i < contexts && !collides; i++ {
+			for _, port := range ports {
+				if seen[port+i*block] {
+					collides = true
+					break
+				}
+				seen[port+i*block] = true
+			}
+		}
+		if !collides {
+			return block
+		}
+	}
+}
+
+// portInUse reports whether port can't be listened on at any of the
+// addresses kubectl will bind, localhost unless --address is given.
+func portInUse(args []string, port int) bool {
+	addresses, _ := extractStringFlag(args, "--address")
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	for _, address := range strings.Split(addresses[len(addresses)-1], ",") {
+		if address == "localhost" {
+			address = "127.0.0.1"
+		}
+		listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+		if err != nil {
+			return true
+		}
+		listener.Close()
+	}
+	return false
+}
+
+func runPortForward(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	_, mappings := offsetPorts(args, 0)
+	if len(mappings) == 0 {
+		return fmt.Errorf("port-forward requires a resource and at least one port, e.g. svc/web 8080")
+	}
+	block := portBlock(localPorts(mappings), len(contexts))
+
+	argsByContext := make([][]string, len(contexts))
+	var rows [][]string
+	var busy []string
+	for i, ctx := range contexts {
+		contextArgs, mappings := offsetPorts(args, i*block)
+		for _, port := range localPorts(mappings) {
+			if portInUse(args, port) {
+				busy = append(busy, fmt.Sprintf("%d (%s)", port, ctx))
+			}
+		}
+		argsByContext[i] = contextArgs
+		rows = append(rows, []string{ctx, strings.Join(mappings, ", ")})
+	}
+	if len(busy) > 0 {
+		return fmt.Errorf("local ports already in use: %s", strings.Join(busy, ", "))
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "LOCAL:REMOTE"}, rows)
+	fmt.Println()
+
+	return streamAcrossContexts(contexts, "port-forward", func(index int, _ string) []string {
+		return argsByContext[index]
+	}, streamOptions{})
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortForwardCmd(t *testing.T) {
+	require.NotNil(t, portForwardCmd)
+	assert.Equal(t, "port-forward", portForwardCmd.Use)
+	assert.True(t, portForwardCmd.DisableFlagParsing)
+}
+
+func TestOffsetPorts(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		offset   int
+		expected []string
+		mappings []string
+	}{
+		{
+			name:     "single port",
+			args:     []string{"svc/web", "8080"},
+			offset:   2,
+			expected: []string{"svc/web", "8082:8080"},
+			mappings: []string{"8082:8080"},
+		},
+		{
+			name:     "local and remote port",
+			args:     []string{"svc/web", "8080:80", "-n", "web"},
+			offset:   1,
+			expected: []string{"svc/web", "8081:80", "-n", "web"},
+			mappings: []string{"8081:80"},
+		},
+		{
+			name:     "first context keeps its port",
+			args:     []string{"svc/web", "8080:80"},
+			offset:   0,
+			expected: []string{"svc/web", "8080:80"},
+			mappings: []string{"8080:80"},
+		},
+		{
+			name:     "multiple ports",
+			args:     []string{"pod/web", "8080:80", "9090"},
+			offset:   1,
+			expected: []string{"pod/web", "8081:80", "9091:9090"},
+			mappings: []string{"8081:80", "9091:9090"},
+		},
+		{
+			name:     "random local port is untouched",
+			args:     []string{"svc/web", ":80"},
+			offset:   3,
+			expected: []string{"svc/web", ":80"},
+			mappings: []string{"random:80"},
+		},
+		{
+			name:     "flag values are not ports",
+			args:     []string{"--address", "0.0.0.0", "-n", "8080", "svc/web", "8080"},
+			offset:   1,
+			expected: []string{"--address", "0.0.0.0", "-n", "8080", "svc/web", "8081:8080"},
+			mappings: []string{"8081:8080"},
+		},
+		{
+			name:     "no ports",
+			args:     []string{"svc/web"},
+			offset:   1,
+			expected: []string{"svc/web"},
+			mappings: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, mappings := offsetPorts(tt.args, tt.offset)
+			assert.Equal(t, tt.expected, args)
+			assert.Equal(t, tt.mappings, mappings)
+		})
+	}
+}
+
+func TestLocalPorts(t *testing.T) {
+	assert.Equal(t, []int{8080, 9090}, localPorts([]string{"8080:80", "random:443", "9090:9090"}))
+	assert.Nil(t, localPorts([]string{"random:80"}))
+}
+
+func TestPortBlock(t *testing.T) {
+	assert.Equal(t, 1, portBlock([]int{8080}, 3))
+	assert.Equal(t, 2, portBlock([]int{8080, 8081}, 3))
+	assert.Equal(t, 3, portBlock([]int{8080, 8082}, 3), "8080 and 8082 shifted by 2 would collide")
+	assert.Equal(t, 2, portBlock([]int{8080, 9090}, 3))
+	assert.Equal(t, 1, portBlock([]int{8080, 8080}, 2))
+	assert.Equal(t, 1, portBlock(nil, 2))
+}
+
+func TestPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	assert.True(t, portInUse([]string{"svc/web"}, port))
+	assert.True(t, portInUse([]string{"--address", "localhost,127.0.0.1"}, port))
+	listener.Close()
+	assert.False(t, portInUse([]string{"svc/web"}, port))
+}
+
+func TestRunPortForwardReportsPortsInUse(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	err = runPortForward([]string{"svc/web", strconv.Itoa(port - 1)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local ports already in use: ")
+	assert.Contains(t, err.Error(), fmt.Sprintf("%d (ctx2)", port))
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// progressMode is the --progress setting: bar, status, plain, none, or empty
+// for bar on a terminal and none otherwise.
+var progressMode string
+
+func validateProgressMode() error {
+	switch progressMode {
+	case "", "bar", "status", "plain", "none":
+		return nil
+	}
+	return fmt.Errorf("invalid --progress %q: must be bar, status, plain, or none", progressMode)
+}
+
+// progressDisplay reports the progress of a fan-out across contexts.
+type progressDisplay interface {
+	contextStarted(context string)
+	contextDone(context string, err error)
+	finish()
+}
+
+// newProgressDisplay returns the display for --progress, or nil when
+// progress is not shown, as while the pager owns the terminal.
+func newProgressDisplay(contexts []string) progressDisplay {
+	if progressDisabled || pagedStdout != nil {
+		return nil
+	}
+	switch progressMode {
+	case "bar":
+		return newProgressBar(len(contexts))
+	case "status":
+		return newStatusProgress(os.Stderr, contexts)
+	case "plain":
+		return newPlainProgress(os.Stderr, len(contexts))
+	case "":
+		if stderrIsTerminal() && ansiStderr {
+			return newProgressBar(len(contexts))
+		}
+	}
+	return nil
+}
+
+// plainProgress prints a line per finished context, such as
+// "prod-eu done (3/40)", which reads well in CI logs.
+type plainProgress struct {
+	mu        sync.Mutex
+	w         io.Writer
+	completed int
+	total     int
+}
+
+func newPlainProgress(w io.Writer, total int) *plainProgress {
+	return &plainProgress{w: w, total: total}
+}
+
+func (p *plainProgress) contextStarted(string) {}
+
+func (p *plainProgress) contextDone(context string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	status := "done"
+	if err != nil {
+		status = "failed"
+	}
+	fmt.Fprintf(p.w, "%s %s (%d/%d)\n", context, status, p.completed, p.total)
+}
+
+func (p *plainProgress) finish() {}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setProgressMode(t *testing.T, mode string) {
+	t.Helper()
+	progressMode = mode
+	t.Cleanup(func() { progressMode = "" })
+}
+
+func TestValidateProgressMode(t *testing.T) {
+	for _, mode := range []string{"", "bar", "plain", "none"} {
+		setProgressMode(t, mode)
+		assert.NoError(t, validateProgressMode())
+	}
+	setProgressMode(t, "dots")
+	assert.ErrorContains(t, validateProgressMode(), `invalid --progress "dots"`)
+}
+
+func TestNewProgressDisplay(t *testing.T) {
+	assert.Nil(t, newProgressDisplay([]string{"a", "b", "c"}), "auto shows nothing when stderr is not a terminal")
+
+	setProgressMode(t, "plain")
+	assert.IsType(t, &plainProgress{}, newProgressDisplay([]string{"a", "b", "c"}))
+
+	setProgressMode(t, "none")
+	assert.Nil(t, newProgressDisplay([]string{"a", "b", "c"}))
+
+	setProgressMode(t, "bar")
+	bar := newProgressDisplay([]string{"a", "b", "c"})
+	require.IsType(t, &progressBar{}, bar)
+	captureStderr(bar.finish)
+
+	setProgressMode(t, "plain")
+	progressDisabled = true
+	t.Cleanup(func() { progressDisabled = false })
+	assert.Nil(t, newProgressDisplay([]string{"a", "b", "c"}))
+}
+
+func TestPlainProgress(t *testing.T) {
+	var out bytes.Buffer
+	progress := newPlainProgress(&out, 2)
+	progress.contextStarted("prod")
+	progress.contextDone("prod", nil)
+	progress.contextDone("dev", fmt.Errorf("timeout"))
+	progress.finish()
+	assert.Equal(t, "prod done (1/2)\ndev failed (2/2)\n", out.String())
+}
+
+func TestRunAcrossContextsPlainProgress(t *testing.T) {
+	setProgressMode(t, "plain")
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		return "ok", nil
+	})
+
+	stderr := captureStderr(func() {
+		runAcrossContexts([]string{"ctx1", "ctx2"}, "get", []string{"pods"})
+	})
+	assert.Contains(t, stderr, "ctx1 done (")
+	assert.Contains(t, stderr, "ctx2 done (")
+	assert.Contains(t, stderr, "(2/2)")
+}
+
+func TestNewProgressDisplayStatus(t *testing.T) {
+	setProgressMode(t, "status")
+	display := newProgressDisplay([]string{"a"})
+	require.IsType(t, &statusProgress{}, display)
+	captureStderr(display.finish)
+}
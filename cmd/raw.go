@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var rawCmd = &cobra.Command{
+	Use:                "raw",
+	Short:              "Request a raw API path from every context",
+	Long:               `Run kubectl get --raw <path> against all contexts in parallel and print a per-context response table, e.g. kubectl x raw /readyz. Multi-line responses are printed with a context prefix on every line instead.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("raw requires an API path, e.g. /readyz")
+		}
+		contexts, err := getContexts()
+		if err != nil {
+			return fmt.Errorf("failed to get contexts: %w", err)
+		}
+		getArgs := append([]string{"--raw"}, args...)
+		return formatRawResponses(runAcrossContexts(contexts, "get", getArgs))
+	},
+}
+
+func formatRawResponses(results []contextResult) error {
+	for _, result := range results {
+		if result.err == nil && strings.Contains(strings.TrimSpace(result.output), "\n") {
+			return formatRawOutput(results)
+		}
+	}
+
+	var rows [][]string
+	for _, result := range results {
+		status := "OK"
+		if result.err != nil {
+			status = "ERROR"
+		}
+		rows = append(rows, []string{result.context, status, lastLine(result.output)})
+	}
+	printContextTable(os.Stdout, []string{"CONTEXT", "STATUS", "RESPONSE"}, rows)
+	return nil
+}
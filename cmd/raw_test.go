@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawCmd(t *testing.T) {
+	require.NotNil(t, rawCmd)
+	assert.Equal(t, "raw", rawCmd.Use)
+	assert.True(t, rawCmd.DisableFlagParsing)
+}
+
+func TestFormatRawResponses(t *testing.T) {
+	t.Run("single-line responses as table", func(t *testing.T) {
+		output := captureStdout(func() {
+			require.NoError(t, formatRawResponses([]contextResult{
+				{context: "ctx1", output: "ok"},
+				{context: "ctx2", output: "Error from server (InternalError): an error on the server (\"[-]etcd failed\") has prevented the request from succeeding\n", err: fmt.Errorf("exit status 1")},
+			}))
+		})
+		assert.Equal(t, "CONTEXT  STATUS    RESPONSE\n"+
+			"ctx1     OK        ok\n"+
+			"ctx2     ERROR     Error from server (InternalError): an error on the server (\"[-]etcd failed\") has prevented the request from succeeding\n", output)
+	})
+
+	t.Run("multi-line responses prefixed per line", func(t *testing.T) {
+		output := captureStdout(func() {
+			require.NoError(t, formatRawResponses([]contextResult{
+				{context: "ctx1", output: "[+]ping ok\n[+]etcd ok\nreadyz check passed\n"},
+				{context: "ctx2", output: "ok"},
+			}))
+		})
+		assert.Equal(t, "ctx1  [+]ping ok\nctx1  [+]etcd ok\nctx1  readyz check passed\nctx2  ok\n", output)
+	})
+}
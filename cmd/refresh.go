@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// parseRefresh extracts --refresh, the interval at which refresh mode runs a
+// command again. It returns 0 when the flag is not given.
+func parseRefresh(args []string) (time.Duration, []string, error) {
+	values, args := extractStringFlag(args, "--refresh")
+	if len(values) == 0 {
+		return 0, args, nil
+	}
+	interval, err := time.ParseDuration(values[len(values)-1])
+	if err != nil || interval <= 0 {
+		return 0, nil, fmt.Errorf("invalid --refresh %q: use a duration such as 10s", values[len(values)-1])
+	}
+	return interval, args, nil
+}
+
+// runRefreshing runs fn every interval until interrupted, replacing the
+// screen with its output each time, like watch(1). The output is collected
+// before the screen is cleared, so the previous table stays up while the
+// contexts are queried again.
+func runRefreshing(interval time.Duration, description string, fn func() error) error {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, shutdownSignals...)
+	defer signal.Stop(sigChan)
+	progressDisabled = true
+
+	for {
+		output, err := captureOutput(fn)
+		clear := ""
+		if isTerminal() && ansiStdout {
+			clear = "\033[H\033[2J"
+		}
+		fmt.Printf("%sEvery %s: kubectl x %s    %s\n\n%s", clear, interval, description, time.Now().Format("15:04:05"), output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+
+		select {
+		case <-sigChan:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// captureOutput runs fn with stdout and stderr going to one buffer, in the
+// order they were written. Colors still follow the real stdout.
+func captureOutput(fn func() error) (string, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return "", fn()
+	}
+	stdout, stderr, paged := os.Stdout, os.Stderr, pagedStdout
+	if pagedStdout == nil {
+		pagedStdout = stdout
+	}
+	os.Stdout, os.Stderr = writer, writer
+
+	var output strings.Builder
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(&output, reader)
+		close(copied)
+	}()
+
+	err = fn()
+	os.Stdout, os.Stderr, pagedStdout = stdout, stderr, paged
+	writer.Close()
+	<-copied
+	reader.Close()
+	return output.String(), err
+}
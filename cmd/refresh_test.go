@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRefresh(t *testing.T) {
+	interval, args, err := parseRefresh([]string{"pods", "-A"})
+	require.NoError(t, err)
+	assert.Zero(t, interval)
+	assert.Equal(t, []string{"pods", "-A"}, args)
+
+	interval, args, err = parseRefresh([]string{"pods", "--refresh", "10s", "-A"})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, interval)
+	assert.Equal(t, []string{"pods", "-A"}, args)
+
+	_, _, err = parseRefresh([]string{"pods", "--refresh=soon"})
+	assert.ErrorContains(t, err, "invalid --refresh")
+	_, _, err = parseRefresh([]string{"pods", "--refresh=0s"})
+	assert.ErrorContains(t, err, "invalid --refresh")
+}
+
+func TestCaptureOutput(t *testing.T) {
+	stdout, stderr := os.Stdout, os.Stderr
+	output, err := captureOutput(func() error {
+		fmt.Println("CONTEXT  NAME")
+		fmt.Fprintln(os.Stderr, "Context b: Error: exit status 1")
+		fmt.Println("a        web-1")
+		return errors.New("1 context failed")
+	})
+
+	assert.EqualError(t, err, "1 context failed")
+	assert.Equal(t, "CONTEXT  NAME\nContext b: Error: exit status 1\na        web-1\n", output)
+	assert.Equal(t, stdout, os.Stdout)
+	assert.Equal(t, stderr, os.Stderr)
+	assert.Nil(t, pagedStdout)
+}
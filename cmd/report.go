@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var reportFormat string
+var reportFile string
+var ghaSummary bool
+
+func reportingEnabled() bool {
+	return reportFormat != "" || ghaSummaryPath() != ""
+}
+
+// ghaSummaryPath returns the GitHub Actions job summary file, which is written
+// whenever the runner provides one.
+func ghaSummaryPath() string {
+	return os.Getenv("GITHUB_STEP_SUMMARY")
+}
+
+func validateReportFormat() error {
+	if ghaSummary && ghaSummaryPath() == "" {
+		return fmt.Errorf("--gha-summary requires GITHUB_STEP_SUMMARY to be set")
+	}
+	switch reportFormat {
+	case "", "junit":
+		return nil
+	}
+	return fmt.Errorf("invalid --report %q: must be junit", reportFormat)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Output  string `xml:",chardata"`
+}
+
+// junitReport renders one test suite for the command with a test case per
+// context; failed contexts carry kubectl's error as the failure message and
+// its output as the failure text.
+func junitReport(command string, results []contextResult) ([]byte, error) {
+	suite := junitTestSuite{Name: command, Tests: len(results)}
+	for _, result := range results {
+		testCase := junitTestCase{ClassName: command, Name: result.context}
+		if result.duration > 0 {
+			testCase.Time = fmt.Sprintf("%.3f", result.duration.Seconds())
+		}
+		if result.err != nil {
+			suite.Failures++
+			message := result.err.Error()
+			if strings.TrimSpace(result.output) != "" {
+				message = lastLine(result.output)
+			}
+			testCase.Failure = &junitFailure{Message: message, Output: strings.TrimSpace(result.output)}
+		} else {
+			testCase.SystemOut = strings.TrimSpace(result.output)
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render JUnit report: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// markdownSummary renders a job summary section for the command with a row
+// per context.
+func markdownSummary(command string, results []contextResult) string {
+	cell := func(text string) string {
+		return strings.ReplaceAll(text, "|", "\\|")
+	}
+	var summary strings.Builder
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+	}
+	fmt.Fprintf(&summary, "### `%s`\n\n", command)
+	fmt.Fprintf(&summary, "%d of %d contexts succeeded.\n\n", len(results)-failed, len(results))
+	summary.WriteString("| Context | Result | Message |\n| --- | --- | --- |\n")
+	for _, result := range results {
+		status := ":white_check_mark: OK"
+		if result.err != nil {
+			status = ":x: ERROR"
+		}
+		message := ""
+		if strings.TrimSpace(result.output) != "" {
+			message = lastLine(result.output)
+		}
+		fmt.Fprintf(&summary, "| %s | %s | %s |\n", cell(result.context), status, cell(message))
+	}
+	summary.WriteString("\n")
+	return summary.String()
+}
+
+// writeReport writes the requested reports for the results recorded while
+// the command ran: the --report file and, on GitHub Actions, a section of the
+// job summary.
+func writeReport(command string) error {
+	if !reportingEnabled() {
+		return nil
+	}
+	results := recordedResults()
+	if reportFormat == "junit" {
+		data, err := junitReport(command, results)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(reportFile, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	if path := ghaSummaryPath(); path != "" && len(results) > 0 {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open job summary: %w", err)
+		}
+		defer file.Close()
+		if _, err := file.WriteString(markdownSummary(command, results)); err != nil {
+			return fmt.Errorf("failed to write job summary: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableReport(t *testing.T, format string) string {
+	t.Helper()
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	reportFormat = format
+	reportFile = filepath.Join(t.TempDir(), "report.xml")
+	resetRecordedResults()
+	t.Cleanup(func() {
+		reportFormat = ""
+		reportFile = "kubectl-x-report.xml"
+		resetRecordedResults()
+	})
+	return reportFile
+}
+
+func TestValidateReportFormat(t *testing.T) {
+	enableReport(t, "junit")
+	assert.NoError(t, validateReportFormat())
+	reportFormat = "tap"
+	assert.ErrorContains(t, validateReportFormat(), `invalid --report "tap"`)
+
+	reportFormat = ""
+	ghaSummary = true
+	t.Cleanup(func() { ghaSummary = false })
+	assert.ErrorContains(t, validateReportFormat(), "requires GITHUB_STEP_SUMMARY")
+}
+
+func TestMarkdownSummary(t *testing.T) {
+	summary := markdownSummary("kubectl x rollout status deploy/api", []contextResult{
+		{context: "prod", output: "Waiting for rollout...\ndeployment \"api\" successfully rolled out\n"},
+		{context: "staging", output: "error: deployment \"api\" exceeded its progress deadline | retry", err: fmt.Errorf("exit status 1")},
+		{context: "dev", output: "", err: fmt.Errorf("context deadline exceeded")},
+	})
+	assert.Equal(t, "### `kubectl x rollout status deploy/api`\n\n"+
+		"1 of 3 contexts succeeded.\n\n"+
+		"| Context | Result | Message |\n| --- | --- | --- |\n"+
+		"| prod | :white_check_mark: OK | deployment \"api\" successfully rolled out |\n"+
+		"| staging | :x: ERROR | error: deployment \"api\" exceeded its progress deadline \\| retry |\n"+
+		"| dev | :x: ERROR |  |\n\n", summary)
+}
+
+func TestWriteReportAppendsJobSummary(t *testing.T) {
+	enableReport(t, "")
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Deploy\n\n"), 0o644))
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	recordResults([]contextResult{{context: "prod", output: "ok"}})
+	require.NoError(t, writeReport("kubectl x get pods"))
+	summary := readFile(t, path)
+	assert.True(t, strings.HasPrefix(summary, "# Deploy\n\n### `kubectl x get pods`"))
+	assert.Contains(t, summary, "| prod | :white_check_mark: OK | ok |")
+	assert.NoFileExists(t, reportFile)
+}
+
+func TestJUnitReport(t *testing.T) {
+	data, err := junitReport("kubectl x wait --for=condition=Ready pod/web", []contextResult{
+		{context: "prod", output: "pod/web condition met\n"},
+		{context: "staging", output: "error: timed out waiting for the condition <pod/web>", err: fmt.Errorf("exit status 1")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="kubectl x wait --for=condition=Ready pod/web" tests="2" failures="1">
+    <testcase classname="kubectl x wait --for=condition=Ready pod/web" name="prod">
+      <system-out>pod/web condition met</system-out>
+    </testcase>
+    <testcase classname="kubectl x wait --for=condition=Ready pod/web" name="staging">
+      <failure message="error: timed out waiting for the condition &lt;pod/web&gt;">error: timed out waiting for the condition &lt;pod/web&gt;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, string(data))
+}
+
+func TestWriteReportAfterRun(t *testing.T) {
+	path := enableReport(t, "junit")
+	var mu sync.Mutex
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if context == "ctx2" {
+			return "error: no matching resources found", fmt.Errorf("exit status 1")
+		}
+		return "pod/web condition met", nil
+	})
+
+	runAcrossContexts([]string{"ctx1", "ctx2"}, "wait", []string{"--for=condition=Ready", "pod/web"})
+	require.NoError(t, writeReport("kubectl x wait"))
+	report := readFile(t, path)
+	assert.Contains(t, report, `tests="2" failures="1"`)
+	assert.Contains(t, report, `name="ctx2" time="`)
+	assert.Contains(t, report, `<failure message="error: no matching resources found">`)
+}
+
+func TestWriteReportDisabled(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	reportFile = filepath.Join(t.TempDir(), "report.xml")
+	t.Cleanup(func() { reportFile = "kubectl-x-report.xml" })
+	require.NoError(t, writeReport("kubectl x get pods"))
+	assert.NoFileExists(t, reportFile)
+}
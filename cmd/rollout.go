@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var rolloutCmd = &cobra.Command{
+	Use:                "rollout",
+	Short:              "Run kubectl rollout against all contexts",
+	Long:               `Run kubectl rollout subcommands against all contexts in parallel. "rollout status" streams progress and finishes with a per-context status table. "rollout history" merges revision tables under a CONTEXT column. "rollout restart" and "rollout undo" ask for confirmation (skip with --yes) before running; "rollout undo" also accepts per-context revisions via --revisions ctx=N or --revisions-file. --canary runs "rollout restart" and "rollout undo" against a subset of contexts first.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			switch args[0] {
+			case "status":
+				return runRolloutStatus(args)
+			case "restart":
+				return runConfirmedCommand("rollout", args)
+			case "history":
+				return runRolloutHistory(args)
+			case "undo":
+				return runRolloutUndo(args)
+			}
+		}
+		return runCommand("rollout", args)
+	},
+}
+
+const (
+	rolloutOK          = "OK"
+	rolloutProgressing = "Progressing"
+	rolloutFailed      = "Failed"
+)
+
+func runRolloutStatus(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	results := runStreamingAcrossContexts(contexts, "rollout", args)
+
+	return formatRolloutStatus(results)
+}
+
+// classifyRolloutStatus reads kubectl's last status line as well as its exit
+// code: with --watch=false kubectl exits 0 after a "Waiting for ..." line
+// when the rollout has not "successfully rolled out" yet.
+func classifyRolloutStatus(result contextResult) string {
+	last := lastLine(result.output)
+	switch {
+	case strings.Contains(result.output, "exceeded its progress deadline"):
+		return rolloutFailed
+	case strings.HasPrefix(last, "Waiting"), strings.Contains(last, "timed out waiting"):
+		return rolloutProgressing
+	case result.err != nil:
+		return rolloutFailed
+	}
+	return rolloutOK
+}
+
+func formatRolloutStatus(results []contextResult) error {
+	if len(results) == 0 {
+		return fmt.Errorf("no contexts to check the rollout status of")
+	}
+	var rows [][]string
+	notOK := 0
+	for _, result := range results {
+		status := classifyRolloutStatus(result)
+		if status != rolloutOK {
+			notOK++
+		} else if onlyErrors {
+			continue
+		}
+		rows = append(rows, []string{result.context, status, lastLine(result.output)})
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "STATUS", "MESSAGE"}, rows)
+
+	if notOK > 0 {
+		return fmt.Errorf("rollout not complete in %d of %d contexts", notOK, len(results))
+	}
+	return nil
+}
+
+func runRolloutHistory(args []string) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	results := runAcrossContexts(contexts, "rollout", args)
+
+	format := detectOutputFormat(args)
+	if format != formatDefault || hasRevisionFlag(args) || onlyErrors {
+		return formatOutput(results, format, "rollout")
+	}
+	return formatDefaultOutput(stripHistoryTitles(results))
+}
+
+// hasRevisionFlag reports whether a single revision's details were requested,
+// in which case kubectl prints a pod template rather than a table.
+func hasRevisionFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--revision" || strings.HasPrefix(arg, "--revision=") {
+			return true
+		}
+	}
+	return false
+}
+
+// stripHistoryTitles drops the "deployment.apps/foo" title kubectl prints
+// above the REVISION table so the table header is the first line.
+func stripHistoryTitles(results []contextResult) []contextResult {
+	stripped := make([]contextResult, len(results))
+	for i, result := range results {
+		stripped[i] = result
+		if result.err != nil {
+			continue
+		}
+		lines := strings.Split(result.output, "\n")
+		for j, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "REVISION") {
+				stripped[i].output = strings.Join(lines[j:], "\n")
+				break
+			}
+		}
+	}
+	return stripped
+}
+
+func runRolloutUndo(args []string) error {
+	yes, args := extractBoolFlag(args, "--yes", "-y")
+	specs, args := extractStringFlag(args, "--revisions")
+	files, args := extractStringFlag(args, "--revisions-file")
+	canary, args := extractCanary(args)
+
+	revisions, err := parseRevisionSpecs(specs)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		fromFile, err := loadRevisionFile(file)
+		if err != nil {
+			return err
+		}
+		for ctx, rev := range fromFile {
+			revisions[ctx] = rev
+		}
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	plan, err := planRolloutUndo(contexts, args, revisions)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	targets := make([]string, 0, len(plan))
+	for _, ctx := range contexts {
+		if rev, ok := plan[ctx]; ok {
+			rows = append(rows, []string{ctx, rev})
+			targets = append(targets, ctx)
+		}
+	}
+	description := strings.TrimSpace("kubectl rollout " + strings.Join(args, " "))
+	fmt.Fprintf(os.Stderr, "%s will roll back %d contexts:\n", description, len(targets))
+	printContextTable(os.Stderr, []string{"CONTEXT", "TO-REVISION"}, rows)
+	if !confirm("Proceed?", yes) {
+		return fmt.Errorf("aborted")
+	}
+
+	results, canaryErr := runWithCanary(targets, canary, yes, func(contexts []string) []contextResult {
+		return runAcrossContextsFunc(contexts, func(context string) (string, error) {
+			contextArgs := args
+			if len(revisions) > 0 {
+				contextArgs = append(append([]string{}, args...), "--to-revision="+plan[context])
+			}
+			return runKubectlCommand(context, "rollout", contextArgs)
+		})
+	})
+	if results == nil {
+		return canaryErr
+	}
+	if err := formatSummaryOutput(results); canaryErr == nil {
+		return err
+	}
+	return canaryErr
+}
+
+// parseRevisionSpecs parses --revisions values of the form "ctx=3,ctx2=4".
+func parseRevisionSpecs(specs []string) (map[string]string, error) {
+	revisions := make(map[string]string)
+	for _, spec := range specs {
+		for _, pair := range strings.Split(spec, ",") {
+			ctx, rev, ok := strings.Cut(pair, "=")
+			if !ok || ctx == "" {
+				return nil, fmt.Errorf("invalid revision %q: expected <context>=<revision>", pair)
+			}
+			if _, err := strconv.ParseUint(rev, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid revision %q for context %q", rev, ctx)
+			}
+			revisions[ctx] = rev
+		}
+	}
+	return revisions, nil
+}
+
+// loadRevisionFile reads a YAML (or JSON) map of context name to revision.
+func loadRevisionFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revisions file: %w", err)
+	}
+	var raw map[string]int
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse revisions file: %w", err)
+	}
+	revisions := make(map[string]string, len(raw))
+	for ctx, rev := range raw {
+		if rev < 0 {
+			return nil, fmt.Errorf("invalid revision %d for context %q", rev, ctx)
+		}
+		revisions[ctx] = strconv.Itoa(rev)
+	}
+	return revisions, nil
+}
+
+// planRolloutUndo decides which revision each context rolls back to. With a
+// per-context map only the mapped contexts are targeted; otherwise every
+// context uses --to-revision (or the previous revision when it is absent).
+func planRolloutUndo(contexts []string, args []string, revisions map[string]string) (map[string]string, error) {
+	toRevision, _ := extractStringFlag(args, "--to-revision")
+	plan := make(map[string]string)
+
+	if len(revisions) == 0 {
+		target := "previous"
+		if len(toRevision) > 0 {
+			target = toRevision[len(toRevision)-1]
+		}
+		for _, ctx := range contexts {
+			plan[ctx] = target
+		}
+		return plan, nil
+	}
+
+	if len(toRevision) > 0 {
+		return nil, fmt.Errorf("--to-revision cannot be combined with --revisions or --revisions-file")
+	}
+
+	known := make(map[string]bool, len(contexts))
+	for _, ctx := range contexts {
+		known[ctx] = true
+	}
+	var unknown []string
+	for ctx, rev := range revisions {
+		if !known[ctx] {
+			unknown = append(unknown, ctx)
+			continue
+		}
+		plan[ctx] = rev
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("revisions given for contexts that are not targeted: %s", strings.Join(unknown, ", "))
+	}
+	return plan, nil
+}
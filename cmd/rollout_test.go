@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutCmd(t *testing.T) {
+	require.NotNil(t, rolloutCmd)
+	assert.Equal(t, "rollout", rolloutCmd.Use)
+	assert.True(t, rolloutCmd.DisableFlagParsing)
+}
+
+func TestClassifyRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   contextResult
+		expected string
+	}{
+		{
+			name:     "successful rollout",
+			result:   contextResult{output: `deployment "app" successfully rolled out`},
+			expected: rolloutOK,
+		},
+		{
+			name:     "timed out while waiting",
+			result:   contextResult{output: "Waiting for deployment \"app\" rollout to finish: 1 of 3 updated replicas are available...\nerror: timed out waiting for the condition", err: fmt.Errorf("exit status 1")},
+			expected: rolloutProgressing,
+		},
+		{
+			name:     "not finished without watching",
+			result:   contextResult{output: "Waiting for deployment \"app\" rollout to finish: 1 of 3 updated replicas are available...\n"},
+			expected: rolloutProgressing,
+		},
+		{
+			name:     "statefulset partitioned rollout",
+			result:   contextResult{output: "partitioned roll out complete: 2 new pods have been updated...\n"},
+			expected: rolloutOK,
+		},
+		{
+			name:     "progress deadline exceeded",
+			result:   contextResult{output: `error: deployment "app" exceeded its progress deadline`, err: fmt.Errorf("exit status 1")},
+			expected: rolloutFailed,
+		},
+		{
+			name:     "resource not found",
+			result:   contextResult{output: `Error from server (NotFound): deployments.apps "app" not found`, err: fmt.Errorf("exit status 1")},
+			expected: rolloutFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyRolloutStatus(tt.result))
+		})
+	}
+}
+
+func TestFormatRolloutStatus(t *testing.T) {
+	t.Run("all contexts rolled out", func(t *testing.T) {
+		results := []contextResult{
+			{context: "ctx1", output: "Waiting...\ndeployment \"app\" successfully rolled out\n"},
+			{context: "ctx2", output: "deployment \"app\" successfully rolled out\n"},
+		}
+		var err error
+		output := captureStdout(func() {
+			err = formatRolloutStatus(results)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "CONTEXT  STATUS    MESSAGE\n"+
+			"ctx1     OK        deployment \"app\" successfully rolled out\n"+
+			"ctx2     OK        deployment \"app\" successfully rolled out\n", output)
+	})
+
+	t.Run("returns error when no context was checked", func(t *testing.T) {
+		err := formatRolloutStatus(nil)
+		assert.EqualError(t, err, "no contexts to check the rollout status of")
+	})
+
+	t.Run("returns error when any context is not OK", func(t *testing.T) {
+		results := []contextResult{
+			{context: "ctx1", output: "deployment \"app\" successfully rolled out\n"},
+			{context: "ctx2", output: "error: timed out waiting for the condition\n", err: fmt.Errorf("exit status 1")},
+		}
+		var err error
+		output := captureStdout(func() {
+			err = formatRolloutStatus(results)
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 2 contexts")
+		assert.Contains(t, output, "ctx2     Progressing")
+	})
+}
+
+func TestHasRevisionFlag(t *testing.T) {
+	assert.False(t, hasRevisionFlag([]string{"history", "deploy/app"}))
+	assert.True(t, hasRevisionFlag([]string{"history", "deploy/app", "--revision=2"}))
+	assert.True(t, hasRevisionFlag([]string{"history", "deploy/app", "--revision", "2"}))
+}
+
+func TestStripHistoryTitles(t *testing.T) {
+	results := []contextResult{
+		{context: "ctx1", output: "deployment.apps/app \nREVISION  CHANGE-CAUSE\n1         <none>\n2         <none>\n\n"},
+		{context: "ctx2", output: "error: not found", err: fmt.Errorf("exit status 1")},
+	}
+
+	stripped := stripHistoryTitles(results)
+	assert.Equal(t, "REVISION  CHANGE-CAUSE\n1         <none>\n2         <none>\n\n", stripped[0].output)
+	assert.Equal(t, "error: not found", stripped[1].output)
+	assert.Equal(t, "deployment.apps/app \nREVISION  CHANGE-CAUSE\n1         <none>\n2         <none>\n\n", results[0].output, "input should not be modified")
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput(stripped[:1]))
+	})
+	assert.Equal(t, "CONTEXT  REVISION    CHANGE-CAUSE\nctx1     1           <none>\nctx1     2           <none>\n", output)
+}
+
+func TestParseRevisionSpecs(t *testing.T) {
+	revisions, err := parseRevisionSpecs([]string{"ctx1=3,ctx2=4", "ctx3=1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ctx1": "3", "ctx2": "4", "ctx3": "1"}, revisions)
+
+	_, err = parseRevisionSpecs([]string{"ctx1"})
+	assert.Error(t, err)
+
+	_, err = parseRevisionSpecs([]string{"ctx1=latest"})
+	assert.Error(t, err)
+}
+
+func TestLoadRevisionFile(t *testing.T) {
+	path := t.TempDir() + "/revisions.yaml"
+	require.NoError(t, os.WriteFile(path, []byte("prod-us: 3\nprod-eu: 5\n"), 0600))
+
+	revisions, err := loadRevisionFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"prod-us": "3", "prod-eu": "5"}, revisions)
+
+	_, err = loadRevisionFile(t.TempDir() + "/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestPlanRolloutUndo(t *testing.T) {
+	contexts := []string{"ctx1", "ctx2"}
+
+	t.Run("previous revision everywhere", func(t *testing.T) {
+		plan, err := planRolloutUndo(contexts, []string{"undo", "deploy/app"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ctx1": "previous", "ctx2": "previous"}, plan)
+	})
+
+	t.Run("single --to-revision", func(t *testing.T) {
+		plan, err := planRolloutUndo(contexts, []string{"undo", "deploy/app", "--to-revision=2"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ctx1": "2", "ctx2": "2"}, plan)
+	})
+
+	t.Run("per-context map targets only mapped contexts", func(t *testing.T) {
+		plan, err := planRolloutUndo(contexts, []string{"undo", "deploy/app"}, map[string]string{"ctx2": "7"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ctx2": "7"}, plan)
+	})
+
+	t.Run("map and --to-revision conflict", func(t *testing.T) {
+		_, err := planRolloutUndo(contexts, []string{"undo", "--to-revision", "2"}, map[string]string{"ctx2": "7"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown context in map", func(t *testing.T) {
+		_, err := planRolloutUndo(contexts, []string{"undo"}, map[string]string{"ctx9": "7"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ctx9")
+	})
+}
+
+func TestRunRolloutUndoPerContextRevisions(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	calls := make(map[string][]string)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[context] = extraArgs
+		return "deployment.apps/app rolled back\n", nil
+	})
+
+	var err error
+	var stderr string
+	captureStdout(func() {
+		stderr = captureStderr(func() {
+			err = runRolloutUndo([]string{"undo", "deploy/app", "--revisions", "ctx1=3", "--yes"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "ctx1     3")
+	assert.Equal(t, map[string][]string{"ctx1": {"undo", "deploy/app", "--to-revision=3"}}, calls)
+}
+
+func TestRunRolloutUndoCanary(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	var mu sync.Mutex
+	var calls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, context+" "+strings.Join(extraArgs, " "))
+		return "deployment.apps/app rolled back\n", nil
+	})
+
+	var err error
+	captureStdout(func() {
+		captureStderr(func() {
+			err = runRolloutUndo([]string{"undo", "deploy/app", "--revisions", "ctx1=3,ctx2=4", "--canary", "ctx2", "--yes"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctx2 undo deploy/app --to-revision=4", "ctx1 undo deploy/app --to-revision=3"}, calls)
+}
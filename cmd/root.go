@@ -1,30 +1,148 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 var batchSize int = 25
 var filterPatterns []string
 var excludePatterns []string
+var contextNames []string
+var kubeconfigPaths []string
+var tagExpressions []string
+var forcedNamespace string
+var preserveNamespace bool
+var skipUnreachable bool
+var profileName string
+var discoverSpecs []string
+var groupNames []string
+var typedList bool
+var noHeaders bool
+var hideContext bool
+var groupByContext bool
 
 var rootCmd = &cobra.Command{
 	Use:              "kubectl x",
 	Short:            "Run kubectl commands against every context in kubeconfig",
 	Long:             `kubectl x executes commands against all contexts in your kubeconfig file in parallel.`,
 	TraverseChildren: true, // this lets us use root-level flags, but still allow subcommands to disable flag parsing
+	// A failed fan-out is not a usage mistake, so errors never print the
+	// usage; --help still does.
+	SilenceUsage: true,
 }
 
+// hoistedFlags are root flags that may also be written after the subcommand.
+// Subcommands disable flag parsing, so without hoisting they would be
+// forwarded to kubectl. Only long forms are hoisted since short ones such as
+// -i clash with kubectl flags.
+var hoistedFlags = []string{"--include", "--filter", "--exclude", "--kubeconfig", "--tag", "--group", "--report", "--report-file", "--grep", "--grep-v", "--color", "--progress", "--max-line-bytes"}
+
+// hoistedBoolFlags are boolean root flags hoisted the same way.
+var hoistedBoolFlags = []string{"--typed-list", "--gha-summary", "--no-headers", "--hide-context", "--group-by-context", "--timing", "--errors-inline", "--only-errors", "--ignore-not-found-types", "--no-summary", "--legend", "--no-pager"}
+
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	enableANSI()
+	rootCmd.SetArgs(hoistRootFlags(os.Args[1:]))
+	err := rootCmd.Execute()
+	stopPager()
+	if results := recordedResults(); len(results) > 0 && !noSummary {
+		fmt.Fprintln(os.Stderr, summaryFooter(results, time.Since(start)))
+	}
+	if reportErr := writeReport(strings.Join(append([]string{"kubectl x"}, os.Args[1:]...), " ")); reportErr != nil && err == nil {
+		err = reportErr
+	}
+	return err
+}
+
+// hoistRootFlags moves hoistedFlags in front of the subcommand so cobra parses
+// them as root flags. Arguments after "--" are left alone.
+func hoistRootFlags(args []string) []string {
+	head, tail := args, []string(nil)
+	for i, arg := range args {
+		if arg == "--" {
+			head, tail = args[:i], args[i:]
+			break
+		}
+	}
+
+	var hoisted []string
+	for _, name := range hoistedFlags {
+		var values []string
+		values, head = extractStringFlag(head, name)
+		for _, value := range values {
+			hoisted = append(hoisted, name+"="+value)
+		}
+	}
+
+	for _, name := range hoistedBoolFlags {
+		var found bool
+		found, head = extractBoolFlag(head, name)
+		if found {
+			hoisted = append(hoisted, name)
+		}
+	}
+
+	result := append(hoisted, head...)
+	return append(result, tail...)
+}
+
+// ExitError is returned by commands that need a specific process exit code,
+// such as diff signalling drift.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
 }
 
 func init() {
+	rootCmd.PersistentPreRunE = loadAndApplyConfig
 	rootCmd.PersistentFlags().IntVarP(&batchSize, "batch-size", "b", 25, "Number of contexts to process in parallel")
 	rootCmd.PersistentFlags().StringArrayVarP(&filterPatterns, "include", "i", []string{}, "Include contexts by name using regex pattern (can be specified multiple times for OR logic)")
 	rootCmd.PersistentFlags().StringArrayVar(&filterPatterns, "filter", []string{}, "Alias for --include")
 	rootCmd.PersistentFlags().MarkDeprecated("filter", "use --include instead")
 	rootCmd.PersistentFlags().StringArrayVarP(&excludePatterns, "exclude", "e", []string{}, "Exclude contexts by name using regex pattern (can be specified multiple times for OR logic)")
+	rootCmd.PersistentFlags().StringArrayVarP(&contextNames, "context", "c", []string{}, "Target exactly these contexts, bypassing --include/--exclude (can be specified multiple times or comma-separated)")
+	rootCmd.PersistentFlags().StringArrayVar(&kubeconfigPaths, "kubeconfig", []string{}, "Kubeconfig file to load contexts from instead of KUBECONFIG (can be specified multiple times)")
+	rootCmd.PersistentFlags().StringArrayVar(&tagExpressions, "tag", []string{}, "Select contexts by kubeconfig tags, e.g. env=prod,region!=us (terms are AND'd; repeated flags are OR'd)")
+	rootCmd.PersistentFlags().StringVarP(&forcedNamespace, "namespace", "n", "", "Run in this namespace in every context instead of each context's default namespace")
+	rootCmd.PersistentFlags().BoolVar(&preserveNamespace, "preserve-namespace", false, "Use each context's default namespace without noting when they differ")
+	rootCmd.MarkFlagsMutuallyExclusive("namespace", "preserve-namespace")
+	rootCmd.PersistentFlags().BoolVar(&skipUnreachable, "skip-unreachable", false, "Probe contexts first and skip, with a warning, those whose API server does not respond")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Apply a named profile from the config file (defaults to $KUBECTL_X_PROFILE)")
+	rootCmd.PersistentFlags().StringArrayVar(&discoverSpecs, "discover", []string{}, "Add contexts for the clusters discovered from a cloud provider or fleet manager, appended to any --kubeconfig files (KUBECONFIG is then not read), e.g. eks:profile=prod,region=eu-west-1, gke:project=my-project, aks:subscription=ID, capi:hub=mgmt, argocd:hub=mgmt, rancher:url=URL, teleport (can be specified multiple times)")
+	rootCmd.PersistentFlags().StringArrayVar(&groupNames, "group", []string{}, "Select the contexts of a named group from the config file or kubie (can be specified multiple times or comma-separated)")
+	rootCmd.PersistentFlags().BoolVar(&typedList, "typed-list", false, "In -o json/yaml output, use the typed list kind (e.g. PodList) instead of List when every item has the same kind")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report", "", "Also write a report of per-context results; junit writes a JUnit XML test suite with one test case per context")
+	rootCmd.PersistentFlags().StringVar(&reportFile, "report-file", "kubectl-x-report.xml", "File the --report is written to")
+	rootCmd.PersistentFlags().BoolVar(&ghaSummary, "gha-summary", false, "Append a markdown table of per-context results to the GitHub Actions job summary (automatic when GITHUB_STEP_SUMMARY is set)")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "Omit the header row from merged tables; kubectl still prints its headers so columns are detected correctly")
+	rootCmd.PersistentFlags().BoolVar(&hideContext, "hide-context", false, "Omit the CONTEXT column and line prefixes so output looks like plain kubectl output")
+	rootCmd.PersistentFlags().StringArrayVar(&grepPatterns, "grep", []string{}, "Only print merged output rows matching this regex (can be specified multiple times for OR logic)")
+	rootCmd.PersistentFlags().StringArrayVar(&grepInvertPatterns, "grep-v", []string{}, "Drop merged output rows matching this regex (can be specified multiple times)")
+	rootCmd.PersistentFlags().BoolVar(&groupByContext, "group-by-context", false, "Print each context's output under its own section header instead of prefixing every row with the context")
+	rootCmd.PersistentFlags().BoolVar(&showTimings, "timing", false, "Show how long each context took: a DURATION column in merged tables, otherwise a summary on stderr")
+	rootCmd.PersistentFlags().BoolVar(&errorsInline, "errors-inline", false, "Show failed contexts as ERROR rows in merged tables instead of only on stderr")
+	rootCmd.PersistentFlags().BoolVar(&onlyErrors, "only-errors", false, "Print only the contexts that failed, with their error output, on stdout")
+	rootCmd.PersistentFlags().BoolVar(&ignoreMissingTypes, "ignore-not-found-types", false, "Treat contexts that lack the requested resource type (such as a CRD) or namespace as empty instead of failed")
+	rootCmd.PersistentFlags().BoolVar(&noSummary, "no-summary", false, "Do not print the one-line summary of context results to stderr at the end of the run")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Colorize context names: auto (only on a terminal, unless NO_COLOR is set), always, or never")
+	rootCmd.PersistentFlags().BoolVar(&showLegend, "legend", false, "Print the color of each context to stderr before the output")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not pipe output longer than a screen through $PAGER")
+	rootCmd.PersistentFlags().StringVar(&progressMode, "progress", "", "How to show progress on stderr: bar (default on a terminal), status for the live state of every context, plain for a line per finished context, or none")
+	rootCmd.PersistentFlags().IntVar(&maxLineBytes, "max-line-bytes", maxLineBytes, "Longest line of streamed output to read; a context whose output has a longer line stops streaming with an error")
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(getCmd)
@@ -35,4 +153,27 @@ func init() {
 	rootCmd.AddCommand(apiResourcesCmd)
 	rootCmd.AddCommand(apiVersionsCmd)
 	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(rolloutCmd)
+	rootCmd.AddCommand(execCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(scaleCmd)
+	rootCmd.AddCommand(cordonCmd)
+	rootCmd.AddCommand(uncordonCmd)
+	rootCmd.AddCommand(drainCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(portForwardCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(rawCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(contextsCmd)
+	rootCmd.AddCommand(pingCmd)
+	rootCmd.AddCommand(findCmd)
+	rootCmd.AddCommand(existsCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(imagesCmd)
+	rootCmd.AddCommand(nodesCmd)
+	rootCmd.AddCommand(summaryCmd)
+	rootCmd.AddCommand(dashCmd)
 }
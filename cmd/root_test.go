@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"errors"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,7 +17,7 @@ func TestRootCmd(t *testing.T) {
 }
 
 func TestRootCmdHasSubcommands(t *testing.T) {
-	expected := []string{"list", "version", "get", "logs", "top", "wait", "events", "api-resources", "api-versions", "auth"}
+	expected := []string{"list", "version", "get", "logs", "top", "wait", "events", "api-resources", "api-versions", "auth", "rollout", "exec", "diff", "apply", "delete", "scale", "cordon", "uncordon", "drain", "explain", "port-forward", "edit", "raw", "attach", "contexts", "ping", "find", "exists", "compare", "images", "nodes", "summary", "dash"}
 	registered := make(map[string]bool)
 	for _, cmd := range rootCmd.Commands() {
 		registered[cmd.Use] = true
@@ -38,4 +41,113 @@ func TestRootCmdFlags(t *testing.T) {
 
 	excludeFlag := rootCmd.PersistentFlags().Lookup("exclude")
 	require.NotNil(t, excludeFlag)
+
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("kubeconfig"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("tag"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("profile"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("discover"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("group"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("report"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("report-file"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("gha-summary"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("no-headers"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("hide-context"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("grep"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("grep-v"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("group-by-context"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("timing"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("errors-inline"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("only-errors"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("ignore-not-found-types"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("no-summary"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("color"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("legend"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("no-pager"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("progress"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("max-line-bytes"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("preserve-namespace"))
+	namespaceFlag := rootCmd.PersistentFlags().Lookup("namespace")
+	require.NotNil(t, namespaceFlag)
+	assert.Equal(t, "n", namespaceFlag.Shorthand)
+
+	contextFlag := rootCmd.PersistentFlags().Lookup("context")
+	require.NotNil(t, contextFlag)
+	assert.Equal(t, "c", contextFlag.Shorthand)
+}
+
+func TestHoistRootFlags(t *testing.T) {
+	assert.Equal(t,
+		[]string{"--include=prod", "--filter=eu", "get", "pods", "-n", "web"},
+		hoistRootFlags([]string{"get", "pods", "--filter", "eu", "-n", "web", "--include=prod"}))
+	assert.Equal(t,
+		[]string{"--include=prod", "exec", "pod", "--", "grep", "--include", "x"},
+		hoistRootFlags([]string{"--include", "prod", "exec", "pod", "--", "grep", "--include", "x"}))
+	assert.Equal(t, []string{"get", "pods"}, hoistRootFlags([]string{"get", "pods"}))
+	assert.Equal(t,
+		[]string{"--no-headers", "get", "pods", "-w"},
+		hoistRootFlags([]string{"get", "pods", "--no-headers", "-w"}))
+	assert.Equal(t,
+		[]string{"--typed-list", "get", "deploy", "-o", "json"},
+		hoistRootFlags([]string{"get", "deploy", "-o", "json", "--typed-list"}))
+	assert.Equal(t,
+		[]string{"--exclude=staging", "--exclude=dev", "get", "nodes"},
+		hoistRootFlags([]string{"get", "nodes", "--exclude", "staging", "--exclude=dev"}))
+	assert.Equal(t,
+		[]string{"--kubeconfig=/fleet/config", "get", "pods"},
+		hoistRootFlags([]string{"get", "pods", "--kubeconfig", "/fleet/config"}))
+}
+
+func TestFilterAfterSubcommandNarrowsContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	t.Setenv("KUBECTL_X_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Cleanup(func() { filterPatterns = []string{} })
+
+	var mu sync.Mutex
+	var called []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, context)
+		assert.Equal(t, []string{"pods"}, extraArgs)
+		return "NAME  READY\npod-a  1/1\n", nil
+	})
+
+	rootCmd.SetArgs(hoistRootFlags([]string{"get", "pods", "--filter", "prod"}))
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	captureOutputCombined(func() {
+		require.NoError(t, rootCmd.Execute())
+	})
+	assert.Equal(t, []string{"prod-us"}, called)
+}
+
+func TestExcludeAfterSubcommandNarrowsContexts(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod-us", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	t.Setenv("KUBECTL_X_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Cleanup(func() { excludePatterns = []string{} })
+
+	var mu sync.Mutex
+	var called []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, context)
+		assert.Equal(t, []string{"nodes"}, extraArgs)
+		return "NAME  STATUS\nnode-1  Ready\n", nil
+	})
+
+	rootCmd.SetArgs(hoistRootFlags([]string{"get", "nodes", "--exclude", "staging"}))
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	captureOutputCombined(func() {
+		require.NoError(t, rootCmd.Execute())
+	})
+	assert.Equal(t, []string{"prod-us"}, called)
+}
+
+func TestExitError(t *testing.T) {
+	inner := errors.New("2 of 3 contexts have drift")
+	err := error(&ExitError{Code: 1, Err: inner})
+	assert.Equal(t, "2 of 3 contexts have drift", err.Error())
+	assert.ErrorIs(t, err, inner)
 }
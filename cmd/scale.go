@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var scaleCmd = &cobra.Command{
+	Use:                "scale",
+	Short:              "Run kubectl scale against all contexts",
+	Long:               `Show the current replica count in every context, scale after confirmation (skip with --yes), then print a before/after table.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScale(args)
+	},
+}
+
+// scaleValueFlags are kubectl scale flags that take a separate value, which
+// must not be mistaken for resource names.
+var scaleValueFlags = []string{"--replicas", "--current-replicas", "--resource-version", "--timeout", "-n", "--namespace", "-o", "--output", "-l", "--selector", "--field-selector"}
+
+// scaleSelectorArgs returns the -l/--selector and --field-selector flags of
+// a scale, so that the replicas shown are read from the objects that will be
+// scaled. --all needs nothing, since get lists every object of a type.
+func scaleSelectorArgs(args []string) []string {
+	var selectorArgs []string
+	selectors, _ := extractStringFlag(args, "-l", "--selector")
+	for _, selector := range selectors {
+		selectorArgs = append(selectorArgs, "--selector", selector)
+	}
+	fieldSelectors, _ := extractStringFlag(args, "--field-selector")
+	for _, selector := range fieldSelectors {
+		selectorArgs = append(selectorArgs, "--field-selector", selector)
+	}
+	return selectorArgs
+}
+
+// scaleTargets returns the positional resource arguments (e.g. "deploy/web").
+func scaleTargets(args []string) []string {
+	_, rest := extractStringFlag(args, scaleValueFlags...)
+	var targets []string
+	for _, arg := range rest {
+		if !strings.HasPrefix(arg, "-") {
+			targets = append(targets, arg)
+		}
+	}
+	return targets
+}
+
+func runScale(args []string) error {
+	yes, args := extractBoolFlag(args, "--yes", "-y")
+	canary, args := extractCanary(args)
+
+	replicas, _ := extractStringFlag(args, "--replicas")
+	if len(replicas) == 0 {
+		return fmt.Errorf("scale requires --replicas")
+	}
+	desired := replicas[len(replicas)-1]
+
+	targets := scaleTargets(args)
+	if len(targets) == 0 {
+		return fmt.Errorf("scale requires a resource, e.g. deploy/web")
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	getArgs := append(append([]string{}, targets...), namespaceArgs(args)...)
+	getArgs = append(getArgs, scaleSelectorArgs(args)...)
+	getArgs = append(getArgs, "-o", "custom-columns=NAME:.metadata.name,REPLICAS:.spec.replicas", "--no-headers")
+	fetchReplicas := func(contexts []string) []contextResult {
+		return runAcrossContexts(contexts, "get", getArgs)
+	}
+
+	before := fetchReplicas(contexts)
+	var rows [][]string
+	var reachable []string
+	for _, result := range before {
+		if result.err != nil {
+			rows = append(rows, []string{result.context, "-", "ERROR", "-"})
+			continue
+		}
+		for _, object := range parseScaleObjects(result.output) {
+			rows = append(rows, []string{result.context, object.name, object.replicas, desired})
+		}
+		reachable = append(reachable, result.context)
+	}
+
+	fmt.Fprintf(os.Stderr, "kubectl scale %s will change replicas in %d contexts:\n", strings.Join(targets, " "), len(reachable))
+	printContextTable(os.Stderr, []string{"CONTEXT", "NAME", "CURRENT", "DESIRED"}, rows)
+	if len(reachable) == 0 {
+		return fmt.Errorf("could not read the current replica count in any context")
+	}
+	if !confirm("Proceed?", yes) {
+		return fmt.Errorf("aborted")
+	}
+
+	results, canaryErr := runWithCanary(reachable, canary, yes, func(contexts []string) []contextResult {
+		return runAcrossContexts(contexts, "scale", args)
+	})
+	if results == nil {
+		return canaryErr
+	}
+	var scaled []string
+	for _, result := range results {
+		scaled = append(scaled, result.context)
+	}
+	after := fetchReplicas(scaled)
+	if err := formatScaleOutput(before, results, after); canaryErr == nil {
+		return err
+	}
+	return canaryErr
+}
+
+// scaleObject is one object being scaled, read from the NAME and REPLICAS
+// custom columns.
+type scaleObject struct {
+	name     string
+	replicas string
+}
+
+func parseScaleObjects(output string) []scaleObject {
+	var objects []scaleObject
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			objects = append(objects, scaleObject{name: fields[0], replicas: fields[1]})
+		}
+	}
+	return objects
+}
+
+// formatScaleOutput prints a row per scaled object with its replicas before
+// and after, and the result of its context's kubectl scale.
+func formatScaleOutput(before, results, after []contextResult) error {
+	afterCounts := make(map[string]map[string]string, len(after))
+	for _, result := range after {
+		if result.err != nil {
+			continue
+		}
+		counts := make(map[string]string)
+		for _, object := range parseScaleObjects(result.output) {
+			counts[object.name] = object.replicas
+		}
+		afterCounts[result.context] = counts
+	}
+	scaled := make(map[string]contextResult, len(results))
+	for _, result := range results {
+		scaled[result.context] = result
+	}
+
+	var rows [][]string
+	failed := 0
+	for _, result := range before {
+		status := "OK"
+		scaleResult, attempted := scaled[result.context]
+		switch {
+		case !attempted:
+			status = "SKIPPED"
+			failed++
+		case scaleResult.err != nil:
+			status = "ERROR"
+			failed++
+			printContextError(scaleResult)
+		}
+		if status == "OK" && onlyErrors {
+			continue
+		}
+		objects := parseScaleObjects(result.output)
+		if result.err != nil || len(objects) == 0 {
+			rows = append(rows, []string{result.context, "-", "-", "-", status})
+			continue
+		}
+		for _, object := range objects {
+			afterCount, ok := afterCounts[result.context][object.name]
+			if !ok {
+				afterCount = "-"
+			}
+			rows = append(rows, []string{result.context, object.name, object.replicas, afterCount, status})
+		}
+	}
+
+	printContextTable(os.Stdout, []string{"CONTEXT", "NAME", "BEFORE", "AFTER", "RESULT"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d contexts were not scaled", failed, len(before))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleCmd(t *testing.T) {
+	require.NotNil(t, scaleCmd)
+	assert.Equal(t, "scale", scaleCmd.Use)
+	assert.True(t, scaleCmd.DisableFlagParsing)
+}
+
+func TestScaleTargets(t *testing.T) {
+	assert.Equal(t, []string{"deploy/web"}, scaleTargets([]string{"deploy/web", "--replicas", "3", "-n", "web"}))
+	assert.Equal(t, []string{"deployment", "web"}, scaleTargets([]string{"--replicas=3", "deployment", "web"}))
+	assert.Nil(t, scaleTargets([]string{"--replicas", "3"}))
+}
+
+func TestScaleSelectorArgs(t *testing.T) {
+	assert.Nil(t, scaleSelectorArgs([]string{"deploy", "--all", "--replicas=3"}))
+	assert.Equal(t, []string{"--selector", "app=web", "--field-selector", "metadata.name!=api"},
+		scaleSelectorArgs([]string{"deploy", "-l", "app=web", "--field-selector=metadata.name!=api", "--replicas=3"}))
+	assert.Equal(t, []string{"deploy"}, scaleTargets([]string{"deploy", "--field-selector", "metadata.name=web", "--replicas=3"}))
+}
+
+func TestParseScaleObjects(t *testing.T) {
+	assert.Equal(t, []scaleObject{{name: "web", replicas: "2"}, {name: "api", replicas: "<none>"}},
+		parseScaleObjects("web   2\napi   <none>\n"))
+	assert.Nil(t, parseScaleObjects(""))
+}
+
+func TestFormatScaleOutput(t *testing.T) {
+	before := []contextResult{
+		{context: "ctx1", output: "web   2\napi   1\n"},
+		{context: "ctx2", output: "web   2\n"},
+		{context: "ctx3", err: fmt.Errorf("exit status 1")},
+	}
+	results := []contextResult{
+		{context: "ctx1", output: "deployment.apps/web scaled"},
+		{context: "ctx2", output: "error: forbidden", err: fmt.Errorf("exit status 1")},
+	}
+	after := []contextResult{
+		{context: "ctx1", output: "web   5\napi   5\n"},
+		{context: "ctx2", output: "web   2\n"},
+	}
+
+	var err error
+	var output string
+	captureStderr(func() {
+		output = captureStdout(func() {
+			err = formatScaleOutput(before, results, after)
+		})
+	})
+	require.Error(t, err)
+	assert.Equal(t, "2 of 3 contexts were not scaled", err.Error())
+	assert.Equal(t, "CONTEXT  NAME    BEFORE    AFTER    RESULT\n"+
+		"ctx1     web     2         5        OK\n"+
+		"ctx1     api     1         5        OK\n"+
+		"ctx2     web     2         2        ERROR\n"+
+		"ctx3     -       -         -        SKIPPED\n", output)
+}
+
+func TestRunScale(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+
+	t.Run("requires --replicas", func(t *testing.T) {
+		assert.ErrorContains(t, runScale([]string{"deploy/web"}), "--replicas")
+	})
+
+	t.Run("previews, scales and reports", func(t *testing.T) {
+		var mu sync.Mutex
+		replicas := map[string]string{"ctx1": "2", "ctx2": "3"}
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if subcommand == "get" {
+				assert.Equal(t, "deploy/web --namespace web -o custom-columns=NAME:.metadata.name,REPLICAS:.spec.replicas --no-headers", strings.Join(extraArgs, " "))
+				return "web   " + replicas[context] + "\n", nil
+			}
+			replicas[context] = "5"
+			return "deployment.apps/web scaled", nil
+		})
+
+		var err error
+		var output, stderr string
+		stderr = captureStderr(func() {
+			output = captureStdout(func() {
+				err = runScale([]string{"deploy/web", "-n", "web", "--replicas=5", "--yes"})
+			})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "CONTEXT  NAME    CURRENT    DESIRED\nctx1     web     2          5\nctx2     web     3          5\n")
+		assert.Equal(t, "CONTEXT  NAME    BEFORE    AFTER    RESULT\nctx1     web     2         5        OK\nctx2     web     3         5        OK\n", output)
+	})
+	t.Run("stops after a declined canary", func(t *testing.T) {
+		var mu sync.Mutex
+		replicas := map[string]string{"ctx1": "2", "ctx2": "3"}
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if subcommand == "get" {
+				return "web   " + replicas[context] + "\n", nil
+			}
+			replicas[context] = "5"
+			return "deployment.apps/web scaled", nil
+		})
+		old := confirmInput
+		confirmInput = strings.NewReader("y\nn\n")
+		t.Cleanup(func() { confirmInput = old })
+
+		var err error
+		var output string
+		captureStderr(func() {
+			output = captureStdout(func() {
+				err = runScale([]string{"deploy/web", "--replicas=5", "--canary", "ctx2"})
+			})
+		})
+		assert.EqualError(t, err, "stopped after canary; 1 remaining contexts were not changed")
+		assert.Equal(t, "CONTEXT  NAME    BEFORE    AFTER    RESULT\nctx1     web     2         -        SKIPPED\nctx2     web     3         5        OK\n", output)
+	})
+	t.Run("records a failed scale as failed", func(t *testing.T) {
+		resetRecordedResults()
+		t.Cleanup(resetRecordedResults)
+		fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+			if subcommand == "scale" && context == "ctx2" {
+				return "error: deployments.apps \"web\" is forbidden", fmt.Errorf("exit status 1")
+			}
+			if subcommand == "get" {
+				return "web   2\n", nil
+			}
+			return "deployment.apps/web scaled", nil
+		})
+
+		var err error
+		captureStderr(func() {
+			captureStdout(func() {
+				err = runScale([]string{"deploy/web", "--replicas=5", "--yes"})
+			})
+		})
+		assert.EqualError(t, err, "1 of 2 contexts were not scaled")
+		results := recordedResults()
+		require.Len(t, results, 2)
+		assert.NoError(t, results[0].err)
+		assert.Equal(t, "ctx2", results[1].context)
+		assert.EqualError(t, results[1].err, "exit status 1")
+	})
+}
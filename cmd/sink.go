@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// sinkHTTPClient is used to push log lines to a --sink; tests replace it.
+var sinkHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+const (
+	sinkFlushInterval = time.Second
+	sinkMaxBatch      = 500
+	// sinkQueueSize is how many lines wait for the flusher before new ones
+	// are dropped, so a slow sink never holds up printing.
+	sinkQueueSize = 10000
+)
+
+// sinkEntry is one log line as shipped to a sink.
+type sinkEntry struct {
+	At        time.Time `json:"time"`
+	Context   string    `json:"context"`
+	Pod       string    `json:"pod,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Line      string    `json:"line"`
+}
+
+// logSink forwards log lines to an external service in batches, in addition
+// to printing them. Lines are queued for the goroutine in run, which alone
+// touches pending.
+type logSink struct {
+	url    string
+	encode func(entries []sinkEntry) ([]byte, error)
+
+	queue   chan sinkEntry
+	dropped atomic.Int64
+	pending []sinkEntry
+}
+
+// parseLogSink extracts --sink. loki://host:port and lokis://host:port push
+// to Loki's push API; http:// and https:// URLs are sent a JSON array of
+// entries.
+func parseLogSink(args []string) (*logSink, []string, error) {
+	values, args := extractStringFlag(args, "--sink")
+	if len(values) == 0 {
+		return nil, args, nil
+	}
+	sink, err := newLogSink(values[len(values)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sink, args, nil
+}
+
+func newLogSink(spec string) (*logSink, error) {
+	scheme, rest, _ := strings.Cut(spec, "://")
+	switch scheme {
+	case "loki", "lokis":
+		protocol := "http"
+		if scheme == "lokis" {
+			protocol = "https"
+		}
+		url := protocol + "://" + strings.TrimSuffix(rest, "/")
+		if !strings.Contains(rest, "/") {
+			url += "/loki/api/v1/push"
+		}
+		return &logSink{url: url, encode: encodeLokiPush, queue: make(chan sinkEntry, sinkQueueSize)}, nil
+	case "http", "https":
+		return &logSink{url: spec, encode: encodeWebhook, queue: make(chan sinkEntry, sinkQueueSize)}, nil
+	}
+	return nil, fmt.Errorf("invalid --sink %q: use loki://host:port, lokis://host:port, or an http(s) URL", spec)
+}
+
+// sourceOf splits the part of a label after the context, such as
+// "web-1[app]", into pod and container.
+func sourceOf(source string) (string, string) {
+	pod, container, _ := strings.Cut(strings.TrimSuffix(source, "]"), "[")
+	return pod, container
+}
+
+// send queues a line without waiting. When the queue is full, because the
+// sink is slower than the logs, the line is dropped and counted.
+func (s *logSink) send(entry sinkEntry) {
+	select {
+	case s.queue <- entry:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// flush pushes the pending and queued lines in batches, reporting failures
+// and dropped lines on stderr. Lines that could not be pushed are dropped
+// rather than held back.
+func (s *logSink) flush() {
+	for queued := true; queued; {
+		select {
+		case entry := <-s.queue:
+			s.pending = append(s.pending, entry)
+		default:
+			queued = false
+		}
+	}
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		fmt.Fprintf(os.Stderr, "Sink: dropped %d lines while the sink was behind\n", dropped)
+	}
+	entries := s.pending
+	s.pending = nil
+	for len(entries) > 0 {
+		batch := entries[:min(len(entries), sinkMaxBatch)]
+		entries = entries[len(batch):]
+		if err := s.push(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "Sink: failed to push %d lines: %v\n", len(batch), err)
+		}
+	}
+}
+
+func (s *logSink) push(entries []sinkEntry) error {
+	body, err := s.encode(entries)
+	if err != nil {
+		return err
+	}
+	response, err := sinkHTTPClient.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("%s returned %s", s.url, response.Status)
+	}
+	return nil
+}
+
+// run collects queued lines and flushes them every sinkFlushInterval, or
+// as soon as a batch is full, until stop is closed, then once more.
+func (s *logSink) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(sinkFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			s.flush()
+			return
+		case entry := <-s.queue:
+			s.pending = append(s.pending, entry)
+			if len(s.pending) >= sinkMaxBatch {
+				s.flush()
+			}
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+func encodeWebhook(entries []sinkEntry) ([]byte, error) {
+	return json.Marshal(entries)
+}
+
+// encodeLokiPush builds a Loki push request with a stream per context, pod,
+// and container.
+func encodeLokiPush(entries []sinkEntry) ([]byte, error) {
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	streams := make(map[string]*stream)
+	var keys []string
+	for _, entry := range entries {
+		key := entry.Context + "\x00" + entry.Pod + "\x00" + entry.Container
+		if streams[key] == nil {
+			labels := map[string]string{"job": "kubectl-x", "context": entry.Context}
+			if entry.Pod != "" {
+				labels["pod"] = entry.Pod
+			}
+			if entry.Container != "" {
+				labels["container"] = entry.Container
+			}
+			streams[key] = &stream{Stream: labels}
+			keys = append(keys, key)
+		}
+		streams[key].Values = append(streams[key].Values, [2]string{strconv.FormatInt(entry.At.UnixNano(), 10), entry.Line})
+	}
+	sort.Strings(keys)
+
+	var request struct {
+		Streams []*stream `json:"streams"`
+	}
+	for _, key := range keys {
+		request.Streams = append(request.Streams, streams[key])
+	}
+	return json.Marshal(request)
+}
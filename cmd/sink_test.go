@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantURL string
+		wantErr bool
+	}{
+		{name: "none", args: []string{"web"}},
+		{name: "loki", args: []string{"web", "--sink", "loki://loki:3100"}, wantURL: "http://loki:3100/loki/api/v1/push"},
+		{name: "lokis", args: []string{"web", "--sink=lokis://loki.example.com"}, wantURL: "https://loki.example.com/loki/api/v1/push"},
+		{name: "loki with path", args: []string{"web", "--sink", "loki://gw:8080/push"}, wantURL: "http://gw:8080/push"},
+		{name: "webhook", args: []string{"web", "--sink", "https://hooks.example.com/logs"}, wantURL: "https://hooks.example.com/logs"},
+		{name: "unknown scheme", args: []string{"web", "--sink", "ftp://x"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, rest, err := parseLogSink(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"web"}, rest)
+			if tt.wantURL == "" {
+				assert.Nil(t, sink)
+				return
+			}
+			require.NotNil(t, sink)
+			assert.Equal(t, tt.wantURL, sink.url)
+		})
+	}
+}
+
+func TestSourceOf(t *testing.T) {
+	pod, container := sourceOf("web-1[app]")
+	assert.Equal(t, "web-1", pod)
+	assert.Equal(t, "app", container)
+
+	pod, container = sourceOf("web-1")
+	assert.Equal(t, "web-1", pod)
+	assert.Empty(t, container)
+
+	pod, container = sourceOf("")
+	assert.Empty(t, pod)
+	assert.Empty(t, container)
+}
+
+func TestEncodeLokiPush(t *testing.T) {
+	at := time.Unix(1700000000, 5)
+	body, err := encodeLokiPush([]sinkEntry{
+		{At: at, Context: "prod", Pod: "web-1", Container: "app", Line: "one"},
+		{At: at, Context: "dev", Line: "two"},
+		{At: at.Add(time.Second), Context: "prod", Pod: "web-1", Container: "app", Line: "three"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"streams": [
+		{"stream": {"job": "kubectl-x", "context": "dev"}, "values": [["1700000000000000005", "two"]]},
+		{"stream": {"job": "kubectl-x", "context": "prod", "pod": "web-1", "container": "app"},
+		 "values": [["1700000000000000005", "one"], ["1700000001000000005", "three"]]}
+	]}`, string(body))
+}
+
+func TestLogSinkFlush(t *testing.T) {
+	var received []sinkEntry
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var entries []sinkEntry
+		require.NoError(t, json.Unmarshal(body, &entries))
+		received = append(received, entries...)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := newLogSink(server.URL)
+	require.NoError(t, err)
+
+	sink.flush()
+	assert.Empty(t, received)
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sink.send(sinkEntry{At: at, Context: "prod", Pod: "web-1", Line: "hello"})
+	sink.flush()
+	require.Len(t, received, 1)
+	assert.Equal(t, "hello", received[0].Line)
+	assert.Equal(t, "web-1", received[0].Pod)
+	assert.True(t, at.Equal(received[0].At))
+
+	status = http.StatusInternalServerError
+	sink.send(sinkEntry{At: at, Context: "prod", Line: "lost"})
+	stderr := captureStderr(sink.flush)
+	assert.Contains(t, stderr, "Sink: failed to push 1 lines")
+	assert.Empty(t, sink.pending)
+}
+
+func TestLogSinkDropsLinesWhenFull(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var entries []sinkEntry
+		require.NoError(t, json.Unmarshal(body, &entries))
+		batches = append(batches, len(entries))
+	}))
+	defer server.Close()
+
+	sink := &logSink{url: server.URL, encode: encodeWebhook, queue: make(chan sinkEntry, sinkMaxBatch+1)}
+	for i := 0; i < sinkMaxBatch+3; i++ {
+		sink.send(sinkEntry{Context: "prod", Line: "line"})
+	}
+	assert.Equal(t, int64(2), sink.dropped.Load())
+
+	stderr := captureStderr(sink.flush)
+	assert.Contains(t, stderr, "Sink: dropped 2 lines while the sink was behind")
+	assert.Equal(t, []int{sinkMaxBatch, 1}, batches)
+	assert.Zero(t, sink.dropped.Load())
+}
+
+func TestLogSinkRunFlushesOnStop(t *testing.T) {
+	var received []sinkEntry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var entries []sinkEntry
+		require.NoError(t, json.Unmarshal(body, &entries))
+		received = append(received, entries...)
+	}))
+	defer server.Close()
+
+	sink, err := newLogSink(server.URL)
+	require.NoError(t, err)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		sink.run(stop)
+		close(done)
+	}()
+	sink.send(sinkEntry{Context: "prod", Line: "one"})
+	sink.send(sinkEntry{Context: "prod", Line: "two"})
+	close(stop)
+	<-done
+
+	require.Len(t, received, 2)
+	assert.Equal(t, "two", received[1].Line)
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sortColumnPaths maps the JSONPath sort keys people usually pass to
+// --sort-by onto the table column that shows the same field.
+var sortColumnPaths = map[string]string{
+	".metadata.name":                            "NAME",
+	".metadata.namespace":                       "NAMESPACE",
+	".metadata.creationTimestamp":               "AGE",
+	".status.phase":                             "STATUS",
+	".status.containerStatuses[0].restartCount": "RESTARTS",
+	".status.podIP":                             "IP",
+	".spec.nodeName":                            "NODE",
+	".spec.replicas":                            "DESIRED",
+	".lastTimestamp":                            "LAST SEEN",
+	".metadata.lastTimestamp":                   "LAST SEEN",
+}
+
+// mergedSortColumn is the column merged tables are sorted by, set when
+// --sort-by is handled client-side.
+var mergedSortColumn string
+
+// sortColumnFor resolves a --sort-by value, either a JSONPath from
+// sortColumnPaths or a column header such as RESTARTS or cpu, to a column
+// name. It returns "" for JSONPaths that do not correspond to a column.
+func sortColumnFor(spec string) string {
+	spec = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(spec), "{"), "}")
+	if isSortPath(spec) {
+		return sortColumnPaths["."+strings.TrimPrefix(spec, ".")]
+	}
+	return strings.ToUpper(spec)
+}
+
+// isSortPath reports whether a --sort-by value is a JSONPath, which kubectl
+// also accepts without the leading dot, such as metadata.name, rather than a
+// column header.
+func isSortPath(spec string) bool {
+	return strings.ContainsAny(spec, ".[")
+}
+
+// findSortColumn returns the index of the header matching name, ignoring
+// case and a parenthesised unit, so "cpu" matches "CPU(cores)".
+func findSortColumn(header []string, name string) int {
+	for i, column := range header {
+		column = strings.ToUpper(column)
+		if column == name || strings.HasPrefix(column, name+"(") {
+			return i
+		}
+	}
+	return -1
+}
+
+var (
+	durationPart    = regexp.MustCompile(`(\d+(?:\.\d+)?)([smhdy])`)
+	durationPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?[smhdy])+$`)
+	quantityPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(m|k|Ki|M|Mi|G|Gi|T|Ti|%)?$`)
+	leadingInteger  = regexp.MustCompile(`^(\d+) \(`)
+)
+
+var durationUnits = map[string]float64{"s": 1, "m": 60, "h": 3600, "d": 86400, "y": 365 * 86400}
+
+var quantityUnits = map[string]float64{
+	"": 1, "%": 1, "m": 0.001,
+	"k": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40,
+}
+
+// durationColumns hold ages, where 250m means minutes rather than millicores.
+var durationColumns = map[string]bool{"AGE": true, "LAST SEEN": true, "DURATION": true}
+
+// sortValue interprets a cell as a number where kubectl prints one: ages
+// such as 3d4h in seconds, quantities such as 250m or 128Mi, percentages, and
+// restart counts such as "3 (5m ago)".
+func sortValue(cell string, duration bool) (float64, bool) {
+	if duration && durationPattern.MatchString(cell) {
+		total := 0.0
+		for _, part := range durationPart.FindAllStringSubmatch(cell, -1) {
+			n, _ := strconv.ParseFloat(part[1], 64)
+			total += n * durationUnits[part[2]]
+		}
+		return total, true
+	}
+	if match := leadingInteger.FindStringSubmatch(cell); match != nil {
+		cell = match[1]
+	}
+	if match := quantityPattern.FindStringSubmatch(cell); match != nil {
+		n, _ := strconv.ParseFloat(match[1], 64)
+		return n * quantityUnits[match[2]], true
+	}
+	return 0, false
+}
+
+// lessCell orders numeric cells numerically, highest first when descending,
+// ahead of non-numeric ones such as <none> or kubectl top's <unknown>, and
+// everything else as strings.
+func lessCell(a, b string, duration, descending bool) bool {
+	av, aNumeric := sortValue(a, duration)
+	bv, bNumeric := sortValue(b, duration)
+	switch {
+	case aNumeric && bNumeric && descending:
+		return av > bv
+	case aNumeric && bNumeric:
+		return av < bv
+	case aNumeric != bNumeric:
+		return aNumeric
+	}
+	return a < b
+}
+
+// clientSideSortBy returns the column the merged table is sorted by. A
+// JSONPath is left in args, so kubectl still sorts each context when the
+// merged table turns out not to have its column; a column header, which
+// kubectl would reject, is taken out. For JSON and other formats or unmapped
+// JSONPaths, args are returned unchanged for kubectl to sort each context.
+func clientSideSortBy(args []string) (string, []string) {
+	switch detectOutputFormat(args) {
+	case formatDefault, formatWide, formatCustomColumns:
+	default:
+		return "", args
+	}
+	values, remaining := extractStringFlag(args, "--sort-by")
+	if len(values) == 0 {
+		return "", args
+	}
+	column := sortColumnFor(values[len(values)-1])
+	if column == "" || isSortPath(values[len(values)-1]) {
+		return column, args
+	}
+	return column, remaining
+}
+
+type tableRow struct {
+	context string
+	columns []string
+}
+
+// sortTableRows sorts rows from every context by the named column. Ages
+// sort oldest first, as kubectl does for .metadata.creationTimestamp, and CPU
+// and memory highest first, as kubectl top does.
+func sortTableRows(rows []tableRow, header []string, name string) {
+	index := findSortColumn(header, name)
+	if index < 0 {
+		fmt.Fprintf(os.Stderr, "Warning: cannot sort the merged table by %s: no such column in %s\n", name, strings.Join(header, ", "))
+		return
+	}
+	cell := func(row tableRow) string {
+		if index < len(row.columns) {
+			return row.columns[index]
+		}
+		return ""
+	}
+	duration := durationColumns[name]
+	descending := duration || name == "CPU" || name == "MEMORY"
+	sort.SliceStable(rows, func(i, j int) bool {
+		return lessCell(cell(rows[i]), cell(rows[j]), duration, descending)
+	})
+}
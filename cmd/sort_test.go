@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortColumnFor(t *testing.T) {
+	assert.Equal(t, "AGE", sortColumnFor(".metadata.creationTimestamp"))
+	assert.Equal(t, "RESTARTS", sortColumnFor("{.status.containerStatuses[0].restartCount}"))
+	assert.Equal(t, "NAME", sortColumnFor("metadata.name"))
+	assert.Equal(t, "", sortColumnFor("status.startTime"))
+	assert.Equal(t, "CPU", sortColumnFor("cpu"))
+	assert.Equal(t, "", sortColumnFor(".spec.priority"))
+}
+
+func TestFindSortColumn(t *testing.T) {
+	header := []string{"NAME", "CPU(cores)", "MEMORY(bytes)"}
+	assert.Equal(t, 1, findSortColumn(header, "CPU"))
+	assert.Equal(t, 0, findSortColumn(header, "NAME"))
+	assert.Equal(t, -1, findSortColumn(header, "AGE"))
+}
+
+func TestSortValue(t *testing.T) {
+	tests := []struct {
+		cell     string
+		duration bool
+		value    float64
+		ok       bool
+	}{
+		{"45s", true, 45, true},
+		{"2d3h", true, 2*86400 + 3*3600, true},
+		{"1.5s", true, 1.5, true},
+		{"250m", true, 250 * 60, true},
+		{"250m", false, 0.25, true},
+		{"128Mi", false, 128 << 20, true},
+		{"12%", false, 12, true},
+		{"3 (5m ago)", false, 3, true},
+		{"7", false, 7, true},
+		{"<none>", false, 0, false},
+		{"Running", false, 0, false},
+	}
+	for _, tt := range tests {
+		value, ok := sortValue(tt.cell, tt.duration)
+		assert.Equal(t, tt.ok, ok, tt.cell)
+		assert.InDelta(t, tt.value, value, 0.0001, tt.cell)
+	}
+}
+
+func TestClientSideSortBy(t *testing.T) {
+	column, args := clientSideSortBy([]string{"pods", "--sort-by=.metadata.creationTimestamp", "-A"})
+	assert.Equal(t, "AGE", column)
+	assert.Equal(t, []string{"pods", "--sort-by=.metadata.creationTimestamp", "-A"}, args)
+
+	column, args = clientSideSortBy([]string{"pods", "--sort-by=RESTARTS", "-A"})
+	assert.Equal(t, "RESTARTS", column)
+	assert.Equal(t, []string{"pods", "-A"}, args)
+
+	column, args = clientSideSortBy([]string{"pods", "--sort-by", ".spec.priority"})
+	assert.Equal(t, "", column)
+	assert.Equal(t, []string{"pods", "--sort-by", ".spec.priority"}, args)
+
+	column, args = clientSideSortBy([]string{"pods", "-o", "json", "--sort-by=.metadata.name"})
+	assert.Equal(t, "", column)
+	assert.Len(t, args, 4)
+}
+
+func TestRunCommandSortsAcrossContexts(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, "pods", extraArgs[0])
+		if context == "ctx1" {
+			return "NAME   RESTARTS      AGE\nweb    3 (5m ago)    2d\napi    0             45m\n", nil
+		}
+		return "NAME   RESTARTS   AGE\ndb     12         3h\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runCommand("get", []string{"pods", "--sort-by=.status.containerStatuses[0].restartCount"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    RESTARTS      AGE\n"+
+		"ctx1     api     0             45m\n"+
+		"ctx1     web     3 (5m ago)    2d\n"+
+		"ctx2     db      12            3h\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, runCommand("get", []string{"pods", "--sort-by=.metadata.creationTimestamp"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    RESTARTS      AGE\n"+
+		"ctx1     web     3 (5m ago)    2d\n"+
+		"ctx2     db      12            3h\n"+
+		"ctx1     api     0             45m\n", output)
+	assert.Empty(t, mergedSortColumn)
+}
+
+func TestRunCommandPassesUnknownSortColumnToKubectl(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"ctx1"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"pods", "--sort-by=spec.nodeName"}, extraArgs)
+		return "NAME   AGE\nweb    2d\napi    45m\n", nil
+	})
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, runCommand("get", []string{"pods", "--sort-by=spec.nodeName"}))
+		})
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    AGE\n"+
+		"ctx1     web     2d\n"+
+		"ctx1     api     45m\n", output)
+	assert.Contains(t, stderr, "cannot sort the merged table by NODE")
+}
+
+func TestRunCommandSortsTopAcrossContexts(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, "top", subcommand)
+		assert.Equal(t, []string{"pods"}, extraArgs)
+		if context == "ctx1" {
+			return "NAME   CPU(cores)   MEMORY(bytes)\nweb    900m         1Gi\nnew    <unknown>    <unknown>\n", nil
+		}
+		return "NAME   CPU(cores)   MEMORY(bytes)\ndb     2            512Mi\napi    15m          2Gi\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, runCommand("top", []string{"pods", "--sort-by", "cpu"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    CPU(cores)    MEMORY(bytes)\n"+
+		"ctx2     db      2             512Mi\n"+
+		"ctx1     web     900m          1Gi\n"+
+		"ctx2     api     15m           2Gi\n"+
+		"ctx1     new     <unknown>     <unknown>\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, runCommand("top", []string{"pods", "--sort-by=memory"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME    CPU(cores)    MEMORY(bytes)\n"+
+		"ctx2     api     15m           2Gi\n"+
+		"ctx1     web     900m          1Gi\n"+
+		"ctx2     db      2             512Mi\n"+
+		"ctx1     new     <unknown>     <unknown>\n", output)
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+type contextState int
+
+const (
+	stateQueued contextState = iota
+	stateRunning
+	stateDone
+	stateFailed
+)
+
+var stateSymbols = map[contextState]string{
+	stateQueued:  colorGray + "·",
+	stateRunning: colorYellow + "◐",
+	stateDone:    colorGreen + "✓",
+	stateFailed:  colorRed + "✗",
+}
+
+// statusProgress is --progress=status: a region on stderr, redrawn in place,
+// listing every context as queued, running, done, or failed.
+type statusProgress struct {
+	mu     sync.Mutex
+	w      io.Writer
+	order  []string
+	states map[string]contextState
+	drawn  int
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// stderrWidth is the width the status region wraps at.
+var stderrWidth = func() int {
+	width, _, err := term.GetSize(int(os.Stderr.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
+
+func newStatusProgress(w io.Writer, contexts []string) *statusProgress {
+	p := &statusProgress{
+		w:      w,
+		order:  contexts,
+		states: make(map[string]contextState),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go p.animate()
+	return p
+}
+
+func (p *statusProgress) contextStarted(context string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states[context] = stateRunning
+}
+
+func (p *statusProgress) contextDone(context string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states[context] = stateDone
+	if err != nil {
+		p.states[context] = stateFailed
+	}
+}
+
+// render lays out a summary line followed by the contexts, as many per line
+// as fit in width.
+func (p *statusProgress) render(width int) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make(map[contextState]int)
+	var entries []string
+	for _, context := range p.order {
+		state := p.states[context]
+		counts[state]++
+		entries = append(entries, stateSymbols[state]+" "+context+colorReset)
+	}
+	lines := []string{fmt.Sprintf("%d/%d complete, %d running, %d queued, %d failed",
+		counts[stateDone]+counts[stateFailed], len(p.order), counts[stateRunning], counts[stateQueued], counts[stateFailed])}
+
+	entryWidth := 0
+	for _, entry := range entries {
+		entryWidth = max(entryWidth, visibleWidth(entry))
+	}
+	perLine := max(1, (width+2)/(entryWidth+2))
+	for start := 0; start < len(entries); start += perLine {
+		row := entries[start:min(start+perLine, len(entries))]
+		var line strings.Builder
+		for i, entry := range row {
+			if i < len(row)-1 {
+				entry = padRight(entry, entryWidth+2)
+			}
+			line.WriteString(entry)
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// redraw replaces the previously drawn region with the current state.
+func (p *statusProgress) redraw() {
+	lines := p.render(stderrWidth())
+	var out strings.Builder
+	if p.drawn > 0 {
+		fmt.Fprintf(&out, "\033[%dA", p.drawn)
+	}
+	out.WriteString("\r\033[J")
+	out.WriteString(strings.Join(lines, "\n"))
+	out.WriteString("\n")
+	fmt.Fprint(p.w, out.String())
+	p.drawn = len(lines)
+}
+
+func (p *statusProgress) clear() {
+	if p.drawn > 0 {
+		fmt.Fprintf(p.w, "\033[%dA\r\033[J", p.drawn)
+		p.drawn = 0
+	}
+}
+
+func (p *statusProgress) animate() {
+	defer close(p.done)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			p.clear()
+			return
+		case <-ticker.C:
+			p.redraw()
+		}
+	}
+}
+
+func (p *statusProgress) finish() {
+	close(p.stop)
+	<-p.done
+}
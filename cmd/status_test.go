@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusProgressRender(t *testing.T) {
+	p := &statusProgress{order: []string{"prod-eu", "prod-us", "dev", "staging"}, states: make(map[string]contextState)}
+	p.contextStarted("prod-eu")
+	p.contextStarted("prod-us")
+	p.contextDone("prod-us", nil)
+	p.contextStarted("dev")
+	p.contextDone("dev", fmt.Errorf("timeout"))
+
+	lines := p.render(30)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "2/4 complete, 1 running, 1 queued, 1 failed", lines[0])
+	assert.Equal(t, "◐ prod-eu  ✓ prod-us", stripColor(lines[1]))
+	assert.Equal(t, "✗ dev      · staging", stripColor(lines[2]))
+	assert.Contains(t, lines[1], colorYellow+"◐ prod-eu"+colorReset)
+
+	assert.Len(t, p.render(5), 5, "one context per line when nothing else fits")
+}
+
+func TestStatusProgressRedraw(t *testing.T) {
+	original := stderrWidth
+	stderrWidth = func() int { return 80 }
+	t.Cleanup(func() { stderrWidth = original })
+
+	var out bytes.Buffer
+	p := &statusProgress{w: &out, order: []string{"prod"}, states: make(map[string]contextState)}
+	p.redraw()
+	assert.Equal(t, "\r\033[J0/1 complete, 0 running, 1 queued, 0 failed\n"+stateSymbols[stateQueued]+" prod"+colorReset+"\n", out.String())
+
+	out.Reset()
+	p.redraw()
+	assert.True(t, bytes.HasPrefix(out.Bytes(), []byte("\033[2A\r\033[J")), "moves up over the previous region")
+
+	out.Reset()
+	p.clear()
+	assert.Equal(t, "\033[2A\r\033[J", out.String())
+}
+
+func TestStatusProgressFinish(t *testing.T) {
+	var out bytes.Buffer
+	p := newStatusProgress(&out, []string{"prod"})
+	p.contextStarted("prod")
+	p.contextDone("prod", nil)
+	p.finish()
+	assert.Zero(t, p.drawn)
+}
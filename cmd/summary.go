@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const podStatusColumns = "custom-columns=NAMESPACE:.metadata.namespace,PHASE:.status.phase,REASON:.status.reason,WAITING:.status.containerStatuses[*].state.waiting.reason"
+
+var podStatuses = []string{"Running", "Pending", "CrashLoopBackOff", "Evicted", "Other"}
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Summarize resource status counts per context",
+	Long: `Report per-context counts instead of listing every object. Currently supports pods:
+
+  kubectl x summary pods [--by-namespace] [-n namespace] [-l selector]
+
+Pods are counted as Running, Pending, CrashLoopBackOff, Evicted, or Other. --by-namespace adds a row per namespace.`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 || (args[0] != "pods" && args[0] != "pod" && args[0] != "po") {
+			return fmt.Errorf("summary supports only pods, e.g. kubectl x summary pods")
+		}
+		byNamespace, args := extractBoolFlag(args[1:], "--by-namespace")
+		return runPodSummary(args, byNamespace)
+	},
+}
+
+// classifyPod buckets a pod by its phase, status reason, and container
+// waiting reasons (comma-separated for multi-container pods).
+func classifyPod(phase, reason, waiting string) string {
+	switch {
+	case reason == "Evicted":
+		return "Evicted"
+	case strings.Contains(waiting, "CrashLoopBackOff"):
+		return "CrashLoopBackOff"
+	case phase == "Running" || phase == "Pending":
+		return phase
+	default:
+		return "Other"
+	}
+}
+
+type podCounts struct {
+	namespace string
+	counts    map[string]int
+	total     int
+}
+
+// countPods tallies pod statuses from podStatusColumns output, either as a
+// single total or per namespace sorted by name.
+func countPods(output string, byNamespace bool) []podCounts {
+	groups := make(map[string]*podCounts)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		key := ""
+		if byNamespace {
+			key = fields[0]
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &podCounts{namespace: key, counts: make(map[string]int)}
+			groups[key] = group
+		}
+		group.counts[classifyPod(fields[1], fields[2], fields[3])]++
+		group.total++
+	}
+
+	var counts []podCounts
+	for _, group := range groups {
+		counts = append(counts, *group)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].namespace < counts[j].namespace })
+	return counts
+}
+
+func runPodSummary(args []string, byNamespace bool) error {
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	getArgs := append([]string{"pods", "--no-headers", "-o", podStatusColumns}, args...)
+	if namespaces, _ := extractStringFlag(args, "-n", "--namespace"); len(namespaces) == 0 {
+		getArgs = append(getArgs, "--all-namespaces")
+	}
+	results := runAcrossContexts(contexts, "get", getArgs)
+
+	header := []string{"CONTEXT"}
+	if byNamespace {
+		header = append(header, "NAMESPACE")
+	}
+	for _, status := range podStatuses {
+		header = append(header, strings.ToUpper(status))
+	}
+	header = append(header, "TOTAL")
+
+	var rows [][]string
+	for _, result := range results {
+		if result.err != nil {
+			printContextError(result)
+			continue
+		}
+		counts := countPods(result.output, byNamespace)
+		if len(counts) == 0 && !byNamespace {
+			counts = []podCounts{{counts: map[string]int{}}}
+		}
+		for _, group := range counts {
+			row := []string{result.context}
+			if byNamespace {
+				row = append(row, group.namespace)
+			}
+			for _, status := range podStatuses {
+				row = append(row, strconv.Itoa(group.counts[status]))
+			}
+			rows = append(rows, append(row, strconv.Itoa(group.total)))
+		}
+	}
+	printContextTable(os.Stdout, header, rows)
+	return nil
+}
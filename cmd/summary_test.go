@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryCmd(t *testing.T) {
+	require.NotNil(t, summaryCmd)
+	assert.Equal(t, "summary", summaryCmd.Use)
+	assert.True(t, summaryCmd.DisableFlagParsing)
+	assert.Error(t, summaryCmd.RunE(summaryCmd, nil))
+	assert.Error(t, summaryCmd.RunE(summaryCmd, []string{"deployments"}))
+}
+
+func TestClassifyPod(t *testing.T) {
+	assert.Equal(t, "Running", classifyPod("Running", "<none>", "<none>"))
+	assert.Equal(t, "Pending", classifyPod("Pending", "<none>", "ContainerCreating"))
+	assert.Equal(t, "CrashLoopBackOff", classifyPod("Running", "<none>", "<none>,CrashLoopBackOff"))
+	assert.Equal(t, "Evicted", classifyPod("Failed", "Evicted", "<none>"))
+	assert.Equal(t, "Other", classifyPod("Succeeded", "<none>", "<none>"))
+}
+
+const summaryPodOutput = `default       Running   <none>    <none>
+default       Running   <none>    CrashLoopBackOff
+kube-system   Running   <none>    <none>
+kube-system   Failed    Evicted   <none>
+web           Pending   <none>    <none>
+`
+
+func TestCountPods(t *testing.T) {
+	total := countPods(summaryPodOutput, false)
+	require.Len(t, total, 1)
+	assert.Equal(t, 5, total[0].total)
+	assert.Equal(t, map[string]int{"Running": 2, "CrashLoopBackOff": 1, "Evicted": 1, "Pending": 1}, total[0].counts)
+
+	byNamespace := countPods(summaryPodOutput, true)
+	require.Len(t, byNamespace, 3)
+	assert.Equal(t, "default", byNamespace[0].namespace)
+	assert.Equal(t, 2, byNamespace[0].total)
+	assert.Equal(t, "kube-system", byNamespace[1].namespace)
+	assert.Equal(t, 1, byNamespace[1].counts["Evicted"])
+	assert.Equal(t, "web", byNamespace[2].namespace)
+}
+
+func TestRunPodSummary(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"ctx1", "ctx2"})
+	t.Setenv("KUBECONFIG", path)
+	var gotArgs []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		gotArgs = extraArgs
+		if context == "ctx2" {
+			return "", nil
+		}
+		return summaryPodOutput, nil
+	})
+
+	t.Run("per context", func(t *testing.T) {
+		output := captureStdout(func() {
+			require.NoError(t, summaryCmd.RunE(summaryCmd, []string{"pods"}))
+		})
+		assert.Equal(t, []string{"pods", "--no-headers", "-o", podStatusColumns, "--all-namespaces"}, gotArgs)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Len(t, lines, 3)
+		assert.Regexp(t, `^CONTEXT\s+RUNNING\s+PENDING\s+CRASHLOOPBACKOFF\s+EVICTED\s+OTHER\s+TOTAL$`, lines[0])
+		assert.Regexp(t, `^ctx1\s+2\s+1\s+1\s+1\s+0\s+5$`, lines[1])
+		assert.Regexp(t, `^ctx2\s+0\s+0\s+0\s+0\s+0\s+0$`, lines[2])
+	})
+
+	t.Run("by namespace", func(t *testing.T) {
+		output := captureStdout(func() {
+			require.NoError(t, summaryCmd.RunE(summaryCmd, []string{"pods", "--by-namespace", "-n", "web"}))
+		})
+		assert.Equal(t, []string{"pods", "--no-headers", "-o", podStatusColumns, "-n", "web"}, gotArgs)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Len(t, lines, 4)
+		assert.Regexp(t, `^CONTEXT\s+NAMESPACE\s+RUNNING`, lines[0])
+		assert.Regexp(t, `^ctx1\s+default\s+1\s+0\s+1\s+0\s+0\s+2$`, lines[1])
+	})
+}
@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// tableAccept asks the API server for the meta.k8s.io Table that kubectl
+// itself prints tables from, falling back to plain JSON.
+const tableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// tableTimeout bounds each request to the API server, so an unreachable
+// context fails in about the time kubectl would take.
+const tableTimeout = 30 * time.Second
+
+// tableRequest is a get that is served from the API server's Table format:
+// one resource type, optionally by name, in a namespace or across all of
+// them, with label and field selectors.
+type tableRequest struct {
+	resource      string
+	names         []string
+	namespace     string
+	allNamespaces bool
+	selector      string
+	fieldSelector string
+	wide          bool
+}
+
+// parseTableRequest reads get args into a tableRequest. It reports false for
+// anything else, such as several resource types, other flags, or other
+// output formats, which are left to kubectl.
+func parseTableRequest(args []string) (tableRequest, bool) {
+	var req tableRequest
+	namespaces, args := extractStringFlag(args, "-n", "--namespace")
+	selectors, args := extractStringFlag(args, "-l", "--selector")
+	fieldSelectors, args := extractStringFlag(args, "--field-selector")
+	outputs, args := extractStringFlag(args, "-o", "--output")
+	req.allNamespaces, args = extractBoolFlag(args, "-A", "--all-namespaces")
+	if len(namespaces) > 0 {
+		req.namespace = namespaces[len(namespaces)-1]
+	}
+	if len(selectors) > 0 {
+		req.selector = selectors[len(selectors)-1]
+	}
+	if len(fieldSelectors) > 0 {
+		req.fieldSelector = fieldSelectors[len(fieldSelectors)-1]
+	}
+	for _, output := range outputs {
+		if output != "wide" {
+			return tableRequest{}, false
+		}
+		req.wide = true
+	}
+
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "-owide":
+			req.wide = true
+		case strings.HasPrefix(arg, "-"):
+			return tableRequest{}, false
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return tableRequest{}, false
+	}
+	if strings.Contains(positional[0], "/") {
+		for _, arg := range positional {
+			resource, name, _ := strings.Cut(arg, "/")
+			if req.resource != "" && resource != req.resource {
+				return tableRequest{}, false
+			}
+			req.resource = resource
+			req.names = append(req.names, name)
+		}
+	} else {
+		req.resource = positional[0]
+		for _, name := range positional[1:] {
+			if strings.Contains(name, "/") {
+				return tableRequest{}, false
+			}
+			req.names = append(req.names, name)
+		}
+	}
+	if req.resource == "" || req.resource == "all" || strings.Contains(req.resource, ",") || (req.allNamespaces && len(req.names) > 0) {
+		return tableRequest{}, false
+	}
+	return req, true
+}
+
+// unsupportedTableError reports a get that the API server cannot serve as
+// a Table, such as an unknown resource type or a server without Table
+// support, so that kubectl runs it instead.
+type unsupportedTableError struct {
+	msg string
+}
+
+func (e *unsupportedTableError) Error() string {
+	return e.msg
+}
+
+// fetchTable gets the rows of req from the context's API server, header
+// first. It is a variable so tests can replace it.
+var fetchTable = func(context string, req tableRequest) ([][]string, error) {
+	client, err := tableClientFor(context)
+	if err != nil {
+		return nil, err
+	}
+	return client.fetch(req)
+}
+
+// runTableGet runs a get in every context from the API server's Table
+// format, so merged rows are built from the server's cells. Contexts whose
+// server cannot serve the get as a Table, or that have nothing to show, run
+// kubectl instead, which also prints kubectl's own messages. Other errors,
+// such as an unreachable server or a denied request, fail the context
+// without asking kubectl to wait for them again.
+func runTableGet(contexts []string, args []string, req tableRequest) []contextResult {
+	var mu sync.Mutex
+	cells := make(map[string][][]string)
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		rows, err := fetchTable(context, req)
+		var unsupported *unsupportedTableError
+		if errors.As(err, &unsupported) || (err == nil && len(rows) < 2) {
+			return runKubectlCommand(context, "get", args)
+		}
+		if err != nil {
+			return "", err
+		}
+		mu.Lock()
+		cells[context] = rows
+		mu.Unlock()
+		return "", nil
+	})
+	for i := range results {
+		results[i].cells = cells[results[i].context]
+	}
+	return results
+}
+
+// tableClient talks to one context's API server. It remembers the
+// resources it has resolved, so discovery runs once per resource type.
+type tableClient struct {
+	http      *http.Client
+	base      *url.URL
+	namespace string
+
+	mu       sync.Mutex
+	resolved map[string]resolvedResource
+}
+
+type resolvedResource struct {
+	groupVersion string
+	resource     metav1.APIResource
+}
+
+// tableClients keeps a client per context and set of kubeconfig files, so
+// that connections and discovery are reused across gets, as in refresh mode.
+var tableClients struct {
+	sync.Mutex
+	byKey map[string]*tableClient
+}
+
+func tableClientFor(context string) (*tableClient, error) {
+	key := context + "\x00" + strings.Join(getKubeconfigPaths(), string(filepath.ListSeparator))
+	tableClients.Lock()
+	defer tableClients.Unlock()
+	if client, ok := tableClients.byKey[key]; ok {
+		return client, nil
+	}
+	client, err := newTableClient(context)
+	if err != nil {
+		return nil, err
+	}
+	if tableClients.byKey == nil {
+		tableClients.byKey = make(map[string]*tableClient)
+	}
+	tableClients.byKey[key] = client
+	return client, nil
+}
+
+func newTableClient(context string) (*tableClient, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.Precedence = getKubeconfigPaths()
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: context})
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	namespace, _, err := config.Namespace()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = tableTimeout
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	base, _, err := rest.DefaultServerUrlFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &tableClient{http: httpClient, base: base, namespace: namespace, resolved: make(map[string]resolvedResource)}, nil
+}
+
+// get decodes the JSON response of path into v.
+func (c *tableClient) get(path string, query url.Values, accept string, v interface{}) error {
+	target := *c.base
+	target.Path = strings.TrimRight(target.Path, "/") + path
+	target.RawQuery = query.Encode()
+	request, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", accept)
+	response, err := c.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusNotAcceptable:
+		io.Copy(io.Discard, response.Body)
+		return &unsupportedTableError{msg: fmt.Sprintf("GET %s: %s", path, response.Status)}
+	default:
+		// Report the server's Status as kubectl does, e.g. for a denied get.
+		var status metav1.Status
+		if json.NewDecoder(response.Body).Decode(&status) == nil && status.Message != "" {
+			return fmt.Errorf("Error from server (%s): %s", status.Reason, status.Message)
+		}
+		return fmt.Errorf("GET %s: %s", path, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+// resolve finds the API resource a get argument such as po, deployments, or
+// deployments.apps names, and the path of its group version, asking the
+// server only the first time.
+func (c *tableClient) resolve(resource string) (string, metav1.APIResource, error) {
+	c.mu.Lock()
+	found, ok := c.resolved[resource]
+	c.mu.Unlock()
+	if ok {
+		return found.groupVersion, found.resource, nil
+	}
+	groupVersion, r, err := c.discover(resource)
+	if err != nil {
+		return "", metav1.APIResource{}, err
+	}
+	c.mu.Lock()
+	c.resolved[resource] = resolvedResource{groupVersion: groupVersion, resource: r}
+	c.mu.Unlock()
+	return groupVersion, r, nil
+}
+
+// discover searches the core group first, then the other groups in the
+// order the server lists them, as kubectl does.
+func (c *tableClient) discover(resource string) (string, metav1.APIResource, error) {
+	name, group, _ := strings.Cut(strings.ToLower(resource), ".")
+	if group == "" {
+		if found, ok := c.findResource("/api/v1", name); ok {
+			return "/api/v1", found, nil
+		}
+	}
+	var groups metav1.APIGroupList
+	if err := c.get("/apis", nil, "application/json", &groups); err != nil {
+		return "", metav1.APIResource{}, err
+	}
+	for _, g := range groups.Groups {
+		if group != "" && g.Name != group {
+			continue
+		}
+		path := "/apis/" + g.PreferredVersion.GroupVersion
+		if found, ok := c.findResource(path, name); ok {
+			return path, found, nil
+		}
+	}
+	return "", metav1.APIResource{}, &unsupportedTableError{msg: fmt.Sprintf("the server doesn't have a resource type %q", resource)}
+}
+
+// findResource looks name up in the resources of a group version.
+func (c *tableClient) findResource(path, name string) (metav1.APIResource, bool) {
+	var resources metav1.APIResourceList
+	if err := c.get(path, nil, "application/json", &resources); err != nil {
+		return metav1.APIResource{}, false
+	}
+	for _, r := range resources.APIResources {
+		if matchesResource(r, name) {
+			return r, true
+		}
+	}
+	return metav1.APIResource{}, false
+}
+
+func matchesResource(r metav1.APIResource, name string) bool {
+	if strings.Contains(r.Name, "/") {
+		return false
+	}
+	if r.Name == name || r.SingularName == name || strings.ToLower(r.Kind) == name {
+		return true
+	}
+	for _, short := range r.ShortNames {
+		if short == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch gets req as a Table, one request per name or a single list.
+func (c *tableClient) fetch(req tableRequest) ([][]string, error) {
+	groupVersion, resource, err := c.resolve(req.resource)
+	if err != nil {
+		return nil, err
+	}
+	path := groupVersion
+	if resource.Namespaced && !req.allNamespaces {
+		namespace := req.namespace
+		if namespace == "" {
+			namespace = forcedNamespace
+		}
+		if namespace == "" {
+			namespace = c.namespace
+		}
+		if namespace == "" {
+			namespace = "default"
+		}
+		path += "/namespaces/" + url.PathEscape(namespace)
+	}
+	path += "/" + resource.Name
+
+	query := url.Values{}
+	if req.selector != "" {
+		query.Set("labelSelector", req.selector)
+	}
+	if req.fieldSelector != "" {
+		query.Set("fieldSelector", req.fieldSelector)
+	}
+	paths := []string{path}
+	if len(req.names) > 0 {
+		paths = nil
+		for _, name := range req.names {
+			paths = append(paths, path+"/"+url.PathEscape(name))
+		}
+	}
+
+	var rows [][]string
+	for _, path := range paths {
+		var table metav1.Table
+		if err := c.get(path, query, tableAccept, &table); err != nil {
+			return nil, err
+		}
+		if table.Kind != "Table" {
+			return nil, &unsupportedTableError{msg: fmt.Sprintf("GET %s: the server did not return a Table", path)}
+		}
+		converted := tableRows(table, req.wide, resource.Namespaced && req.allNamespaces)
+		if rows == nil {
+			rows = converted[:1]
+		}
+		rows = append(rows, converted[1:]...)
+	}
+	return rows, nil
+}
+
+// tableRows turns a Table into rows of cells as kubectl prints them, header
+// first: column names in upper case, the columns of -o wide only when wide,
+// and a NAMESPACE column first when listing all namespaces.
+func tableRows(table metav1.Table, wide, namespaced bool) [][]string {
+	var columns []int
+	var header []string
+	if namespaced {
+		header = append(header, "NAMESPACE")
+	}
+	for i, column := range table.ColumnDefinitions {
+		if column.Priority == 0 || wide {
+			columns = append(columns, i)
+			header = append(header, strings.ToUpper(column.Name))
+		}
+	}
+
+	rows := [][]string{header}
+	for _, row := range table.Rows {
+		var cells []string
+		if namespaced {
+			var object struct {
+				Metadata struct {
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+			}
+			json.Unmarshal(row.Object.Raw, &object)
+			cells = append(cells, object.Metadata.Namespace)
+		}
+		for _, i := range columns {
+			var cell interface{}
+			if i < len(row.Cells) {
+				cell = row.Cells[i]
+			}
+			cells = append(cells, tableCell(cell))
+		}
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+// tableCell prints a Table cell as kubectl does: missing values as <none>,
+// whole numbers without a fraction, and only the first line of text.
+func tableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		if first, _, multiline := strings.Cut(v, "\n"); multiline {
+			return first + "..."
+		}
+		return v
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
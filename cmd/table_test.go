@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseTableRequest(t *testing.T) {
+	req, ok := parseTableRequest([]string{"pods", "-n", "kube-system", "-l", "app=web", "-o", "wide"})
+	require.True(t, ok)
+	assert.Equal(t, tableRequest{resource: "pods", namespace: "kube-system", selector: "app=web", wide: true}, req)
+
+	req, ok = parseTableRequest([]string{"svc", "-A", "--field-selector=metadata.name=api"})
+	require.True(t, ok)
+	assert.Equal(t, tableRequest{resource: "svc", allNamespaces: true, fieldSelector: "metadata.name=api"}, req)
+
+	req, ok = parseTableRequest([]string{"pod/web-1", "pod/web-2", "-owide"})
+	require.True(t, ok)
+	assert.Equal(t, tableRequest{resource: "pod", names: []string{"web-1", "web-2"}, wide: true}, req)
+
+	req, ok = parseTableRequest([]string{"deployments.apps", "api"})
+	require.True(t, ok)
+	assert.Equal(t, tableRequest{resource: "deployments.apps", names: []string{"api"}}, req)
+
+	for _, args := range [][]string{
+		{},
+		{"pods", "-o", "json"},
+		{"pods,svc"},
+		{"all"},
+		{"pods", "--show-labels"},
+		{"pod/web-1", "svc/api"},
+		{"pods", "web-1", "-A"},
+	} {
+		_, ok := parseTableRequest(args)
+		assert.False(t, ok, "%v", args)
+	}
+}
+
+func TestTableCell(t *testing.T) {
+	assert.Equal(t, "<none>", tableCell(nil))
+	assert.Equal(t, "Running", tableCell("Running"))
+	assert.Equal(t, "first...", tableCell("first\nsecond"))
+	assert.Equal(t, "3", tableCell(float64(3)))
+	assert.Equal(t, "0.5", tableCell(0.5))
+	assert.Equal(t, "true", tableCell(true))
+}
+
+func TestTableRows(t *testing.T) {
+	table := metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name"},
+			{Name: "External-IP"},
+			{Name: "Node", Priority: 1},
+		},
+		Rows: []metav1.TableRow{{
+			Cells:  []interface{}{"api", "", "node-1"},
+			Object: runtime.RawExtension{Raw: []byte(`{"metadata":{"namespace":"web"}}`)},
+		}},
+	}
+
+	assert.Equal(t, [][]string{
+		{"NAME", "EXTERNAL-IP"},
+		{"api", ""},
+	}, tableRows(table, false, false))
+	assert.Equal(t, [][]string{
+		{"NAMESPACE", "NAME", "EXTERNAL-IP", "NODE"},
+		{"web", "api", "", "node-1"},
+	}, tableRows(table, true, true))
+}
+
+// serveTables starts an API server that knows pods and serves them as a
+// Table, and points KUBECONFIG at it as context ctx1.
+func serveTables(t *testing.T) *[]string {
+	t.Helper()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1":
+			json.NewEncoder(w).Encode(metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{Name: "pods", SingularName: "pod", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}},
+					{Name: "pods/log", Kind: "Pod", Namespaced: true},
+				},
+			})
+		case "/apis":
+			json.NewEncoder(w).Encode(metav1.APIGroupList{})
+		case "/api/v1/namespaces/default/pods":
+			if !strings.Contains(r.Header.Get("Accept"), "as=Table") {
+				http.Error(w, "not a table request", http.StatusNotAcceptable)
+				return
+			}
+			fmt.Fprint(w, `{"kind":"Table","apiVersion":"meta.k8s.io/v1",
+				"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Status","type":"string"},{"name":"Restarts","type":"integer"}],
+				"rows":[{"cells":["web-1","Running",2]},{"cells":["web 2","",0]}]}`)
+		case "/api/v1/namespaces/secret/pods":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,
+				"message":"pods is forbidden: User \"dev\" cannot list resource \"pods\" in the namespace \"secret\""}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: c1
+  cluster:
+    server: `+server.URL+`
+users:
+- name: u1
+  user: {}
+contexts:
+- name: ctx1
+  context:
+    cluster: c1
+    user: u1
+`), 0600))
+	t.Setenv("KUBECONFIG", path)
+	return &requests
+}
+
+func TestTableClientFetch(t *testing.T) {
+	requests := serveTables(t)
+
+	rows, err := fetchTable("ctx1", tableRequest{resource: "po", selector: "app=web"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"NAME", "STATUS", "RESTARTS"},
+		{"web-1", "Running", "2"},
+		{"web 2", "", "0"},
+	}, rows)
+	assert.Equal(t, []string{"/api/v1", "/api/v1/namespaces/default/pods?labelSelector=app%3Dweb"}, *requests)
+
+	// Discovery is not repeated for a resource type already resolved.
+	_, err = fetchTable("ctx1", tableRequest{resource: "po", selector: "app=web"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/v1/namespaces/default/pods?labelSelector=app%3Dweb"}, (*requests)[2:])
+
+	_, err = fetchTable("ctx1", tableRequest{resource: "widgets"})
+	assert.EqualError(t, err, `the server doesn't have a resource type "widgets"`)
+	var unsupported *unsupportedTableError
+	assert.ErrorAs(t, err, &unsupported)
+
+	_, err = fetchTable("ctx1", tableRequest{resource: "pods", namespace: "secret"})
+	assert.EqualError(t, err, `Error from server (Forbidden): pods is forbidden: User "dev" cannot list resource "pods" in the namespace "secret"`)
+	assert.False(t, errors.As(err, &unsupported))
+}
+
+func TestRunTableGet(t *testing.T) {
+	var kubectlCalls []string
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		kubectlCalls = append(kubectlCalls, context)
+		return "NAME    STATUS\nweb-3   Running\n", nil
+	})
+	fetchTable = func(context string, req tableRequest) ([][]string, error) {
+		switch context {
+		case "ctx2":
+			return nil, &unsupportedTableError{msg: "GET /api/v1/pods: 406 Not Acceptable"}
+		case "ctx3":
+			return nil, errors.New("dial tcp 10.0.0.3:443: i/o timeout")
+		}
+		return [][]string{{"NAME", "STATUS"}, {"web  1", ""}}, nil
+	}
+
+	results := runTableGet([]string{"ctx1", "ctx2", "ctx3"}, []string{"pods"}, tableRequest{resource: "pods"})
+	assert.Equal(t, []string{"ctx2"}, kubectlCalls)
+	assert.Equal(t, [][]string{{"NAME", "STATUS"}, {"web  1", ""}}, results[0].cells)
+	assert.Nil(t, results[1].cells)
+	assert.EqualError(t, results[2].err, "dial tcp 10.0.0.3:443: i/o timeout")
+	results = results[:2]
+
+	output := captureStdout(func() {
+		require.NoError(t, formatTableOutput(results, false))
+	})
+	assert.Equal(t, "CONTEXT  NAME      STATUS\n"+
+		"ctx1     web  1\n"+
+		"ctx2     web-3     Running\n", output)
+}
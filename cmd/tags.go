@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tagExtensionName is the kubeconfig context extension that holds tags:
+//
+//	contexts:
+//	- name: prod-eu
+//	  context:
+//	    extensions:
+//	    - name: kubectl-x
+//	      extension:
+//	        env: prod
+//	        region: eu
+const tagExtensionName = "kubectl-x"
+
+type tagSelector struct {
+	key    string
+	value  string
+	negate bool
+}
+
+// parseTagSelectors parses a comma-separated list of key=value and
+// key!=value terms, all of which must hold.
+func parseTagSelectors(expression string) ([]tagSelector, error) {
+	var selectors []tagSelector
+	for _, term := range strings.Split(expression, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		selector := tagSelector{}
+		key, value, found := strings.Cut(term, "!=")
+		if found {
+			selector.negate = true
+		} else if key, value, found = strings.Cut(term, "="); !found {
+			return nil, fmt.Errorf("invalid tag selector %q: expected key=value or key!=value", term)
+		}
+		selector.key = strings.TrimSpace(key)
+		selector.value = strings.TrimSpace(value)
+		if selector.key == "" {
+			return nil, fmt.Errorf("invalid tag selector %q: missing key", term)
+		}
+		selectors = append(selectors, selector)
+	}
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("empty tag selector")
+	}
+	return selectors, nil
+}
+
+func matchesTags(tags map[string]string, selectors []tagSelector) bool {
+	for _, selector := range selectors {
+		value, ok := tags[selector.key]
+		if (ok && value == selector.value) == selector.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// filterContextsByTags keeps contexts matching any of the tag expressions.
+func filterContextsByTags(contexts []string, tags map[string]map[string]string, expressions []string) ([]string, error) {
+	var selectorSets [][]tagSelector
+	for _, expression := range expressions {
+		selectors, err := parseTagSelectors(expression)
+		if err != nil {
+			return nil, err
+		}
+		selectorSets = append(selectorSets, selectors)
+	}
+
+	var filtered []string
+	for _, ctx := range contexts {
+		for _, selectors := range selectorSets {
+			if matchesTags(tags[ctx], selectors) {
+				filtered = append(filtered, ctx)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// loadContextTags reads the tag extension of every context in the kubeconfig
+// files.
+func loadContextTags() (map[string]map[string]string, error) {
+	entries, err := loadContextEntries()
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]map[string]string, len(entries))
+	for name, entry := range entries {
+		tags[name] = map[string]string{}
+		for _, extension := range entry.Context.Extensions {
+			if extension.Name != tagExtensionName {
+				continue
+			}
+			for key, value := range extension.Extension {
+				tags[name][key] = fmt.Sprint(value)
+			}
+		}
+	}
+	return tags, nil
+}
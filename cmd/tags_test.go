@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const taggedKubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- name: prod-us
+  context:
+    cluster: prod-us
+    extensions:
+    - name: kubectl-x
+      extension:
+        env: prod
+        region: us
+- name: prod-eu
+  context:
+    cluster: prod-eu
+    extensions:
+    - name: other-tool
+      extension:
+        env: ignored
+    - name: kubectl-x
+      extension:
+        env: prod
+        region: eu
+        tier: 1
+- name: dev
+  context:
+    cluster: dev
+`
+
+func writeTaggedKubeconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte(taggedKubeconfig), 0o600))
+	return path
+}
+
+func TestParseTagSelectors(t *testing.T) {
+	selectors, err := parseTagSelectors("env=prod, region!=us")
+	require.NoError(t, err)
+	assert.Equal(t, []tagSelector{{key: "env", value: "prod"}, {key: "region", value: "us", negate: true}}, selectors)
+
+	for _, bad := range []string{"env", "=prod", ","} {
+		_, err := parseTagSelectors(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestMatchesTags(t *testing.T) {
+	tags := map[string]string{"env": "prod", "region": "eu"}
+	selectors, _ := parseTagSelectors("env=prod,region!=us")
+	assert.True(t, matchesTags(tags, selectors))
+	assert.False(t, matchesTags(map[string]string{"env": "prod", "region": "us"}, selectors))
+	assert.False(t, matchesTags(nil, selectors))
+
+	untagged, _ := parseTagSelectors("region!=us")
+	assert.True(t, matchesTags(nil, untagged))
+}
+
+func TestLoadContextTags(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeTaggedKubeconfig(t))
+	tags, err := loadContextTags()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "us"}, tags["prod-us"])
+	assert.Equal(t, map[string]string{"env": "prod", "region": "eu", "tier": "1"}, tags["prod-eu"])
+	assert.Empty(t, tags["dev"])
+}
+
+func TestGetContextsWithTags(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeTaggedKubeconfig(t))
+	t.Cleanup(func() { tagExpressions = []string{} })
+
+	tagExpressions = []string{"env=prod,region!=us"}
+	contexts, err := getContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-eu"}, contexts)
+
+	tagExpressions = []string{"region=us", "tier=1"}
+	contexts, err = getContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-us", "prod-eu"}, contexts)
+
+	tagExpressions = []string{"env=staging"}
+	_, err = getContexts()
+	assert.ErrorContains(t, err, "no contexts match tags: env=staging")
+
+	tagExpressions = []string{"env"}
+	_, err = getContexts()
+	assert.ErrorContains(t, err, "invalid tag selector")
+}
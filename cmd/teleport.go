@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// teleportSource discovers the Kubernetes clusters available through a
+// Teleport proxy. Only the first cluster is logged into during discovery;
+// every context uses the `tsh kube credentials` exec plugin, so certificates
+// for the others are issued as kubectl first reaches them in the fan-out.
+type teleportSource struct {
+	proxy   string
+	cluster string
+}
+
+func (s teleportSource) scopeArgs() []string {
+	var args []string
+	if s.proxy != "" {
+		args = append(args, "--proxy", s.proxy)
+	}
+	if s.cluster != "" {
+		args = append(args, "--cluster", s.cluster)
+	}
+	return args
+}
+
+func (s teleportSource) listClusters() ([]string, error) {
+	output, err := runCloudCLI(nil, "tsh", append([]string{"kube", "ls", "--format", "json"}, s.scopeArgs()...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teleport kube clusters (are you logged in with tsh login?): %s", lastLine(string(output)))
+	}
+	var clusters []struct {
+		Name string `json:"kube_cluster_name"`
+	}
+	if err := json.Unmarshal(output, &clusters); err != nil {
+		return nil, fmt.Errorf("failed to parse tsh kube ls output: %w", err)
+	}
+	var names []string
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names, nil
+}
+
+func (s teleportSource) discover(path string) (int, error) {
+	clusters, err := s.listClusters()
+	if err != nil || len(clusters) == 0 {
+		return 0, err
+	}
+
+	output, err := runCloudCLI([]string{"KUBECONFIG=" + path}, "tsh", append([]string{"kube", "login", clusters[0]}, s.scopeArgs()...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to log in to teleport kube cluster %s: %s", clusters[0], lastLine(string(output)))
+	}
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load discovered kubeconfig: %w", err)
+	}
+	template, err := teleportTemplate(config, clusters[0])
+	if err != nil {
+		return 0, err
+	}
+
+	fragment := clientcmdapi.NewConfig()
+	for _, cluster := range clusters {
+		name := "teleport_" + cluster
+		user := template.user.DeepCopy()
+		user.Exec.Args = teleportCredentialArgs(user.Exec.Args, cluster)
+		fragment.AuthInfos[name] = user
+		fragment.Contexts[name] = &clientcmdapi.Context{Cluster: template.context.Cluster, AuthInfo: name}
+	}
+	delete(config.Contexts, template.contextName)
+	delete(config.AuthInfos, template.context.AuthInfo)
+	config.CurrentContext = ""
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		return 0, fmt.Errorf("failed to write discovered kubeconfig: %w", err)
+	}
+	if err := mergeKubeconfig(path, fragment); err != nil {
+		return 0, err
+	}
+	return len(clusters), nil
+}
+
+type teleportContext struct {
+	contextName string
+	context     *clientcmdapi.Context
+	user        *clientcmdapi.AuthInfo
+}
+
+// teleportTemplate finds the context tsh kube login wrote for cluster: the
+// one whose user runs `tsh kube credentials --kube-cluster=<cluster>`.
+func teleportTemplate(config *clientcmdapi.Config, cluster string) (teleportContext, error) {
+	for name, context := range config.Contexts {
+		user, ok := config.AuthInfos[context.AuthInfo]
+		if !ok || user.Exec == nil {
+			continue
+		}
+		for _, arg := range user.Exec.Args {
+			if arg == "--kube-cluster="+cluster {
+				return teleportContext{contextName: name, context: context, user: user}, nil
+			}
+		}
+	}
+	return teleportContext{}, fmt.Errorf("tsh kube login did not write a context for %s", cluster)
+}
+
+func teleportCredentialArgs(args []string, cluster string) []string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--kube-cluster=") {
+			arg = "--kube-cluster=" + cluster
+		}
+		result[i] = arg
+	}
+	return result
+}
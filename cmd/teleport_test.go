@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const tshLoginKubeconfig = `apiVersion: v1
+kind: Config
+current-context: acme-%[1]s
+clusters:
+- name: acme
+  cluster:
+    server: https://teleport.acme.com:443
+    tls-server-name: kube-teleport-proxy-alpn.teleport.cluster.local
+contexts:
+- name: acme-%[1]s
+  context:
+    cluster: acme
+    user: acme-%[1]s
+users:
+- name: acme-%[1]s
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: tsh
+      args: [kube, credentials, --kube-cluster=%[1]s, --teleport-cluster=acme, --proxy=teleport.acme.com:443]
+`
+
+func TestTeleportSourceDiscover(t *testing.T) {
+	path := emptyDiscoveredKubeconfig(t)
+	calls := fakeCloudCLI(t, func(call cloudCall) ([]byte, error) {
+		switch call.args[1] {
+		case "ls":
+			return []byte(`[{"kube_cluster_name":"prod","labels":{"env":"prod"}},{"kube_cluster_name":"staging"}]`), nil
+		case "login":
+			require.Equal(t, []string{"KUBECONFIG=" + path}, call.env)
+			return nil, os.WriteFile(path, []byte(fmt.Sprintf(tshLoginKubeconfig, call.args[2])), 0o600)
+		}
+		return nil, fmt.Errorf("unexpected call %v", call.args)
+	})
+
+	count, err := teleportSource{proxy: "teleport.acme.com:443"}.discover(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "teleport.acme.com:443", argValue((*calls)[0].args, "--proxy"))
+	assert.Equal(t, "prod", (*calls)[1].args[2])
+
+	config, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Len(t, config.Contexts, 2)
+	assert.Equal(t, "acme", config.Contexts["teleport_staging"].Cluster)
+	assert.Contains(t, config.AuthInfos["teleport_staging"].Exec.Args, "--kube-cluster=staging")
+	assert.Contains(t, config.AuthInfos["teleport_prod"].Exec.Args, "--kube-cluster=prod")
+	assert.NotContains(t, config.AuthInfos, "acme-prod")
+	assert.Equal(t, "kube-teleport-proxy-alpn.teleport.cluster.local", config.Clusters["acme"].TLSServerName)
+}
+
+func TestTeleportSourceDiscoverNotLoggedIn(t *testing.T) {
+	fakeCloudCLI(t, func(call cloudCall) ([]byte, error) {
+		return []byte("ERROR: Not logged in."), fmt.Errorf("exit status 1")
+	})
+	_, err := teleportSource{}.discover(emptyDiscoveredKubeconfig(t))
+	assert.ErrorContains(t, err, "Not logged in")
+}
+
+func TestTeleportSourceDiscoverNoClusters(t *testing.T) {
+	calls := fakeCloudCLI(t, func(call cloudCall) ([]byte, error) {
+		return []byte("[]"), nil
+	})
+	count, err := teleportSource{cluster: "leaf"}.discover(emptyDiscoveredKubeconfig(t))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "leaf", argValue((*calls)[0].args, "--cluster"))
+}
+
+func TestTeleportCredentialArgs(t *testing.T) {
+	args := []string{"kube", "credentials", "--kube-cluster=prod", "--teleport-cluster=acme"}
+	assert.Equal(t, "kube credentials --kube-cluster=dev --teleport-cluster=acme", strings.Join(teleportCredentialArgs(args, "dev"), " "))
+	assert.Equal(t, "--kube-cluster=prod", args[2])
+}
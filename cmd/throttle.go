@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// throttleReportInterval is how often suppressed line counts are reported.
+const throttleReportInterval = 5 * time.Second
+
+// lineThrottle drops streamed lines beyond a rate per context and across all
+// contexts, so that one noisy context cannot drown out the others. Rates are
+// counted in one-second windows; zero means unlimited.
+type lineThrottle struct {
+	perContext int
+	total      int
+	now        func() time.Time
+
+	mu         sync.Mutex
+	window     time.Time
+	counts     map[string]int
+	count      int
+	suppressed map[string]int
+}
+
+func newLineThrottle(perContext, total int) *lineThrottle {
+	return &lineThrottle{
+		perContext: perContext,
+		total:      total,
+		now:        time.Now,
+		counts:     make(map[string]int),
+		suppressed: make(map[string]int),
+	}
+}
+
+// parseLineThrottle extracts --max-lines-per-sec and
+// --max-lines-per-sec-total, returning nil when neither is given.
+func parseLineThrottle(args []string) (*lineThrottle, []string, error) {
+	perContext, args, err := extractRate(args, "--max-lines-per-sec")
+	if err != nil {
+		return nil, nil, err
+	}
+	total, args, err := extractRate(args, "--max-lines-per-sec-total")
+	if err != nil {
+		return nil, nil, err
+	}
+	if perContext == 0 && total == 0 {
+		return nil, args, nil
+	}
+	return newLineThrottle(perContext, total), args, nil
+}
+
+func extractRate(args []string, name string) (int, []string, error) {
+	values, args := extractStringFlag(args, name)
+	if len(values) == 0 {
+		return 0, args, nil
+	}
+	rate, err := strconv.Atoi(values[len(values)-1])
+	if err != nil || rate < 0 {
+		return 0, nil, fmt.Errorf("invalid %s %q: must be a non-negative number of lines", name, values[len(values)-1])
+	}
+	return rate, args, nil
+}
+
+// allow reports whether a line from context may be printed at now, counting
+// it as suppressed if not.
+func (t *lineThrottle) allow(context string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if second := now.Truncate(time.Second); !second.Equal(t.window) {
+		t.window = second
+		t.counts = make(map[string]int)
+		t.count = 0
+	}
+	if (t.perContext > 0 && t.counts[context] >= t.perContext) || (t.total > 0 && t.count >= t.total) {
+		t.suppressed[context]++
+		return false
+	}
+	t.counts[context]++
+	t.count++
+	return true
+}
+
+// filter passes through the lines from reader that the throttle allows.
+func (t *lineThrottle) filter(context string, reader io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		scanner := newLineScanner(reader)
+		for scanner.Scan() {
+			if !t.allow(context, t.now()) {
+				continue
+			}
+			if _, err := pw.Write(scanner.Bytes()); err != nil {
+				break
+			}
+			if _, err := pw.Write([]byte("\n")); err != nil {
+				break
+			}
+		}
+		pw.CloseWithError(scanner.Err())
+		io.Copy(io.Discard, reader)
+	}()
+	return pr
+}
+
+// report prints, and resets, how many lines each context had suppressed.
+func (t *lineThrottle) report(w io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	contexts := make([]string, 0, len(t.suppressed))
+	for context := range t.suppressed {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+	for _, context := range contexts {
+		fmt.Fprintf(w, "%s: %s lines suppressed\n", colorizeContext(context), groupThousands(t.suppressed[context]))
+	}
+	t.suppressed = make(map[string]int)
+}
+
+// run reports suppressed lines every throttleReportInterval until stop is
+// closed, then one last time.
+func (t *lineThrottle) run(w io.Writer, stop <-chan struct{}) {
+	ticker := time.NewTicker(throttleReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			t.report(w)
+			return
+		case <-ticker.C:
+			t.report(w)
+		}
+	}
+}
+
+// groupThousands formats n with comma thousands separators, e.g. 1,024.
+func groupThousands(n int) string {
+	digits := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + groupThousands(-n)
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLineThrottle(t *testing.T) {
+	throttle, args, err := parseLineThrottle([]string{"web-1", "-f"})
+	require.NoError(t, err)
+	assert.Nil(t, throttle)
+	assert.Equal(t, []string{"web-1", "-f"}, args)
+
+	throttle, args, err = parseLineThrottle([]string{"-f", "--max-lines-per-sec=50", "--max-lines-per-sec-total", "200"})
+	require.NoError(t, err)
+	assert.Equal(t, 50, throttle.perContext)
+	assert.Equal(t, 200, throttle.total)
+	assert.Equal(t, []string{"-f"}, args)
+
+	_, _, err = parseLineThrottle([]string{"--max-lines-per-sec", "lots"})
+	assert.Error(t, err)
+}
+
+func TestLineThrottleAllow(t *testing.T) {
+	throttle := newLineThrottle(2, 3)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, throttle.allow("ctx1", now))
+	assert.True(t, throttle.allow("ctx1", now))
+	assert.False(t, throttle.allow("ctx1", now), "per-context limit")
+	assert.True(t, throttle.allow("ctx2", now))
+	assert.False(t, throttle.allow("ctx2", now), "total limit")
+	assert.True(t, throttle.allow("ctx1", now.Add(time.Second)), "next window")
+
+	var buf bytes.Buffer
+	throttle.report(&buf)
+	assert.Equal(t, "ctx1: 1 lines suppressed\nctx2: 1 lines suppressed\n", buf.String())
+
+	buf.Reset()
+	throttle.report(&buf)
+	assert.Empty(t, buf.String())
+}
+
+func TestLineThrottleFilter(t *testing.T) {
+	throttle := newLineThrottle(2, 0)
+	throttle.now = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	data, err := io.ReadAll(throttle.filter("ctx1", strings.NewReader("a\nb\nc\nd\n")))
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\n", string(data))
+	assert.Equal(t, 2, throttle.suppressed["ctx1"])
+}
+
+func TestGroupThousands(t *testing.T) {
+	assert.Equal(t, "0", groupThousands(0))
+	assert.Equal(t, "999", groupThousands(999))
+	assert.Equal(t, "1,024", groupThousands(1024))
+	assert.Equal(t, "12,345,678", groupThousands(12345678))
+	assert.Equal(t, "-1,000", groupThousands(-1000))
+}
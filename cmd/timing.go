@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+var showTimings bool
+
+// formatDuration prints durations in the form kubectl uses for ages, with
+// tenths of a second below a minute so fast contexts stay distinguishable.
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+// withDurationColumn returns a copy of a table row padded to width cells with
+// the duration appended, so it lands under the DURATION header even when
+// trailing cells are blank.
+func withDurationColumn(columns []string, width int, duration string) []string {
+	if len(columns) > width {
+		width = len(columns)
+	}
+	padded := make([]string, width, width+1)
+	copy(padded, columns)
+	return append(padded, duration)
+}
+
+// isTableOutput reports whether results are printed as a merged table, which
+// gets a DURATION column rather than a separate timing summary.
+func isTableOutput(format outputFormat, subcommand string) bool {
+	switch format {
+	case formatWide, formatCustomColumns:
+		return true
+	case formatDefault:
+		return subcommand != "version" && subcommand != "logs" && subcommand != "api-versions"
+	}
+	return false
+}
+
+// printTimingSummary prints how long each context took, slowest first.
+func printTimingSummary(w io.Writer, results []contextResult) {
+	sorted := append([]contextResult{}, results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].duration > sorted[j].duration
+	})
+	var rows [][]string
+	for _, result := range sorted {
+		status := "OK"
+		if result.err != nil {
+			status = "ERROR"
+		}
+		rows = append(rows, []string{result.context, formatDuration(result.duration), status})
+	}
+	fmt.Fprintln(w)
+	printContextTable(w, []string{"CONTEXT", "DURATION", "RESULT"}, rows)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0.4s", formatDuration(420*time.Millisecond))
+	assert.Equal(t, "12.0s", formatDuration(12*time.Second))
+	assert.Equal(t, "1m5s", formatDuration(65*time.Second+400*time.Millisecond))
+}
+
+func TestWithDurationColumn(t *testing.T) {
+	assert.Equal(t, []string{"web", "", "1.0s"}, withDurationColumn([]string{"web"}, 2, "1.0s"))
+	assert.Equal(t, []string{"a", "b", "c", "1.0s"}, withDurationColumn([]string{"a", "b", "c"}, 2, "1.0s"))
+}
+
+func TestIsTableOutput(t *testing.T) {
+	assert.True(t, isTableOutput(formatDefault, "get"))
+	assert.True(t, isTableOutput(formatWide, "get"))
+	assert.False(t, isTableOutput(formatDefault, "logs"))
+	assert.False(t, isTableOutput(formatJSON, "get"))
+}
+
+func TestPrintTimingSummary(t *testing.T) {
+	var out bytes.Buffer
+	printTimingSummary(&out, []contextResult{
+		{context: "fast", duration: 200 * time.Millisecond},
+		{context: "slow", duration: 3 * time.Second, err: fmt.Errorf("timeout")},
+	})
+	assert.Equal(t, "\nCONTEXT  DURATION    RESULT\nslow     3.0s        ERROR\nfast     0.2s        OK\n", out.String())
+}
+
+func TestFormatDefaultOutputTiming(t *testing.T) {
+	showTimings = true
+	t.Cleanup(func() { showTimings = false })
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "ctx1", output: "NAME    STATUS\npod1    Running", duration: 1500 * time.Millisecond},
+			{context: "ctx2", output: "NAME    STATUS\npod2", duration: 200 * time.Millisecond},
+		}))
+	})
+	assert.Equal(t, "CONTEXT  NAME    STATUS     DURATION\nctx1     pod1    Running    1.5s\nctx2     pod2               0.2s\n", output)
+}
+
+func TestFormatOutputTimingSummary(t *testing.T) {
+	showTimings = true
+	t.Cleanup(func() { showTimings = false })
+
+	stderr := captureStderr(func() {
+		captureStdout(func() {
+			require.NoError(t, formatOutput([]contextResult{{context: "ctx1", output: "line", duration: time.Second}}, formatRaw, "logs"))
+		})
+	})
+	assert.Contains(t, stderr, "ctx1     1.0s        OK")
+}
+
+func TestFormatDefaultOutputSortByDuration(t *testing.T) {
+	showTimings = true
+	mergedSortColumn = "DURATION"
+	t.Cleanup(func() {
+		showTimings = false
+		mergedSortColumn = ""
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, formatDefaultOutput([]contextResult{
+			{context: "fast", output: "NAME\npod1", duration: 200 * time.Millisecond},
+			{context: "slow", output: "NAME\npod2", duration: 2 * time.Minute},
+		}))
+	})
+	assert.Equal(t, "CONTEXT  NAME    DURATION\nslow     pod2    2m0s\nfast     pod1    0.2s\n", output)
+}
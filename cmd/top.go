@@ -1,15 +1,59 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
 var topCmd = &cobra.Command{
-	Use:                "top",
-	Short:              "Run kubectl top against all contexts",
-	Long:               `Run kubectl top command against all contexts in parallel.`,
+	Use:   "top",
+	Short: "Run kubectl top against all contexts",
+	Long: `Run kubectl top command against all contexts in parallel.
+
+With --sum, the merged table ends with the total CPU and memory of each context and of the whole fleet.
+
+With top node --allocatable, each node's allocatable CPU and memory, from kubectl get nodes, are shown next to its usage; with --show-capacity, its capacity.
+
+With top pod --containers, the merged table has a row per container under its pod; --by-pod sums each pod's containers into one row.
+
+With --watch, metrics are collected again every --interval (default 15s) and the table is redrawn with the change in CPU and memory of every row since the previous sample.`,
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runCommand("top", args)
+		interval, args, err := parseTopWatch(args)
+		if err != nil {
+			return err
+		}
+		sum, args := extractBoolFlag(args, "--sum")
+		allocatable, args := extractBoolFlag(args, "--allocatable")
+		byPod, args := extractBoolFlag(args, "--by-pod")
+		if sum {
+			showTotals, contextTotals = true, true
+			defer func() { showTotals, contextTotals = false, false }()
+		}
+		if allocatable && !isTopNode(args) {
+			return fmt.Errorf("--allocatable only applies to top node")
+		}
+		if byPod {
+			if !hasContainersFlag(args) {
+				return fmt.Errorf("--by-pod only applies to top pod --containers")
+			}
+			podRollup = true
+			defer func() { podRollup = false }()
+		}
+
+		run := func() error {
+			if allocatable {
+				return runTopNodesAllocatable(args)
+			}
+			return runCommand("top", args)
+		}
+		if interval > 0 {
+			topDeltas = make(topSamples)
+			defer func() { topDeltas = nil }()
+			return runRefreshing(interval, "top "+strings.Join(args, " "), run)
+		}
+		return run()
 	},
 }
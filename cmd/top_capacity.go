@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// isTopNode reports whether top args are for top node, which kubectl also
+// accepts as nodes or no.
+func isTopNode(args []string) bool {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return arg == "node" || arg == "nodes" || arg == "no"
+		}
+	}
+	return false
+}
+
+// allocatableArgs turns top node args into get nodes args listing each
+// node's allocatable CPU and memory, or its capacity with --show-capacity,
+// keeping the node names and label selectors.
+func allocatableArgs(args []string, field string) []string {
+	selectors, args := extractStringFlag(args, "-l", "--selector")
+	getArgs := []string{"nodes"}
+	resource := true
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "-"):
+		case resource:
+			resource = false
+		default:
+			getArgs = append(getArgs, arg)
+		}
+	}
+	for _, selector := range selectors {
+		getArgs = append(getArgs, "--selector", selector)
+	}
+	columns := fmt.Sprintf("custom-columns=NAME:.metadata.name,CPU:.status.%s.cpu,MEMORY:.status.%s.memory", field, field)
+	return append(getArgs, "-o", columns, "--no-headers")
+}
+
+// joinAllocatable adds a column after CPU% and MEMORY% of kubectl top node
+// output with each node's allocatable CPU and memory from get nodes output,
+// in the units top prints.
+func joinAllocatable(usage, nodes, field string) string {
+	allocatable := make(map[string][]string)
+	for _, line := range strings.Split(nodes, "\n") {
+		if fields := strings.Fields(line); len(fields) == 3 {
+			allocatable[fields[0]] = fields[1:]
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(usage), "\n")
+	starts := headerColumnStarts(lines[0])
+	header := splitAtColumnStarts(lines[0], starts)
+	name := slices.Index(header, "NAME")
+	cpu := slices.Index(header, "CPU%")
+	if cpu < 0 {
+		cpu = findSortColumn(header, "CPU")
+	}
+	memory := slices.Index(header, "MEMORY%")
+	if memory < 0 {
+		memory = findSortColumn(header, "MEMORY")
+	}
+	if name < 0 || cpu < 0 || memory < 0 {
+		return usage
+	}
+
+	aligned := alignedToHeader(lines[1:], starts)
+	var table [][]string
+	for i, line := range lines {
+		row := splitAtColumnStarts(line, starts)
+		if !aligned {
+			row = columnSeparator.Split(strings.TrimSpace(line), -1)
+			for len(row) < len(header) {
+				row = append(row, "")
+			}
+		}
+		added := []string{"CPU(" + field + ")", "MEMORY(" + field + ")"}
+		if i > 0 {
+			added = []string{"<unknown>", "<unknown>"}
+			if values, ok := allocatable[row[name]]; ok {
+				added = []string{formatAllocatable(values[0], formatCPU), formatAllocatable(values[1], formatMemory)}
+			}
+		}
+		joined := append(append([]string{}, row[:cpu+1]...), added[0])
+		joined = append(append(joined, row[cpu+1:memory+1]...), added[1])
+		table = append(table, append(joined, row[memory+1:]...))
+	}
+
+	widths := columnWidths(table)
+	var joined strings.Builder
+	for _, row := range table {
+		joined.WriteString(alignedLine(row, widths, "   ") + "\n")
+	}
+	return joined.String()
+}
+
+func formatAllocatable(quantity string, format func(float64) string) string {
+	if value, ok := sortValue(quantity, false); ok {
+		return format(value)
+	}
+	return quantity
+}
+
+// runTopNodesAllocatable runs top node in every context along with get
+// nodes, and merges the joined tables like runCommand does.
+func runTopNodesAllocatable(args []string) error {
+	column, args := clientSideSortBy(args)
+	if column != "" {
+		mergedSortColumn = column
+		defer func() { mergedSortColumn = "" }()
+	}
+	capacity, _ := extractBoolFlag(args, "--show-capacity")
+	field := "allocatable"
+	if capacity {
+		field = "capacity"
+	}
+
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+
+	results := runAcrossContextsFunc(contexts, func(context string) (string, error) {
+		usage, err := runKubectlCommand(context, "top", args)
+		if err != nil {
+			return usage, err
+		}
+		nodes, err := runKubectlCommand(context, "get", allocatableArgs(args, field))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Context %s: failed to get node %s: %s\n", colorizeContext(context), field, lastLine(nodes))
+		}
+		return joinAllocatable(usage, nodes, field), nil
+	})
+	return formatOutput(results, formatDefault, "top")
+}
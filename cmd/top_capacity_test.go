@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTopNode(t *testing.T) {
+	assert.True(t, isTopNode([]string{"node"}))
+	assert.True(t, isTopNode([]string{"--show-capacity", "nodes", "worker-1"}))
+	assert.False(t, isTopNode([]string{"pods", "-A"}))
+	assert.False(t, isTopNode(nil))
+}
+
+func TestAllocatableArgs(t *testing.T) {
+	assert.Equal(t, []string{"nodes", "worker-1", "--selector", "pool=gpu", "-o",
+		"custom-columns=NAME:.metadata.name,CPU:.status.allocatable.cpu,MEMORY:.status.allocatable.memory", "--no-headers"},
+		allocatableArgs([]string{"node", "worker-1", "-l", "pool=gpu", "--use-protocol-buffers"}, "allocatable"))
+	assert.Equal(t, []string{"nodes", "-o",
+		"custom-columns=NAME:.metadata.name,CPU:.status.capacity.cpu,MEMORY:.status.capacity.memory", "--no-headers"},
+		allocatableArgs([]string{"--show-capacity", "no"}, "capacity"))
+}
+
+func TestJoinAllocatable(t *testing.T) {
+	usage := "" +
+		"NAME       CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%\n" +
+		"worker-1   500m         12%    2048Mi          13%\n" +
+		"worker-2   <unknown>    <unknown>   <unknown>   <unknown>\n"
+	nodes := "worker-1   3920m   16283236Ki\nworker-3   4   8Gi\n"
+	assert.Equal(t, ""+
+		"NAME       CPU(cores)   CPU%        CPU(allocatable)   MEMORY(bytes)   MEMORY%     MEMORY(allocatable)\n"+
+		"worker-1   500m         12%         3920m              2048Mi          13%         15.5Gi\n"+
+		"worker-2   <unknown>    <unknown>   <unknown>          <unknown>       <unknown>   <unknown>\n",
+		joinAllocatable(usage, nodes, "allocatable"))
+
+	assert.Equal(t, "NAME   AGE\n", joinAllocatable("NAME   AGE\n", nodes, "allocatable"))
+}
+
+func TestRunTopNodesAllocatable(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeMinimalKubeconfig(t, []string{"prod", "staging"}))
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		if subcommand == "top" {
+			assert.Equal(t, []string{"nodes"}, extraArgs)
+			if context == "staging" {
+				return "NAME   CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%\nnode-a   2            50%    4096Mi          50%\n", nil
+			}
+			return "NAME     CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%\nnode-a   900m         23%    1024Mi          13%\n", nil
+		}
+		if context == "staging" {
+			return "Error from server (Forbidden): nodes is forbidden", errors.New("exit status 1")
+		}
+		return "node-a   3900m   8000Mi\n", nil
+	})
+
+	var output string
+	stderr := captureStderr(func() {
+		output = captureStdout(func() {
+			require.NoError(t, topCmd.RunE(topCmd, []string{"nodes", "--allocatable", "--sort-by=cpu"}))
+		})
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  NAME      CPU(cores)    CPU%    CPU(allocatable)    MEMORY(bytes)    MEMORY%    MEMORY(allocatable)\n"+
+		"staging  node-a    2             50%     <unknown>           4096Mi           50%        <unknown>\n"+
+		"prod     node-a    900m          23%     3900m               1024Mi           13%        8000Mi\n", output)
+	assert.Contains(t, stderr, "Context staging: failed to get node allocatable: Error from server (Forbidden): nodes is forbidden")
+
+	assert.ErrorContains(t, topCmd.RunE(topCmd, []string{"pods", "--allocatable"}), "only applies to top node")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// podRollup is set by top pod --containers --by-pod, which sums the
+// containers of each pod into one row.
+var podRollup bool
+
+// isContainerTable reports whether a header is that of kubectl top pod
+// --containers, which names the pod under POD and the container under NAME,
+// repeating the pod on every container's row.
+func isContainerTable(header []string) bool {
+	return slices.Contains(header, "POD") && slices.Contains(header, "NAME")
+}
+
+// hasContainersFlag reports whether top args ask kubectl for container
+// metrics.
+func hasContainersFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--containers" || arg == "--containers=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// podRollupHeader replaces the container NAME column of a kubectl top pod
+// --containers header with the number of CONTAINERS.
+func podRollupHeader(header []string) []string {
+	rolled := slices.Clone(header)
+	rolled[slices.Index(header, "NAME")] = "CONTAINERS"
+	return rolled
+}
+
+// rollupContainers merges the container rows of each pod, in the order the
+// pods were first seen, into one row under podRollupHeader. CPU and memory
+// are summed, and are <unknown> if any container's are.
+func rollupContainers(header []string, rows [][]string) [][]string {
+	name := slices.Index(header, "NAME")
+	var merged [][]string
+	counts := make(map[string]int)
+	byPod := make(map[string]int)
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		var identity []string
+		for i, column := range header {
+			if i != name && !isMetricColumn(column) && i < len(row) {
+				identity = append(identity, row[i])
+			}
+		}
+		key := strings.Join(identity, "\x00")
+		index, seen := byPod[key]
+		if !seen {
+			index = len(merged)
+			byPod[key] = index
+			merged = append(merged, make([]string, len(header)))
+			copy(merged[index], row)
+		} else {
+			merged[index] = sumContainerMetrics(header, merged[index], row)
+		}
+		counts[key]++
+		merged[index][name] = strconv.Itoa(counts[key])
+	}
+	return merged
+}
+
+func sumContainerMetrics(header, pod, container []string) []string {
+	for i, column := range header {
+		var format func(float64) string
+		switch {
+		case strings.HasPrefix(column, "CPU("):
+			format = formatCPU
+		case strings.HasPrefix(column, "MEMORY("):
+			format = formatMemory
+		default:
+			continue
+		}
+		if i >= len(container) {
+			continue
+		}
+		total, totalOK := sortValue(pod[i], false)
+		value, ok := sortValue(container[i], false)
+		if !totalOK || !ok {
+			pod[i] = "<unknown>"
+			continue
+		}
+		pod[i] = format(total + value)
+	}
+	return pod
+}
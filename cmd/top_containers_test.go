@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsContainerTable(t *testing.T) {
+	assert.True(t, isContainerTable([]string{"NAMESPACE", "POD", "NAME", "CPU(cores)", "MEMORY(bytes)"}))
+	assert.False(t, isContainerTable([]string{"NAMESPACE", "NAME", "CPU(cores)", "MEMORY(bytes)"}))
+}
+
+func TestHasContainersFlag(t *testing.T) {
+	assert.True(t, hasContainersFlag([]string{"pods", "--containers", "-A"}))
+	assert.True(t, hasContainersFlag([]string{"pods", "--containers=true"}))
+	assert.False(t, hasContainersFlag([]string{"pods", "--containers=false"}))
+}
+
+func TestPodRollupHeader(t *testing.T) {
+	header := []string{"POD", "NAME", "CPU(cores)", "MEMORY(bytes)"}
+	assert.Equal(t, []string{"POD", "CONTAINERS", "CPU(cores)", "MEMORY(bytes)"}, podRollupHeader(header))
+	assert.Equal(t, "NAME", header[1])
+}
+
+func TestRollupContainers(t *testing.T) {
+	header := []string{"NAMESPACE", "POD", "NAME", "CPU(cores)", "MEMORY(bytes)"}
+	rows := [][]string{
+		{"shop", "checkout-1", "app", "250m", "200Mi"},
+		{"shop", "checkout-1", "istio-proxy", "12m", "56Mi"},
+		{"shop", "cart-1", "app", "5m", "64Mi"},
+		{},
+		{"web", "checkout-1", "app", "1", "1Gi"},
+		{"shop", "cart-1", "istio-proxy", "<unknown>", "40Mi"},
+	}
+	assert.Equal(t, [][]string{
+		{"shop", "checkout-1", "2", "262m", "256Mi"},
+		{"shop", "cart-1", "2", "<unknown>", "104Mi"},
+		{"web", "checkout-1", "1", "1", "1Gi"},
+	}, rollupContainers(header, rows))
+}
@@ -12,3 +12,61 @@ func TestTopCmd(t *testing.T) {
 	assert.Equal(t, "top", topCmd.Use)
 	assert.True(t, topCmd.DisableFlagParsing)
 }
+
+func TestTopSum(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"nodes"}, extraArgs)
+		return "NAME     CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%\nnode-1   500m         25%    2048Mi          25%\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, topCmd.RunE(topCmd, []string{"nodes", "--sum"}))
+	})
+	assert.Contains(t, output, "prod     TOTAL")
+	assert.Contains(t, output, "TOTAL    2 objects    1000m         25%     4096Mi           25%")
+	assert.False(t, showTotals)
+}
+
+func TestTopContainersByPod(t *testing.T) {
+	path := writeMinimalKubeconfig(t, []string{"prod", "staging"})
+	t.Setenv("KUBECONFIG", path)
+	fakeKubectl(t, func(context, subcommand string, extraArgs []string) (string, error) {
+		assert.Equal(t, []string{"pods", "--containers"}, extraArgs)
+		if context == "staging" {
+			return "POD          NAME   CPU(cores)   MEMORY(bytes)\ncheckout-1   app    5m           64Mi\n", nil
+		}
+		return "" +
+			"POD          NAME          CPU(cores)   MEMORY(bytes)\n" +
+			"checkout-1   app           250m         200Mi\n" +
+			"checkout-1   istio-proxy   12m          56Mi\n", nil
+	})
+
+	output := captureStdout(func() {
+		require.NoError(t, topCmd.RunE(topCmd, []string{"pods", "--containers", "--sum"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  POD             NAME           CPU(cores)    MEMORY(bytes)\n"+
+		"prod     checkout-1      app            250m          200Mi\n"+
+		"prod     checkout-1      istio-proxy    12m           56Mi\n"+
+		"staging  checkout-1      app            5m            64Mi\n"+
+		"prod     TOTAL                          262m          256Mi\n"+
+		"staging  TOTAL                          5m            64Mi\n"+
+		"TOTAL    3 containers                   267m          320Mi\n", output)
+
+	output = captureStdout(func() {
+		require.NoError(t, topCmd.RunE(topCmd, []string{"pods", "--containers", "--by-pod", "--sum"}))
+	})
+	assert.Equal(t, ""+
+		"CONTEXT  POD           CONTAINERS    CPU(cores)    MEMORY(bytes)\n"+
+		"prod     checkout-1    2             262m          256Mi\n"+
+		"staging  checkout-1    1             5m            64Mi\n"+
+		"prod     TOTAL         2             262m          256Mi\n"+
+		"staging  TOTAL         1             5m            64Mi\n"+
+		"TOTAL    2 pods        3             267m          320Mi\n", output)
+	assert.False(t, podRollup)
+
+	err := topCmd.RunE(topCmd, []string{"pods", "--by-pod"})
+	assert.EqualError(t, err, "--by-pod only applies to top pod --containers")
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// defaultTopInterval is how often top --watch collects metrics again.
+const defaultTopInterval = 15 * time.Second
+
+// topSamples holds the CPU and memory of every row of the previous top
+// --watch sample, keyed by context, the row's other cells, and metric.
+type topSamples map[string]float64
+
+// topDeltas is set by top --watch, which adds a column to merged tables with
+// each metric's change since the previous sample.
+var topDeltas topSamples
+
+// deltaMetric is a kubectl top metric whose change top --watch shows, with
+// the smallest change worth showing.
+type deltaMetric struct {
+	name      string
+	format    func(float64) string
+	threshold float64
+}
+
+var deltaMetrics = []deltaMetric{
+	{name: "CPU", format: formatCPU, threshold: 0.001},
+	{name: "MEMORY", format: formatMemory, threshold: 1 << 20},
+}
+
+// parseTopWatch extracts --watch and --interval, returning the interval at
+// which top --watch samples, or 0 without --watch.
+func parseTopWatch(args []string) (time.Duration, []string, error) {
+	watch, args := extractBoolFlag(args, "--watch")
+	intervals, args := extractStringFlag(args, "--interval")
+	if !watch {
+		if len(intervals) > 0 {
+			return 0, nil, fmt.Errorf("--interval only applies to top --watch")
+		}
+		return 0, args, nil
+	}
+	if len(intervals) == 0 {
+		return defaultTopInterval, args, nil
+	}
+	interval, err := time.ParseDuration(intervals[len(intervals)-1])
+	if err != nil || interval <= 0 {
+		return 0, nil, fmt.Errorf("invalid --interval %q: use a duration such as 15s", intervals[len(intervals)-1])
+	}
+	return interval, args, nil
+}
+
+func isMetricColumn(name string) bool {
+	for _, metric := range deltaMetrics {
+		if strings.HasPrefix(name, metric.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deltaColumns returns, for each metric in the header, the column holding
+// its usage and the last of its columns, after which its delta goes.
+func deltaColumns(header []string) (usage, last map[int]deltaMetric) {
+	usage, last = make(map[int]deltaMetric), make(map[int]deltaMetric)
+	for _, metric := range deltaMetrics {
+		found, end := -1, -1
+		for i, name := range header {
+			if found < 0 && strings.HasPrefix(name, metric.name+"(") {
+				found = i
+			}
+			if strings.HasPrefix(name, metric.name) {
+				end = i
+			}
+		}
+		if found >= 0 {
+			usage[found] = metric
+			last[end] = metric
+		}
+	}
+	return usage, last
+}
+
+// withDeltaHeader adds a ΔCPU and a ΔMEMORY column after the CPU and memory
+// columns of a kubectl top header.
+func withDeltaHeader(header []string) []string {
+	_, last := deltaColumns(header)
+	var columns []string
+	for i, name := range header {
+		columns = append(columns, name)
+		if metric, ok := last[i]; ok {
+			columns = append(columns, "Δ"+metric.name)
+		}
+	}
+	return columns
+}
+
+// annotate adds the change of each metric of a row since the previous
+// sample, as ▲ or ▼ and the amount, in the columns withDeltaHeader adds, and
+// records the row's values for the next sample. Rows that are new, or
+// whose metrics are unknown or barely changed, get a blank cell.
+func (s topSamples) annotate(context string, header, row []string) []string {
+	usage, last := deltaColumns(header)
+	identity := []string{context}
+	for i, name := range header {
+		if !isMetricColumn(name) && i < len(row) {
+			identity = append(identity, row[i])
+		}
+	}
+	key := strings.Join(identity, "\x00")
+
+	deltas := make(map[string]string)
+	for i, metric := range usage {
+		if i >= len(row) {
+			continue
+		}
+		value, ok := sortValue(row[i], false)
+		if !ok {
+			continue
+		}
+		previous, seen := s[key+"\x00"+metric.name]
+		s[key+"\x00"+metric.name] = value
+		if seen {
+			deltas[metric.name] = formatDelta(value-previous, metric)
+		}
+	}
+
+	var columns []string
+	for i := range header {
+		if i < len(row) {
+			columns = append(columns, row[i])
+		} else {
+			columns = append(columns, "")
+		}
+		if metric, ok := last[i]; ok {
+			columns = append(columns, deltas[metric.name])
+		}
+	}
+	return columns
+}
+
+// formatDelta prints a change as ▲ or ▼ and its size, in red when usage went
+// up and in green when it went down.
+func formatDelta(change float64, metric deltaMetric) string {
+	if math.Abs(change) < metric.threshold {
+		return ""
+	}
+	arrow, color := "▲", colorRed
+	if change < 0 {
+		arrow, color = "▼", colorGreen
+	}
+	delta := arrow + metric.format(math.Abs(change))
+	if colorEnabled() {
+		delta = color + delta + colorReset
+	}
+	return delta
+}
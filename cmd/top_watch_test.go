@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTopWatch(t *testing.T) {
+	interval, args, err := parseTopWatch([]string{"pods", "--watch", "-A"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultTopInterval, interval)
+	assert.Equal(t, []string{"pods", "-A"}, args)
+
+	interval, args, err = parseTopWatch([]string{"nodes", "--watch", "--interval", "30s"})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, interval)
+	assert.Equal(t, []string{"nodes"}, args)
+
+	interval, args, err = parseTopWatch([]string{"nodes"})
+	require.NoError(t, err)
+	assert.Zero(t, interval)
+	assert.Equal(t, []string{"nodes"}, args)
+
+	_, _, err = parseTopWatch([]string{"nodes", "--interval", "30s"})
+	assert.EqualError(t, err, "--interval only applies to top --watch")
+
+	_, _, err = parseTopWatch([]string{"nodes", "--watch", "--interval", "soon"})
+	assert.EqualError(t, err, `invalid --interval "soon": use a duration such as 15s`)
+}
+
+func TestWithDeltaHeader(t *testing.T) {
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "CPU(cores)", "ΔCPU", "MEMORY(bytes)", "ΔMEMORY"},
+		withDeltaHeader([]string{"NAMESPACE", "NAME", "CPU(cores)", "MEMORY(bytes)"}))
+	assert.Equal(t, []string{"NAME", "CPU(cores)", "CPU%", "CPU(allocatable)", "ΔCPU", "MEMORY(bytes)", "MEMORY%", "ΔMEMORY"},
+		withDeltaHeader([]string{"NAME", "CPU(cores)", "CPU%", "CPU(allocatable)", "MEMORY(bytes)", "MEMORY%"}))
+	assert.Equal(t, []string{"NAME", "AGE"}, withDeltaHeader([]string{"NAME", "AGE"}))
+}
+
+func TestTopSamplesAnnotate(t *testing.T) {
+	header := []string{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%"}
+	samples := make(topSamples)
+
+	assert.Equal(t, []string{"node-1", "500m", "25%", "", "2048Mi", "25%", ""},
+		samples.annotate("prod", header, []string{"node-1", "500m", "25%", "2048Mi", "25%"}))
+	assert.Equal(t, []string{"node-1", "750m", "37%", "▲250m", "1536Mi", "19%", "▼512Mi"},
+		samples.annotate("prod", header, []string{"node-1", "750m", "37%", "1536Mi", "19%"}))
+	assert.Equal(t, []string{"node-1", "750m", "37%", "", "1536Mi", "19%", ""},
+		samples.annotate("prod", header, []string{"node-1", "750m", "37%", "1536Mi", "19%"}),
+		"unchanged metrics get a blank cell")
+	assert.Equal(t, []string{"node-1", "100m", "5%", "", "512Mi", "6%", ""},
+		samples.annotate("staging", header, []string{"node-1", "100m", "5%", "512Mi", "6%"}),
+		"rows are told apart by context")
+	assert.Equal(t, []string{"node-2", "<unknown>", "<unknown>", "", "<unknown>", "<unknown>", ""},
+		samples.annotate("prod", header, []string{"node-2", "<unknown>", "<unknown>", "<unknown>", "<unknown>"}))
+}
+
+func TestFormatDelta(t *testing.T) {
+	cpu, memory := deltaMetrics[0], deltaMetrics[1]
+	assert.Equal(t, "▲50m", formatDelta(0.05, cpu))
+	assert.Equal(t, "▼12", formatDelta(-12, cpu))
+	assert.Equal(t, "", formatDelta(0.0004, cpu))
+	assert.Equal(t, "▲12Gi", formatDelta(12<<30, memory))
+	assert.Equal(t, "", formatDelta(-(512<<10), memory))
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// showTotals is set by get --totals and top --sum, which end merged tables
+// with a TOTAL row. contextTotals, set by top --sum, adds a TOTAL row per
+// context.
+var (
+	showTotals    bool
+	contextTotals bool
+)
+
+var (
+	readyCount = regexp.MustCompile(`^(\d+)/(\d+)$`)
+	countCell  = regexp.MustCompile(`^(\d+)(?: \(.*\))?$`)
+)
+
+// tableTotals returns the TOTAL row of a merged table: the number of rows
+// under the column totalsLabel picks, and the sum of every column whose cells
+// are all counts, such as READY, RESTARTS, or AVAILABLE, or kubectl top's
+// CPU and memory quantities and their percentages.
+func tableTotals(header []string, rows []tableRow) []string {
+	totals := make([]string, len(header))
+	for i, name := range header {
+		switch {
+		case name == "NAME" || name == "NAMESPACE" || name == "POD":
+		case strings.HasPrefix(name, "CPU("):
+			totals[i] = formatCPU(sumQuantity(rows, i))
+		case strings.HasPrefix(name, "MEMORY("):
+			totals[i] = formatMemory(sumQuantity(rows, i))
+		case strings.HasSuffix(name, "%") && i > 0:
+			totals[i] = sumPercentage(rows, i-1, i)
+		default:
+			totals[i] = sumColumn(rows, i)
+		}
+	}
+	label, noun := totalsLabel(header)
+	totals[label] = fmt.Sprintf("%d %ss", len(rows), noun)
+	if len(rows) == 1 {
+		totals[label] = "1 " + noun
+	}
+	return totals
+}
+
+// totalsLabel returns the column the TOTAL row counts its rows in, and what
+// a row is. kubectl top pod --containers tables have a row per container
+// and are labelled under POD, as are their --by-pod roll-ups.
+func totalsLabel(header []string) (int, string) {
+	if pod := slices.Index(header, "POD"); pod >= 0 {
+		if isContainerTable(header) {
+			return pod, "container"
+		}
+		return pod, "pod"
+	}
+	return max(slices.Index(header, "NAME"), 0), "object"
+}
+
+// sumColumn adds up a column of counts, such as 3 or "3 (5m ago)", or of
+// ready counts such as 1/2, which are summed on both sides. It returns ""
+// for any other column. Blank cells are skipped.
+func sumColumn(rows []tableRow, index int) string {
+	sum, ready, total := 0, 0, 0
+	counts, fractions := false, false
+	for _, row := range rows {
+		if index >= len(row.columns) || row.columns[index] == "" {
+			continue
+		}
+		cell := row.columns[index]
+		if match := readyCount.FindStringSubmatch(cell); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			d, _ := strconv.Atoi(match[2])
+			ready += n
+			total += d
+			fractions = true
+			continue
+		}
+		if match := countCell.FindStringSubmatch(cell); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			sum += n
+			counts = true
+			continue
+		}
+		return ""
+	}
+	switch {
+	case counts && fractions:
+		return ""
+	case fractions:
+		return fmt.Sprintf("%d/%d", ready, total)
+	case counts:
+		return strconv.Itoa(sum)
+	}
+	return ""
+}
+
+// sumQuantity adds up a column of quantities such as 250m or 128Mi, skipping
+// cells that are not one, such as <unknown>.
+func sumQuantity(rows []tableRow, index int) float64 {
+	sum := 0.0
+	for _, row := range rows {
+		if index < len(row.columns) {
+			if value, ok := sortValue(row.columns[index], false); ok {
+				sum += value
+			}
+		}
+	}
+	return sum
+}
+
+// sumPercentage returns the share of the summed capacity that the summed
+// usage in the usage column is. kubectl top node prints the share of each
+// node's allocatable, from which that node's capacity is worked out.
+func sumPercentage(rows []tableRow, usageIndex, percentIndex int) string {
+	usage, capacity := 0.0, 0.0
+	for _, row := range rows {
+		if percentIndex >= len(row.columns) {
+			continue
+		}
+		used, usedOK := sortValue(row.columns[usageIndex], false)
+		percent, percentOK := sortValue(row.columns[percentIndex], false)
+		if !usedOK || !percentOK || percent == 0 || !strings.HasSuffix(row.columns[percentIndex], "%") {
+			continue
+		}
+		usage += used
+		capacity += used * 100 / percent
+	}
+	if capacity == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%%", usage*100/capacity)
+}
+
+// formatCPU prints cores as kubectl top does, in millicores, switching to
+// cores from 10 cores up so fleet-wide sums stay readable.
+func formatCPU(cores float64) string {
+	if cores < 10 {
+		return fmt.Sprintf("%.0fm", cores*1000)
+	}
+	return strconv.FormatFloat(math.Round(cores*10)/10, 'f', -1, 64)
+}
+
+// formatMemory prints bytes as kubectl top does, in Mi, switching to Gi and
+// Ti for larger sums.
+func formatMemory(bytes float64) string {
+	switch {
+	case bytes >= 1<<40:
+		return strconv.FormatFloat(math.Round(bytes/(1<<40)*10)/10, 'f', -1, 64) + "Ti"
+	case bytes >= 10<<30:
+		return strconv.FormatFloat(math.Round(bytes/(1<<30)*10)/10, 'f', -1, 64) + "Gi"
+	}
+	return fmt.Sprintf("%.0fMi", bytes/(1<<20))
+}
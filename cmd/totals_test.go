@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableTotals(t *testing.T) {
+	header := []string{"NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE"}
+	rows := []tableRow{
+		{context: "prod", columns: []string{"payments", "web-1", "1/1", "Running", "0", "3d"}},
+		{context: "prod", columns: []string{"payments", "web-2", "0/2", "CrashLoopBackOff", "12 (1m ago)", "3d"}},
+		{context: "staging", columns: []string{"payments", "web-1", "1/1", "Running", "3", "1h"}},
+	}
+	assert.Equal(t, []string{"", "3 objects", "2/4", "", "15", ""}, tableTotals(header, rows))
+	assert.Equal(t, []string{"1 object", ""}, tableTotals([]string{"TYPE", "AGE"}, []tableRow{{columns: []string{"Opaque", "3d"}}}))
+}
+
+func TestSumColumn(t *testing.T) {
+	rows := func(cells ...string) []tableRow {
+		var rows []tableRow
+		for _, cell := range cells {
+			rows = append(rows, tableRow{columns: []string{cell}})
+		}
+		return rows
+	}
+	assert.Equal(t, "6", sumColumn(rows("1", "", "5"), 0))
+	assert.Equal(t, "3/5", sumColumn(rows("1/2", "2/3"), 0))
+	assert.Equal(t, "", sumColumn(rows("1", "<none>"), 0))
+	assert.Equal(t, "", sumColumn(rows("1", "1/2"), 0))
+	assert.Equal(t, "", sumColumn(rows("", ""), 0))
+}
+
+func TestTableTotalsTopNodes(t *testing.T) {
+	header := []string{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%"}
+	rows := []tableRow{
+		{context: "prod", columns: []string{"node-1", "500m", "25%", "2048Mi", "25%"}},
+		{context: "prod", columns: []string{"node-2", "1500m", "75%", "6Gi", "75%"}},
+		{context: "staging", columns: []string{"node-1", "<unknown>", "<unknown>", "<unknown>", "<unknown>"}},
+	}
+	assert.Equal(t, []string{"3 objects", "2000m", "50%", "8192Mi", "50%"}, tableTotals(header, rows))
+}
+
+func TestFormatQuantities(t *testing.T) {
+	assert.Equal(t, "250m", formatCPU(0.25))
+	assert.Equal(t, "9999m", formatCPU(9.999))
+	assert.Equal(t, "12.5", formatCPU(12.5))
+	assert.Equal(t, "128Mi", formatMemory(128<<20))
+	assert.Equal(t, "9216Mi", formatMemory(9<<30))
+	assert.Equal(t, "64Gi", formatMemory(64<<30))
+	assert.Equal(t, "1.5Ti", formatMemory(1.5*(1<<40)))
+}
+
+func TestTotalsLabel(t *testing.T) {
+	column, noun := totalsLabel([]string{"NAMESPACE", "POD", "NAME", "CPU(cores)", "MEMORY(bytes)"})
+	assert.Equal(t, 1, column)
+	assert.Equal(t, "container", noun)
+	column, noun = totalsLabel([]string{"POD", "CONTAINERS", "CPU(cores)", "MEMORY(bytes)"})
+	assert.Equal(t, 0, column)
+	assert.Equal(t, "pod", noun)
+	column, noun = totalsLabel([]string{"NAMESPACE", "NAME", "AGE"})
+	assert.Equal(t, 1, column)
+	assert.Equal(t, "object", noun)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultUnhealthyRestarts is how many restarts a pod may have before
+// --unhealthy lists it even when it is running.
+const defaultUnhealthyRestarts = 5
+
+// unhealthyRestarts is the restart threshold of get --unhealthy, which
+// filters merged pod tables when it is not negative.
+var unhealthyRestarts = -1
+
+// parseUnhealthy extracts --unhealthy and --unhealthy-restarts, which
+// implies it, returning the restart threshold, or -1 without either flag.
+func parseUnhealthy(args []string) (int, []string, error) {
+	unhealthy, args := extractBoolFlag(args, "--unhealthy")
+	values, args := extractStringFlag(args, "--unhealthy-restarts")
+	if len(values) == 0 {
+		if unhealthy {
+			return defaultUnhealthyRestarts, args, nil
+		}
+		return -1, args, nil
+	}
+	restarts, err := strconv.Atoi(values[len(values)-1])
+	if err != nil || restarts < 0 {
+		return 0, nil, fmt.Errorf("invalid --unhealthy-restarts %q: use a number of restarts", values[len(values)-1])
+	}
+	return restarts, args, nil
+}
+
+// unhealthyPod reports whether a row of a pod table is for a pod that is
+// neither Running nor Completed, is running with containers that are not
+// ready, or has restarted more than restarts times.
+func unhealthyPod(header, row []string, restarts int) bool {
+	cell := func(name string) string {
+		if i := slices.Index(header, name); i >= 0 && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	status := cell("STATUS")
+	if status != "Running" && status != "Completed" {
+		return true
+	}
+	if ready, total, ok := strings.Cut(cell("READY"), "/"); ok && status == "Running" && ready != total {
+		return true
+	}
+	// RESTARTS reads like "3 (5m ago)".
+	count, _, _ := strings.Cut(cell("RESTARTS"), " ")
+	n, err := strconv.Atoi(count)
+	return err == nil && n > restarts
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnhealthy(t *testing.T) {
+	restarts, args, err := parseUnhealthy([]string{"pods", "-A"})
+	require.NoError(t, err)
+	assert.Equal(t, -1, restarts)
+	assert.Equal(t, []string{"pods", "-A"}, args)
+
+	restarts, args, err = parseUnhealthy([]string{"pods", "--unhealthy"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultUnhealthyRestarts, restarts)
+	assert.Equal(t, []string{"pods"}, args)
+
+	restarts, args, err = parseUnhealthy([]string{"pods", "--unhealthy-restarts=0"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, restarts)
+	assert.Equal(t, []string{"pods"}, args)
+
+	_, _, err = parseUnhealthy([]string{"pods", "--unhealthy-restarts", "-1"})
+	assert.Error(t, err)
+}
+
+func TestUnhealthyPod(t *testing.T) {
+	header := []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
+	tests := []struct {
+		row       []string
+		unhealthy bool
+	}{
+		{[]string{"web", "1/1", "Running", "0", "3d"}, false},
+		{[]string{"job", "0/1", "Completed", "0", "3d"}, false},
+		{[]string{"web", "0/1", "CrashLoopBackOff", "12 (30s ago)", "3d"}, true},
+		{[]string{"web", "0/1", "Pending", "0", "3d"}, true},
+		{[]string{"web", "1/2", "Running", "0", "3d"}, true},
+		{[]string{"web", "1/1", "Running", "5 (2h ago)", "3d"}, false},
+		{[]string{"web", "1/1", "Running", "6 (2h ago)", "3d"}, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.unhealthy, unhealthyPod(header, tt.row, 5), "%v", tt.row)
+	}
+}
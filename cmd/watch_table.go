@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// watchHighlight is how long changed cells stay highlighted.
+const watchHighlight = 3 * time.Second
+
+type watchRow struct {
+	context string
+	columns []string
+	// changed holds when each column last changed; new rows are changed in
+	// every column.
+	changed []time.Time
+}
+
+// watchTable is the state of get -w --tui: one row per object, keyed by
+// context, namespace, and name, updated in place as kubectl reports changes.
+type watchTable struct {
+	resource string
+	header   []string
+	// events is set when kubectl prints an EVENT column, from
+	// --output-watch-events, which is used to drop deleted objects.
+	events  bool
+	rows    map[string]*watchRow
+	notices []string
+	ended   bool
+
+	scroll    int
+	page      int
+	filter    string
+	filtering bool
+}
+
+func newWatchTable(resource string) *watchTable {
+	return &watchTable{resource: resource, rows: make(map[string]*watchRow), page: 1}
+}
+
+// setHeader takes a header line; the first context to print one wins.
+func (t *watchTable) setHeader(line string) {
+	if t.header != nil {
+		return
+	}
+	t.header = dashColumnSeparator.Split(strings.TrimSpace(line), -1)
+	if len(t.header) > 1 && t.header[0] == "EVENT" {
+		t.events = true
+		t.header = t.header[1:]
+	}
+}
+
+func (t *watchTable) column(name string) int {
+	return slices.Index(t.header, name)
+}
+
+// update applies a row kubectl printed for context, marking the cells that
+// differ from the object's previous row.
+func (t *watchTable) update(context, line string, now time.Time) {
+	columns := dashColumnSeparator.Split(strings.TrimSpace(line), -1)
+	event := ""
+	if t.events && len(columns) > 1 {
+		event, columns = columns[0], columns[1:]
+	}
+
+	name := columns[0]
+	if i := t.column("NAME"); i >= 0 && i < len(columns) {
+		name = columns[i]
+	}
+	namespace := ""
+	if i := t.column("NAMESPACE"); i >= 0 && i < len(columns) {
+		namespace = columns[i]
+	}
+	key := context + "/" + namespace + "/" + name
+
+	if event == "DELETED" {
+		delete(t.rows, key)
+		return
+	}
+	row := t.rows[key]
+	if row == nil {
+		row = &watchRow{context: context}
+		t.rows[key] = row
+	}
+	changed := make([]time.Time, len(columns))
+	for i, cell := range columns {
+		if i < len(row.columns) && row.columns[i] == cell {
+			changed[i] = row.changed[i]
+		} else {
+			changed[i] = now
+		}
+	}
+	row.columns = columns
+	row.changed = changed
+}
+
+func (t *watchTable) notice(text string) {
+	t.notices = append(t.notices, text)
+	if len(t.notices) > logViewerNotices {
+		t.notices = t.notices[len(t.notices)-logViewerNotices:]
+	}
+}
+
+// visibleRows returns the rows in context, namespace, and name order, of
+// the contexts matching the filter.
+func (t *watchTable) visibleRows() []*watchRow {
+	keys := make([]string, 0, len(t.rows))
+	for key, row := range t.rows {
+		if t.filter == "" || strings.Contains(row.context, t.filter) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	rows := make([]*watchRow, len(keys))
+	for i, key := range keys {
+		rows[i] = t.rows[key]
+	}
+	return rows
+}
+
+// handleKey applies a keypress and reports whether the user quit.
+func (t *watchTable) handleKey(key string) bool {
+	if t.filtering {
+		switch key {
+		case "\r", "\x1b":
+			t.filtering = false
+		case "\x7f", "\b":
+			if len(t.filter) > 0 {
+				t.filter = t.filter[:len(t.filter)-1]
+			}
+		case "\x03":
+			return true
+		default:
+			if len(key) == 1 && key[0] >= ' ' {
+				t.filter += key
+			}
+		}
+		t.scroll = 0
+		return false
+	}
+
+	switch key {
+	case "q", "\x03":
+		return true
+	case "/":
+		t.filtering = true
+	case "\x1b":
+		t.filter = ""
+		t.scroll = 0
+	case "\x1b[A", "k":
+		t.scroll = max(t.scroll-1, 0)
+	case "\x1b[B", "j":
+		t.scroll++
+	case "\x1b[5~":
+		t.scroll = max(t.scroll-t.page, 0)
+	case "\x1b[6~":
+		t.scroll += t.page
+	}
+	return false
+}
+
+// render draws the table as height lines at most, highlighting the cells
+// that changed within watchHighlight of now.
+func (t *watchTable) render(width, height int, now time.Time) []string {
+	title := fmt.Sprintf("kubectl x get -w: %s  (%d objects)", t.resource, len(t.rows))
+	if t.ended {
+		title += "  all watches ended"
+	}
+	if t.filtering || t.filter != "" {
+		title += fmt.Sprintf("  filter: %s", t.filter)
+		if t.filtering {
+			title += "_"
+		}
+	}
+	lines := []string{truncateVisible(title, width)}
+
+	rows := t.visibleRows()
+	header := append([]string{"CONTEXT"}, t.header...)
+	table := [][]string{header}
+	for _, row := range rows {
+		table = append(table, append([]string{row.context}, row.columns...))
+	}
+	widths := columnWidths(table)
+	if t.header != nil {
+		lines = append(lines, truncateVisible(alignedLine(header, widths, "   "), width))
+	}
+
+	t.page = max(height-len(lines)-1-len(t.notices), 1)
+	t.scroll = min(t.scroll, max(len(rows)-t.page, 0))
+	for _, row := range rows[t.scroll:min(t.scroll+t.page, len(rows))] {
+		cells := []string{row.context}
+		for i, cell := range row.columns {
+			if now.Sub(row.changed[i]) < watchHighlight {
+				cell = colorHighlight + cell + colorReset
+			}
+			cells = append(cells, cell)
+		}
+		lines = append(lines, truncateVisible(alignedLine(cells, widths, "   "), width))
+	}
+
+	for _, notice := range t.notices {
+		lines = append(lines, colorGray+truncateVisible(notice, width)+colorReset)
+	}
+	return append(lines, colorGray+truncateVisible("up/down scroll  / filter by context  q quit", width)+colorReset)
+}
+
+// truncateVisible cuts text to width visible columns, keeping color codes
+// intact. A width of 0 leaves text as it is.
+func truncateVisible(text string, width int) string {
+	if width <= 0 || visibleWidth(text) <= width {
+		return text
+	}
+	var cut strings.Builder
+	visible := 0
+	for i := 0; i < len(text) && visible < width; {
+		if match := colorCode.FindStringIndex(text[i:]); match != nil && match[0] == 0 {
+			cut.WriteString(text[i : i+match[1]])
+			i += match[1]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		cut.WriteRune(r)
+		i += size
+		visible++
+	}
+	if strings.Contains(text, "\033[") {
+		cut.WriteString(colorReset)
+	}
+	return cut.String()
+}
+
+// runWatchTable runs kubectl get -w in every context into a watchTable
+// until the user quits, which stops the watches.
+func runWatchTable(args []string) error {
+	if err := rejectOnlyErrors("get --watch"); err != nil {
+		return err
+	}
+	contexts, err := getContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts found in kubeconfig")
+	}
+	notices, restore, err := openViewerTerminal()
+	if err != nil {
+		return err
+	}
+	defer restore()
+	warnNamespaceSkew(contexts, "get", args)
+
+	type watchLine struct {
+		context string
+		text    string
+		header  bool
+	}
+	lines := make(chan watchLine, 1024)
+	stop := make(chan struct{})
+	opts := streamOptions{stop: stop}
+	opts.handleStdout = func(reader io.Reader, coloredCtx, padding string) {
+		context := stripColor(coloredCtx)
+		header := true
+		scanner := newLineScanner(reader)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			select {
+			case lines <- watchLine{context: context, text: scanner.Text(), header: header}:
+			case <-stop:
+			}
+			header = false
+		}
+		finishScan(scanner, reader, coloredCtx)
+	}
+
+	streamed := make(chan error, 1)
+	go func() {
+		streamed <- streamAcrossContexts(contexts, "get", func(int, string) []string { return args }, opts)
+	}()
+
+	_, resource := extractBoolFlag(args, "-w", "--watch", "--watch-only")
+	table := newWatchTable(strings.Join(resource, " "))
+	draw := func() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil || height == 0 {
+			width, height = 0, 24
+		}
+		fmt.Print("\033[H\033[2J" + strings.Join(table.render(width, height, time.Now()), "\r\n"))
+	}
+
+	keys := make(chan string)
+	go readKeys(keys)
+	ticker := time.NewTicker(logViewerRedraw)
+	defer ticker.Stop()
+
+	draw()
+	dirty := false
+	var changed time.Time
+	for {
+		select {
+		case line := <-lines:
+			if line.header {
+				table.setHeader(line.text)
+			} else {
+				changed = time.Now()
+				table.update(line.context, line.text, changed)
+			}
+			dirty = true
+			continue
+		case notice := <-notices:
+			table.notice(notice)
+			dirty = true
+			continue
+		case <-streamed:
+			streamed = nil
+			table.ended = true
+		case <-ticker.C:
+			// Keep redrawing until the last highlight has faded.
+			if !dirty && time.Since(changed) > watchHighlight+logViewerRedraw {
+				continue
+			}
+		case key, ok := <-keys:
+			if !ok || table.handleKey(key) {
+				close(stop)
+				if streamed != nil {
+					<-streamed
+				}
+				return nil
+			}
+		}
+		draw()
+		dirty = false
+	}
+}
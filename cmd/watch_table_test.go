@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchTableUpdate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	table := newWatchTable("pods")
+	table.setHeader("NAMESPACE   NAME    READY   STATUS")
+	table.setHeader("NAME   READY")
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "READY", "STATUS"}, table.header)
+
+	table.update("prod", "default     web-1   0/1     Pending", start)
+	table.update("staging", "default     web-1   1/1     Running", start)
+	require.Len(t, table.rows, 2)
+
+	later := start.Add(5 * time.Second)
+	table.update("prod", "default   web-1   1/1   Running", later)
+	require.Len(t, table.rows, 2)
+	row := table.rows["prod/default/web-1"]
+	assert.Equal(t, []string{"default", "web-1", "1/1", "Running"}, row.columns)
+	assert.Equal(t, []time.Time{start, start, later, later}, row.changed)
+}
+
+func TestWatchTableEvents(t *testing.T) {
+	table := newWatchTable("pods")
+	table.setHeader("EVENT    NAME    READY")
+	assert.True(t, table.events)
+	assert.Equal(t, []string{"NAME", "READY"}, table.header)
+
+	now := time.Now()
+	table.update("prod", "ADDED    web-1   1/1", now)
+	require.Contains(t, table.rows, "prod//web-1")
+	assert.Equal(t, []string{"web-1", "1/1"}, table.rows["prod//web-1"].columns)
+
+	table.update("prod", "DELETED   web-1   0/1", now)
+	assert.Empty(t, table.rows)
+}
+
+func TestWatchTableRender(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	table := newWatchTable("pods")
+	table.setHeader("NAME    READY   STATUS")
+	table.update("staging", "web-1   1/1     Running", start)
+	table.update("prod", "web-1   0/1     Pending", start)
+	table.update("prod", "web-1   1/1     Running", start.Add(10*time.Second))
+
+	lines := table.render(0, 10, start.Add(11*time.Second))
+	assert.Equal(t, "kubectl x get -w: pods  (2 objects)", lines[0])
+	assert.Equal(t, "CONTEXT   NAME    READY   STATUS", lines[1])
+	assert.Equal(t, "prod      web-1   "+colorHighlight+"1/1"+colorReset+"     "+colorHighlight+"Running"+colorReset, lines[2])
+	assert.Equal(t, "staging   web-1   1/1     Running", lines[3])
+	assert.Contains(t, lines[len(lines)-1], "q quit")
+
+	lines = table.render(0, 10, start.Add(time.Minute))
+	assert.Equal(t, "prod      web-1   1/1     Running", lines[2])
+
+	table.handleKey("/")
+	table.handleKey("s")
+	table.handleKey("\r")
+	lines = table.render(0, 10, start.Add(time.Minute))
+	assert.Contains(t, lines[0], "filter: s")
+	assert.Equal(t, "staging   web-1   1/1     Running", lines[2])
+	assert.True(t, table.handleKey("q"))
+}
+
+func TestWatchTableScroll(t *testing.T) {
+	table := newWatchTable("pods")
+	table.setHeader("NAME")
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		table.update("prod", name, time.Time{})
+	}
+
+	lines := table.render(0, 5, time.Now())
+	assert.Equal(t, []string{"prod      a", "prod      b"}, lines[2:4])
+
+	table.handleKey("j")
+	table.handleKey("\x1b[6~")
+	table.handleKey("\x1b[6~")
+	lines = table.render(0, 5, time.Now())
+	assert.Equal(t, []string{"prod      d", "prod      e"}, lines[2:4])
+}
+
+func TestTruncateVisible(t *testing.T) {
+	assert.Equal(t, "hello", truncateVisible("hello", 0))
+	assert.Equal(t, "hello", truncateVisible("hello", 5))
+	assert.Equal(t, "hel", truncateVisible("hello", 3))
+	assert.Equal(t, "a"+colorHighlight+"bc"+colorReset, truncateVisible("a"+colorHighlight+"bcd"+colorReset+"e", 3))
+	assert.Equal(t, "hé", truncateVisible("héllo", 2))
+}